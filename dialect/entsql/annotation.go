@@ -7,6 +7,7 @@ package entsql
 import (
 	"errors"
 	"fmt"
+	"slices"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/schema"
@@ -193,6 +194,141 @@ type Annotation struct {
 	//	}
 	ViewFor map[string]string `json:"view_for,omitempty"`
 
+	// OptimisticLock marks the annotated integer field as the version field of its type.
+	// Generated UpdateOne builders update the record only if its version was not changed
+	// since it was loaded, and increment it. For example:
+	//
+	//	entsql.Annotation{
+	//		OptimisticLock: &entsql.OptimisticLockOptions{},
+	//	}
+	//
+	OptimisticLock *OptimisticLockOptions `json:"optimistic_lock,omitempty"`
+
+	// SoftDelete marks the annotated time field as the deletion time of its type. Generated
+	// delete builders set the field instead of deleting the records, and queries skip records
+	// that were marked as deleted. For example:
+	//
+	//	entsql.Annotation{
+	//		SoftDelete: true,
+	//	}
+	//
+	SoftDelete bool `json:"soft_delete,omitempty"`
+
+	// History enables the row history of the annotated type. The migration creates a history
+	// table that mirrors the columns of the type table, and the generated hooks record a
+	// revision of the entities on every mutation. For example:
+	//
+	//	entsql.Annotation{
+	//		History: true,
+	//	}
+	//
+	History bool `json:"history,omitempty"`
+
+	// EnumType defines the name of a native enum type for the annotated enum field in PostgreSQL.
+	// The migration creates the type using CREATE TYPE ... AS ENUM, and adds the new values of the
+	// field to it. Other dialects ignore this option. For example:
+	//
+	//	entsql.Annotation{
+	//		EnumType: "status",
+	//	}
+	//
+	EnumType string `json:"enum_type,omitempty"`
+
+	// Array stores the annotated slice field (e.g. field.Strings) as a native array in PostgreSQL,
+	// instead of a JSON column. Other dialects keep storing the field as a JSON array. For example:
+	//
+	//	entsql.Annotation{
+	//		Array: true,
+	//	}
+	//
+	Array bool `json:"array,omitempty"`
+
+	// DurationFormat defines the storage format of the annotated duration field (see field.Duration).
+	// By default, durations are stored as interval in PostgreSQL and as bigint in other dialects.
+	// For example:
+	//
+	//	entsql.Annotation{
+	//		DurationFormat: sql.DurationTime,
+	//	}
+	//
+	DurationFormat sql.DurationFormat `json:"duration_format,omitempty"`
+
+	// Partition defines the partitioning of the annotated schema table. The migration
+	// creates the table with a PARTITION BY clause (PostgreSQL and MySQL only). For example:
+	//
+	//	entsql.Annotation{
+	//		Partition: &entsql.PartitionOptions{
+	//			Type:    entsql.PartitionRange,
+	//			Columns: []string{"created_at"},
+	//		},
+	//	}
+	//
+	Partition *PartitionOptions `json:"partition,omitempty"`
+
+	// Generated defines the annotated field as a generated column, whose value is computed
+	// by the database from the given expression. Generated fields are read-only, and are
+	// not settable by the create and update builders. For example:
+	//
+	//	entsql.Annotation{
+	//		Generated: &entsql.GeneratedOptions{
+	//			Expr: "price * quantity",
+	//			Type: entsql.Stored,
+	//		},
+	//	}
+	//
+	Generated *GeneratedOptions `json:"generated,omitempty"`
+
+	// Triggers defines the triggers of the annotated schema table. The migration creates
+	// the triggers, and replaces them when their definitions change. For example:
+	//
+	//	entsql.Annotation{
+	//		Triggers: []*entsql.TriggerOptions{
+	//			{
+	//				Name:   "users_set_updated_at",
+	//				Timing: entsql.TriggerBefore,
+	//				Event:  "UPDATE",
+	//				Body:   "EXECUTE FUNCTION set_updated_at()",
+	//			},
+	//		},
+	//	}
+	//
+	Triggers []*TriggerOptions `json:"triggers,omitempty"`
+
+	// Functions defines stored functions that are created alongside the annotated schema
+	// table (PostgreSQL only). The migration creates the functions, and replaces them when
+	// their definitions change. For example:
+	//
+	//	entsql.Annotation{
+	//		Functions: []*entsql.FunctionOptions{
+	//			{
+	//				Name:    "set_updated_at",
+	//				Returns: "trigger",
+	//				Body:    "BEGIN NEW.updated_at = now(); RETURN NEW; END",
+	//			},
+	//		},
+	//	}
+	//
+	Functions []*FunctionOptions `json:"functions,omitempty"`
+
+	// RowSecurity enables row-level security on the annotated schema table, and defines
+	// its policies (PostgreSQL only). The migration enables row-level security on the
+	// table, creates its policies, and replaces them when their definitions change.
+	// For example:
+	//
+	//	entsql.Annotation{
+	//		RowSecurity: &entsql.RowSecurityOptions{
+	//			Force: true,
+	//			Policies: []*entsql.PolicyOptions{
+	//				{
+	//					Name:  "tenant_isolation",
+	//					Using: "tenant_id = current_setting('app.current_tenant')::integer",
+	//				},
+	//			},
+	//		},
+	//	}
+	//
+	RowSecurity *RowSecurityOptions `json:"row_security,omitempty"`
+
 	// error occurs during annotation build. This field is not
 	// serialized to JSON and used only by the codegen loader.
 	err error
@@ -390,6 +526,373 @@ func IncrementStart(i int) *Annotation {
 	}
 }
 
+// OptimisticLock marks the field as the version field that is used for optimistic locking.
+// Updating a single entity using the generated UpdateOne builders adds a version check to
+// the UPDATE statement and increments the version. If the version was changed since the
+// entity was loaded, the update fails with a *sqlgraph.StaleObjectError.
+//
+//	func (T) Fields() []ent.Field {
+//		return []ent.Field{
+//			field.Int("version").
+//				Default(1).
+//				Annotations(
+//					entsql.OptimisticLock(),
+//				),
+//		}
+//	}
+//
+// By default, bulk updates increment the version of all matched records. Use the
+// RefuseBulkUpdate option to fail bulk updates of the type instead.
+func OptimisticLock(opts ...OptimisticLockOption) *Annotation {
+	o := &OptimisticLockOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Annotation{
+		OptimisticLock: o,
+	}
+}
+
+type (
+	// OptimisticLockOptions holds the configuration of optimistic locking.
+	OptimisticLockOptions struct {
+		// RefuseBulkUpdate indicates that bulk updates of the type
+		// should fail instead of incrementing the version of all
+		// the matched records.
+		RefuseBulkUpdate bool `json:"refuse_bulk_update,omitempty"`
+	}
+
+	// OptimisticLockOption configures the OptimisticLock annotation.
+	OptimisticLockOption func(*OptimisticLockOptions)
+)
+
+// RefuseBulkUpdate returns an option that makes bulk updates of the type fail.
+func RefuseBulkUpdate() OptimisticLockOption {
+	return func(o *OptimisticLockOptions) {
+		o.RefuseBulkUpdate = true
+	}
+}
+
+// SoftDelete marks the field as the deletion time of the type. Generated Delete and DeleteOne
+// builders set the field to the current time instead of deleting the records, and queries,
+// traversals and eager-loading skip the records that were marked as deleted.
+//
+//	func (T) Fields() []ent.Field {
+//		return []ent.Field{
+//			field.Time("deleted_at").
+//				Optional().
+//				Nillable().
+//				Annotations(
+//					entsql.SoftDelete(),
+//				),
+//		}
+//	}
+//
+// Use the generated WithDeleted method of the query builders in order to include the deleted
+// records, and the HardDelete method of the clients in order to delete records permanently.
+func SoftDelete() *Annotation {
+	return &Annotation{
+		SoftDelete: true,
+	}
+}
+
+// History enables the row history of the type. The migration creates an "<table>_history" table
+// that mirrors the columns of the type table, with the operation, actor and time of each revision,
+// and the generated hooks record the state of the entities on every create, update and delete.
+//
+//	func (T) Annotations() []schema.Annotation {
+//		return []schema.Annotation{
+//			entsql.History(),
+//		}
+//	}
+//
+// The revisions are queried using the generated History method of the client, and the actor of
+// the mutations is set in their context using history.WithActor.
+func History() *Annotation {
+	return &Annotation{
+		History: true,
+	}
+}
+
+// EnumType defines the name of a native enum type (PostgreSQL only) for the enum field. The
+// migration creates the type using CREATE TYPE ... AS ENUM, adds the new values of the field
+// to it using ALTER TYPE ... ADD VALUE, and drops it when it is no longer used by the schema.
+//
+//	field.Enum("status").
+//		Values("active", "inactive").
+//		Annotations(
+//			entsql.EnumType("status"),
+//		)
+//
+// Multiple fields can share the same type, if they are defined with the same values.
+func EnumType(name string) *Annotation {
+	return &Annotation{
+		EnumType: name,
+	}
+}
+
+// Array stores the slice field as a native array in PostgreSQL (e.g. text[] or bigint[]),
+// and as a JSON array in other dialects. The generated predicates of the field allow
+// checking its elements and length, using the array operators in PostgreSQL.
+//
+//	field.Strings("tags").
+//		Annotations(
+//			entsql.Array(),
+//		)
+//
+// Array fields support slices of strings, integers, floats and booleans.
+func Array() *Annotation {
+	return &Annotation{
+		Array: true,
+	}
+}
+
+// DurationFormat sets the storage format of the duration field. By default, durations are
+// stored as interval in PostgreSQL, and as bigint (nanoseconds) in other dialects. Use the
+// sql.DurationNanoseconds format for storing them as bigint in PostgreSQL as well, or the
+// sql.DurationTime format for storing them as TIME in MySQL.
+//
+//	field.Duration("timeout").
+//		Annotations(
+//			entsql.DurationFormat(sql.DurationTime),
+//		)
+func DurationFormat(f sql.DurationFormat) *Annotation {
+	return &Annotation{
+		DurationFormat: f,
+	}
+}
+
+// Partition defines the partitioning of the schema table, using the given partition type and key columns.
+// The migration creates the table with a PARTITION BY clause, and reports an error if the partition
+// key of an existing table was changed.
+//
+//	func (Event) Annotations() []schema.Annotation {
+//		return []schema.Annotation{
+//			entsql.Partition(entsql.PartitionRange, "created_at"),
+//		}
+//	}
+//
+// Note that the primary key and the unique indexes of a partitioned table must include the key columns.
+// In PostgreSQL, the partitions themselves are tables that are created separately, and in MySQL, RANGE tables
+// are created with a single MAXVALUE partition named "pmax" that can be reorganized later. LIST partitioning is
+// not supported by MySQL, and HASH partitioning is emitted as PARTITION BY KEY. Other dialects ignore this option.
+// See schema.CreatePartition for creating the partitions of the table.
+func Partition(t PartitionType, columns ...string) *Annotation {
+	return &Annotation{
+		Partition: &PartitionOptions{
+			Type:    t,
+			Columns: columns,
+		},
+	}
+}
+
+type (
+	// PartitionType defines the partitioning strategy of a table.
+	PartitionType string
+
+	// PartitionOptions holds the partitioning configuration of a table.
+	PartitionOptions struct {
+		// Type of the partitioning. One of RANGE, LIST or HASH.
+		Type PartitionType `json:"type"`
+		// Columns of the partition key.
+		Columns []string `json:"columns"`
+	}
+)
+
+// Partitioning strategies.
+const (
+	PartitionRange PartitionType = "RANGE"
+	PartitionList  PartitionType = "LIST"
+	PartitionHash  PartitionType = "HASH"
+)
+
+// Generated defines the field as a generated column, whose value is computed by the database
+// from the given SQL expression. For example:
+//
+//	field.Float("total").
+//		Annotations(
+//			entsql.Generated("price * quantity", entsql.Stored),
+//		)
+//
+// Generated fields are read-only. The create and update builders do not have setters for them,
+// but they are loaded by queries, and can be used in predicates and ordering. Note that PostgreSQL
+// supports only stored generated columns.
+func Generated(expr string, t GeneratedType) *Annotation {
+	return &Annotation{
+		Generated: &GeneratedOptions{
+			Expr: expr,
+			Type: t,
+		},
+	}
+}
+
+type (
+	// GeneratedType defines the storage type of a generated column.
+	GeneratedType string
+
+	// GeneratedOptions holds the configuration of a generated column.
+	GeneratedOptions struct {
+		// Expr is the SQL expression that computes the column value.
+		Expr string `json:"expr"`
+		// Type defines if the column value is stored or computed on read.
+		Type GeneratedType `json:"type,omitempty"`
+	}
+)
+
+// Storage types of generated columns.
+const (
+	Stored  GeneratedType = "STORED"
+	Virtual GeneratedType = "VIRTUAL"
+)
+
+// Trigger defines a row-level trigger on the schema table. The body is the action of the trigger,
+// and its syntax depends on the dialect. In PostgreSQL, it executes a trigger function, and in SQLite,
+// it holds the statements that are executed by the trigger (i.e. the content of its BEGIN ... END block).
+//
+//	func (User) Annotations() []schema.Annotation {
+//		return []schema.Annotation{
+//			entsql.Function("set_updated_at", "trigger", "BEGIN NEW.updated_at = now(); RETURN NEW; END"),
+//			entsql.Trigger("users_set_updated_at", entsql.TriggerBefore, "UPDATE", "EXECUTE FUNCTION set_updated_at()"),
+//		}
+//	}
+//
+//	CREATE TRIGGER "users_set_updated_at" BEFORE UPDATE ON "users" FOR EACH ROW EXECUTE FUNCTION set_updated_at()
+//
+// Use TriggerFor for defining triggers that are applied only to a specific dialect.
+func Trigger(name string, timing TriggerTiming, event, body string) *Annotation {
+	return &Annotation{
+		Triggers: []*TriggerOptions{
+			{Name: name, Timing: timing, Event: event, Body: body},
+		},
+	}
+}
+
+// TriggerFor is like Trigger, but defines a trigger that is applied only to the given dialect.
+//
+//	func (User) Annotations() []schema.Annotation {
+//		return []schema.Annotation{
+//			entsql.TriggerFor(dialect.Postgres, "users_set_updated_at", entsql.TriggerBefore, "UPDATE", "EXECUTE FUNCTION set_updated_at()"),
+//			entsql.TriggerFor(dialect.SQLite, "users_set_updated_at", entsql.TriggerAfter, "UPDATE", "UPDATE users SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;"),
+//		}
+//	}
+func TriggerFor(dialect, name string, timing TriggerTiming, event, body string) *Annotation {
+	ant := Trigger(name, timing, event, body)
+	ant.Triggers[0].Dialect = dialect
+	return ant
+}
+
+// Function defines a stored function (PostgreSQL only) with the given name, return type and body. The
+// function takes no arguments and is written in PL/pgSQL, unless configured otherwise using FunctionOptions.
+//
+//	entsql.Function("set_updated_at", "trigger", "BEGIN NEW.updated_at = now(); RETURN NEW; END")
+//
+//	CREATE OR REPLACE FUNCTION "set_updated_at"() RETURNS trigger LANGUAGE plpgsql AS $$BEGIN NEW.updated_at = now(); RETURN NEW; END$$
+//
+// Functions are schema objects, and therefore, their names must be unique in the schema. Other dialects
+// ignore this option.
+func Function(name, returns, body string) *Annotation {
+	return &Annotation{
+		Functions: []*FunctionOptions{
+			{Name: name, Returns: returns, Body: body},
+		},
+	}
+}
+
+type (
+	// TriggerTiming defines when a trigger is fired, relative to its event.
+	TriggerTiming string
+
+	// TriggerOptions holds the configuration of a table trigger.
+	TriggerOptions struct {
+		// Name of the trigger.
+		Name string `json:"name"`
+		// Timing of the trigger. One of BEFORE, AFTER or INSTEAD OF.
+		Timing TriggerTiming `json:"timing"`
+		// Event that fires the trigger. For example, "INSERT",
+		// "UPDATE", "INSERT OR UPDATE" or "UPDATE OF name".
+		Event string `json:"event"`
+		// Body is the action of the trigger.
+		Body string `json:"body"`
+		// Dialect limits the trigger to the given dialect, if set.
+		Dialect string `json:"dialect,omitempty"`
+	}
+
+	// FunctionOptions holds the configuration of a stored function.
+	FunctionOptions struct {
+		// Name of the function.
+		Name string `json:"name"`
+		// Args holds the argument list of the function (e.g. "a integer, b integer").
+		Args string `json:"args,omitempty"`
+		// Returns is the return type of the function.
+		Returns string `json:"returns"`
+		// Lang is the language of the function. Defaults to plpgsql.
+		Lang string `json:"lang,omitempty"`
+		// Body of the function.
+		Body string `json:"body"`
+	}
+)
+
+// Trigger timings.
+const (
+	TriggerBefore    TriggerTiming = "BEFORE"
+	TriggerAfter     TriggerTiming = "AFTER"
+	TriggerInsteadOf TriggerTiming = "INSTEAD OF"
+)
+
+// RowSecurity enables row-level security on the schema table, and defines its policies (PostgreSQL only).
+//
+//	func (User) Annotations() []schema.Annotation {
+//		return []schema.Annotation{
+//			entsql.RowSecurity(
+//				entsql.Policy("tenant_isolation", "tenant_id = current_setting('app.current_tenant')::integer"),
+//			),
+//		}
+//	}
+//
+//	ALTER TABLE "users" ENABLE ROW LEVEL SECURITY, NO FORCE ROW LEVEL SECURITY
+//	CREATE POLICY "tenant_isolation" ON "users" USING (tenant_id = current_setting('app.current_tenant')::integer)
+//
+// Session variables that are read by the policies using current_setting are set from the context by
+// the generated RowSecurityDriver, or by sql.WithVar. Other dialects ignore this option.
+func RowSecurity(policies ...*PolicyOptions) *Annotation {
+	return &Annotation{
+		RowSecurity: &RowSecurityOptions{
+			Policies: policies,
+		},
+	}
+}
+
+// Policy returns a permissive policy that applies to all commands and roles, and
+// allows access to the rows that match the given USING expression.
+func Policy(name, using string) *PolicyOptions {
+	return &PolicyOptions{Name: name, Using: using}
+}
+
+type (
+	// RowSecurityOptions holds the row-level security configuration of a table.
+	RowSecurityOptions struct {
+		// Force applies the policies also to the owner of the table.
+		Force bool `json:"force,omitempty"`
+		// Policies of the table.
+		Policies []*PolicyOptions `json:"policies,omitempty"`
+	}
+
+	// PolicyOptions holds the configuration of a row-level security policy.
+	PolicyOptions struct {
+		// Name of the policy. Must be unique in its table.
+		Name string `json:"name"`
+		// Command the policy applies to. One of ALL (default),
+		// SELECT, INSERT, UPDATE or DELETE.
+		Command string `json:"command,omitempty"`
+		// Roles the policy applies to. Defaults to PUBLIC.
+		Roles []string `json:"roles,omitempty"`
+		// Using is the expression that is checked against existing rows.
+		Using string `json:"using,omitempty"`
+		// WithCheck is the expression that is checked against new rows.
+		WithCheck string `json:"with_check,omitempty"`
+	}
+)
+
 // Merge implements the schema.Merger interface.
 func (a Annotation) Merge(other schema.Annotation) schema.Annotation {
 	var ant Annotation
@@ -472,6 +975,50 @@ func (a Annotation) Merge(other schema.Annotation) schema.Annotation {
 			a.ViewFor[dialect] = view
 		}
 	}
+	if l := ant.OptimisticLock; l != nil {
+		o := *l
+		if a.OptimisticLock != nil && a.OptimisticLock.RefuseBulkUpdate {
+			o.RefuseBulkUpdate = true
+		}
+		a.OptimisticLock = &o
+	}
+	if ant.SoftDelete {
+		a.SoftDelete = true
+	}
+	if ant.History {
+		a.History = true
+	}
+	if e := ant.EnumType; e != "" {
+		a.EnumType = e
+	}
+	if ant.Array {
+		a.Array = true
+	}
+	if f := ant.DurationFormat; f != "" {
+		a.DurationFormat = f
+	}
+	if p := ant.Partition; p != nil {
+		a.Partition = p
+	}
+	if g := ant.Generated; g != nil {
+		a.Generated = g
+	}
+	if t := ant.Triggers; len(t) > 0 {
+		a.Triggers = append(a.Triggers, t...)
+	}
+	if f := ant.Functions; len(f) > 0 {
+		a.Functions = append(a.Functions, f...)
+	}
+	if r := ant.RowSecurity; r != nil {
+		if a.RowSecurity == nil {
+			a.RowSecurity = &RowSecurityOptions{}
+		} else {
+			r2 := *a.RowSecurity
+			a.RowSecurity = &r2
+		}
+		a.RowSecurity.Force = a.RowSecurity.Force || r.Force
+		a.RowSecurity.Policies = append(slices.Clip(a.RowSecurity.Policies), r.Policies...)
+	}
 	if ant.err != nil {
 		a.err = errors.Join(a.err, ant.err)
 	}
@@ -625,6 +1172,33 @@ type IndexAnnotation struct {
 	//		)
 	//	CREATE INDEX "table_a" ON "table"("a") WHERE (b AND c > 0)
 	Where string
+
+	// Exprs defines expression parts (a.k.a. functional key parts) that are
+	// added to the index after its columns. Indexes that are defined only
+	// by expressions must set their name using the StorageKey option. See
+	// IndexExprs for the form expressions should be defined in.
+	//
+	//	index.Fields().
+	//		Annotations(
+	//			entsql.IndexExprs("lower((email)::text)"),
+	//		).
+	//		StorageKey("users_lower_email")
+	//
+	//	CREATE INDEX "users_lower_email" ON "users" ((lower((email)::text)))
+	//
+	Exprs []string
+
+	// ExprColumns replaces columns in the index with expressions
+	// that are computed from their values. For example:
+	//
+	//	index.Fields("c1", "c2").
+	//		Annotations(
+	//			entsql.IndexExprColumn("c1", "lower(c1)"),
+	//		)
+	//
+	//	CREATE INDEX "table_c1_c2" ON "table" ((lower(c1)), "c2")
+	//
+	ExprColumns map[string]string
 }
 
 // Prefix returns a new index annotation with a single string column index.
@@ -780,6 +1354,45 @@ func IndexWhere(pred string) *IndexAnnotation {
 	return &IndexAnnotation{Where: pred}
 }
 
+// IndexExprs returns a new index annotation with expression parts that are
+// added to the index after its columns. For example, a case-insensitive
+// unique index on a string field is defined in PostgreSQL as follows:
+//
+//	index.Fields().
+//		Annotations(
+//			entsql.IndexExprs("lower((email)::text)"),
+//		).
+//		StorageKey("users_lower_email").
+//		Unique()
+//
+//	CREATE UNIQUE INDEX "users_lower_email" ON "users" ((lower((email)::text)))
+//
+// Note that expressions should be defined exactly like they are stored in
+// the database (i.e. normal form), as they are compared as-is when diffing.
+// Otherwise, the index is recreated by every migration. For example, the
+// PostgreSQL normal form of lower(email) for a "character varying" column
+// is lower((email)::text). Expression indexes require MySQL 8.0.13 or above.
+func IndexExprs(exprs ...string) *IndexAnnotation {
+	return &IndexAnnotation{Exprs: exprs}
+}
+
+// IndexExprColumn returns a new index annotation that replaces
+// the given column in the index with an expression.
+//
+//	index.Fields("kind", "created_at").
+//		Annotations(
+//			entsql.IndexExprColumn("created_at", "date(created_at)"),
+//		)
+//
+//	CREATE INDEX "table_kind_created_at" ON "table" ("kind", (date(created_at)))
+func IndexExprColumn(name, expr string) *IndexAnnotation {
+	return &IndexAnnotation{
+		ExprColumns: map[string]string{
+			name: expr,
+		},
+	}
+}
+
 // Name describes the annotation name.
 func (IndexAnnotation) Name() string {
 	return "EntSQLIndexes"
@@ -848,6 +1461,17 @@ func (a IndexAnnotation) Merge(other schema.Annotation) schema.Annotation {
 	if ant.Where != "" {
 		a.Where = ant.Where
 	}
+	if ant.Exprs != nil {
+		a.Exprs = append(a.Exprs, ant.Exprs...)
+	}
+	if ant.ExprColumns != nil {
+		if a.ExprColumns == nil {
+			a.ExprColumns = make(map[string]string)
+		}
+		for column, expr := range ant.ExprColumns {
+			a.ExprColumns[column] = expr
+		}
+	}
 	return a
 }
 
@@ -0,0 +1,382 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sql
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"entgo.io/ent/dialect"
+)
+
+// ArrayValue wraps a slice that is stored in a column defined as a native array in PostgreSQL,
+// and as a JSON array in other dialects. The Builder encodes the value based on its dialect.
+type ArrayValue struct {
+	V any // Wrapped slice.
+}
+
+// Array wraps the given slice as an ArrayValue.
+//
+//	Insert("users").
+//		Columns("tags").
+//		Values(Array([]string{"a", "b"}))
+func Array(v any) *ArrayValue {
+	return &ArrayValue{V: v}
+}
+
+// encode returns the representation of the array in the given dialect.
+func (a *ArrayValue) encode(name string) (any, error) {
+	rv := reflect.ValueOf(a.V)
+	if k := rv.Kind(); k != reflect.Slice && k != reflect.Array {
+		return nil, fmt.Errorf("sql: unexpected array value %T", a.V)
+	}
+	if name != dialect.Postgres {
+		// Nil slices are stored as empty arrays, as in PostgreSQL.
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return "[]", nil
+		}
+		buf, err := json.Marshal(a.V)
+		if err != nil {
+			return nil, fmt.Errorf("sql: marshal array value: %w", err)
+		}
+		return string(buf), nil
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := 0; i < rv.Len(); i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		switch e := rv.Index(i); e.Kind() {
+		case reflect.String:
+			b.WriteByte('"')
+			for _, r := range e.String() {
+				if r == '"' || r == '\\' {
+					b.WriteByte('\\')
+				}
+				b.WriteRune(r)
+			}
+			b.WriteByte('"')
+		case reflect.Bool:
+			b.WriteString(strconv.FormatBool(e.Bool()))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			b.WriteString(strconv.FormatInt(e.Int(), 10))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			b.WriteString(strconv.FormatUint(e.Uint(), 10))
+		case reflect.Float32, reflect.Float64:
+			b.WriteString(strconv.FormatFloat(e.Float(), 'g', -1, e.Type().Bits()))
+		default:
+			return nil, fmt.Errorf("sql: unsupported array element type %s", e.Type())
+		}
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+// UnmarshalArray parses the given data into v, which must be a pointer to a slice of strings,
+// booleans or numbers. The data is expected to be a PostgreSQL array literal (e.g. {"a","b"}),
+// or a JSON array (e.g. ["a","b"]). It is used for decoding columns that are stored as native
+// arrays in PostgreSQL, and as JSON arrays in other dialects.
+func UnmarshalArray(data []byte, v any) error {
+	if len(data) == 0 || data[0] != '{' {
+		return json.Unmarshal(data, v)
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("sql: unmarshal array into non-slice pointer %T", v)
+	}
+	elems, err := parseArray(string(data))
+	if err != nil {
+		return err
+	}
+	slice := reflect.MakeSlice(rv.Elem().Type(), len(elems), len(elems))
+	for i, s := range elems {
+		// NULL elements are decoded as zero values.
+		if s == nil {
+			continue
+		}
+		if err := setArrayElem(slice.Index(i), *s); err != nil {
+			return err
+		}
+	}
+	rv.Elem().Set(slice)
+	return nil
+}
+
+// parseArray parses a one-dimensional PostgreSQL array literal. NULL elements are returned as nil.
+func parseArray(s string) ([]*string, error) {
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, fmt.Errorf("sql: invalid array literal %q", s)
+	}
+	var (
+		elems []*string
+		body  = s[1 : len(s)-1]
+	)
+	if strings.TrimSpace(body) == "" {
+		return elems, nil
+	}
+	for i := 0; i <= len(body); {
+		var (
+			e      strings.Builder
+			quoted bool
+		)
+		for i < len(body) && body[i] == ' ' {
+			i++
+		}
+		if i < len(body) && body[i] == '{' {
+			return nil, fmt.Errorf("sql: multi-dimensional array literal %q is not supported", s)
+		}
+		if i < len(body) && body[i] == '"' {
+			quoted = true
+			for i++; i < len(body) && body[i] != '"'; i++ {
+				if body[i] == '\\' && i+1 < len(body) {
+					i++
+				}
+				e.WriteByte(body[i])
+			}
+			if i == len(body) {
+				return nil, fmt.Errorf("sql: unterminated element in array literal %q", s)
+			}
+			i++
+		}
+		for ; i < len(body) && body[i] != ','; i++ {
+			if !quoted {
+				e.WriteByte(body[i])
+			}
+		}
+		elem := e.String()
+		if !quoted {
+			elem = strings.TrimSpace(elem)
+		}
+		if !quoted && strings.EqualFold(elem, "NULL") {
+			elems = append(elems, nil)
+		} else {
+			elems = append(elems, &elem)
+		}
+		// Skip the separator.
+		i++
+	}
+	return elems, nil
+}
+
+// setArrayElem sets the value of an array element from its text representation.
+func setArrayElem(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		switch strings.ToLower(s) {
+		case "t", "true":
+			v.SetBool(true)
+		case "f", "false":
+			v.SetBool(false)
+		default:
+			return fmt.Errorf("sql: invalid boolean array element %q", s)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("sql: invalid integer array element %q: %w", s, err)
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("sql: invalid unsigned integer array element %q: %w", s, err)
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("sql: invalid float array element %q: %w", s, err)
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("sql: unsupported array element type %s", v.Type())
+	}
+	return nil
+}
+
+// ArrayContains returns a predicate for checking that the array column contains all the given
+// values. In PostgreSQL, it uses the @> operator of native arrays, and in MySQL and SQLite, it
+// checks the elements of the JSON array.
+//
+//	ArrayContains("tags", "a", "b")
+//	"tags" @> $1
+func ArrayContains[T any](column string, vs ...T) *Predicate {
+	return P(func(b *Builder) {
+		switch b.Dialect() {
+		case dialect.Postgres:
+			b.Ident(column).WriteString(" @> ").Arg(Array(vs))
+		case dialect.MySQL:
+			b.WriteString("JSON_CONTAINS").Wrap(func(b *Builder) {
+				b.Ident(column).Comma().Arg(Array(vs))
+			})
+		default:
+			b.WriteString("NOT EXISTS").Wrap(func(b *Builder) {
+				b.WriteString("SELECT * FROM JSON_EACH").Wrap(func(b *Builder) {
+					b.Arg(Array(vs))
+				})
+				b.WriteString(" WHERE ").Ident("value").WriteString(" NOT IN ").Wrap(func(b *Builder) {
+					b.WriteString("SELECT ").Ident("value").WriteString(" FROM JSON_EACH").Wrap(func(b *Builder) {
+						b.Ident(column)
+					})
+				})
+			})
+		}
+	})
+}
+
+// ArrayOverlaps returns a predicate for checking that the array column contains at least one
+// of the given values. In PostgreSQL, it uses the && operator of native arrays, and in MySQL
+// and SQLite, it checks the elements of the JSON array.
+//
+//	ArrayOverlaps("tags", "a", "b")
+//	"tags" && $1
+func ArrayOverlaps[T any](column string, vs ...T) *Predicate {
+	return P(func(b *Builder) {
+		switch b.Dialect() {
+		case dialect.Postgres:
+			b.Ident(column).WriteString(" && ").Arg(Array(vs))
+		case dialect.MySQL:
+			b.WriteString("JSON_OVERLAPS").Wrap(func(b *Builder) {
+				b.Ident(column).Comma().Arg(Array(vs))
+			})
+		default:
+			b.WriteString("EXISTS").Wrap(func(b *Builder) {
+				b.WriteString("SELECT * FROM JSON_EACH").Wrap(func(b *Builder) {
+					b.Ident(column)
+				})
+				b.WriteString(" WHERE ").Ident("value").WriteString(" IN ").Wrap(func(b *Builder) {
+					b.WriteString("SELECT ").Ident("value").WriteString(" FROM JSON_EACH").Wrap(func(b *Builder) {
+						b.Arg(Array(vs))
+					})
+				})
+			})
+		}
+	})
+}
+
+// ArrayLenEQ returns a predicate for checking that the length of the array column is equal to n.
+//
+//	ArrayLenEQ("tags", 2)
+//	CARDINALITY("tags") = $1
+func ArrayLenEQ(column string, n int) *Predicate {
+	return arrayLen(column, OpEQ, n)
+}
+
+// ArrayLenNEQ returns a predicate for checking that the length of the array column is not equal to n.
+func ArrayLenNEQ(column string, n int) *Predicate {
+	return arrayLen(column, OpNEQ, n)
+}
+
+// ArrayLenGT returns a predicate for checking that the length of the array column is greater than n.
+func ArrayLenGT(column string, n int) *Predicate {
+	return arrayLen(column, OpGT, n)
+}
+
+// ArrayLenGTE returns a predicate for checking that the length of the array column is greater than or equal to n.
+func ArrayLenGTE(column string, n int) *Predicate {
+	return arrayLen(column, OpGTE, n)
+}
+
+// ArrayLenLT returns a predicate for checking that the length of the array column is less than n.
+func ArrayLenLT(column string, n int) *Predicate {
+	return arrayLen(column, OpLT, n)
+}
+
+// ArrayLenLTE returns a predicate for checking that the length of the array column is less than or equal to n.
+func ArrayLenLTE(column string, n int) *Predicate {
+	return arrayLen(column, OpLTE, n)
+}
+
+func arrayLen(column string, op Op, n int) *Predicate {
+	return P(func(b *Builder) {
+		fn := "JSON_ARRAY_LENGTH"
+		switch b.Dialect() {
+		case dialect.Postgres:
+			fn = "CARDINALITY"
+		case dialect.MySQL:
+			fn = "JSON_LENGTH"
+		}
+		b.WriteString(fn).Wrap(func(b *Builder) {
+			b.Ident(column)
+		})
+		b.WriteOp(op).Arg(n)
+	})
+}
+
+// FieldArrayContains returns a raw predicate to check if the array field contains all the given values.
+func FieldArrayContains[T any](name string, vs ...T) func(*Selector) {
+	return func(s *Selector) {
+		s.Where(ArrayContains(s.C(name), vs...))
+	}
+}
+
+// FieldArrayOverlaps returns a raw predicate to check if the array field contains at least one of the given values.
+func FieldArrayOverlaps[T any](name string, vs ...T) func(*Selector) {
+	return func(s *Selector) {
+		s.Where(ArrayOverlaps(s.C(name), vs...))
+	}
+}
+
+// FieldArrayLenEQ returns a raw predicate to check if the length of the array field is equal to n.
+func FieldArrayLenEQ(name string, n int) func(*Selector) {
+	return func(s *Selector) {
+		s.Where(ArrayLenEQ(s.C(name), n))
+	}
+}
+
+// ArrayAppend writes to the given update builder the SQL command for appending the elements to
+// the array column. A NULL column is set to the given elements. For example:
+//
+//	ArrayAppend(u, "tags", []string{"a", "b"})
+//	UPDATE "t" SET "tags" = ARRAY_CAT(COALESCE("tags", '{}'), $1)
+func ArrayAppend[T any](u *UpdateBuilder, column string, elems []T) {
+	if len(elems) == 0 {
+		u.AddError(fmt.Errorf("sql: cannot append an empty array to column %q", column))
+		return
+	}
+	// Elements are inserted one by one to JSON arrays in SQLite.
+	jsonElems := make([]any, len(elems))
+	for i, e := range elems {
+		buf, err := json.Marshal(e)
+		if err != nil {
+			u.AddError(fmt.Errorf("sql: marshal array element: %w", err))
+			return
+		}
+		jsonElems[i] = string(buf)
+	}
+	u.Set(column, ExprFunc(func(b *Builder) {
+		switch b.Dialect() {
+		case dialect.Postgres:
+			b.WriteString("ARRAY_CAT").Wrap(func(b *Builder) {
+				b.WriteString("COALESCE").Wrap(func(b *Builder) {
+					b.Ident(column).WriteString(", '{}'")
+				})
+				b.Comma().Arg(Array(elems))
+			})
+		case dialect.MySQL:
+			b.WriteString("JSON_MERGE_PRESERVE").Wrap(func(b *Builder) {
+				b.WriteString("COALESCE").Wrap(func(b *Builder) {
+					b.Ident(column).WriteString(", JSON_ARRAY()")
+				})
+				b.Comma().Arg(Array(elems))
+			})
+		default:
+			b.WriteString("JSON_INSERT").Wrap(func(b *Builder) {
+				b.WriteString("COALESCE").Wrap(func(b *Builder) {
+					b.Ident(column).WriteString(", '[]'")
+				})
+				for _, e := range jsonElems {
+					b.WriteString(", '$[#]', ").Argf("JSON(?)", e)
+				}
+			})
+		}
+	}))
+}
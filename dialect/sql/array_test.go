@@ -0,0 +1,191 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sql
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"entgo.io/ent/dialect"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArrayValue(t *testing.T) {
+	query, args := Dialect(dialect.Postgres).
+		Insert("users").
+		Columns("tags", "scores", "flags").
+		Values(Array([]string{"a", `b"c`, `d\e`}), Array([]float64{1.5, 2}), Array([]bool{true, false})).
+		Query()
+	require.Equal(t, `INSERT INTO "users" ("tags", "scores", "flags") VALUES ($1, $2, $3)`, query)
+	require.Equal(t, []any{`{"a","b\"c","d\\e"}`, "{1.5,2}", "{true,false}"}, args)
+
+	query, args = Dialect(dialect.MySQL).
+		Update("users").
+		Set("tags", Array([]string{"a"})).
+		Set("ids", Array([]int(nil))).
+		Query()
+	require.Equal(t, "UPDATE `users` SET `tags` = ?, `ids` = ?", query)
+	require.Equal(t, []any{`["a"]`, "[]"}, args)
+
+	_, _, err := Dialect(dialect.Postgres).Insert("users").Columns("tags").Values(Array([]any{struct{}{}})).QueryErr()
+	require.Error(t, err)
+}
+
+func TestUnmarshalArray(t *testing.T) {
+	tests := []struct {
+		data    string
+		v       any
+		want    any
+		wantErr bool
+	}{
+		{data: `{a,"b c","d\"e","f\\g",NULL}`, v: &[]string{}, want: &[]string{"a", "b c", `d"e`, `f\g`, ""}},
+		{data: `{"NULL",""}`, v: &[]string{}, want: &[]string{"NULL", ""}},
+		{data: `{}`, v: &[]string{"a"}, want: &[]string{}},
+		{data: `["a","b"]`, v: &[]string{}, want: &[]string{"a", "b"}},
+		{data: `{1,-2,3}`, v: &[]int{}, want: &[]int{1, -2, 3}},
+		{data: `{1.5,2}`, v: &[]float64{}, want: &[]float64{1.5, 2}},
+		{data: `{t,f,true}`, v: &[]bool{}, want: &[]bool{true, false, true}},
+		{data: `{{1},{2}}`, v: &[]int{}, wantErr: true},
+		{data: `{"a}`, v: &[]string{}, wantErr: true},
+		{data: `{a}`, v: &[]int{}, wantErr: true},
+		{data: `{a}`, v: []string{}, wantErr: true},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			err := UnmarshalArray([]byte(tt.data), tt.v)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, tt.v)
+		})
+	}
+}
+
+func TestArrayPredicates(t *testing.T) {
+	tests := []struct {
+		p         *Predicate
+		postgres  string
+		mysql     string
+		sqlite    string
+		pgArgs    []any
+		otherArgs []any
+	}{
+		{
+			p:         ArrayContains("tags", "a", "b"),
+			postgres:  `"tags" @> $1`,
+			mysql:     "JSON_CONTAINS(`tags`, ?)",
+			sqlite:    "NOT EXISTS(SELECT * FROM JSON_EACH(?) WHERE `value` NOT IN (SELECT `value` FROM JSON_EACH(`tags`)))",
+			pgArgs:    []any{`{"a","b"}`},
+			otherArgs: []any{`["a","b"]`},
+		},
+		{
+			p:         ArrayOverlaps("ids", 1, 2),
+			postgres:  `"ids" && $1`,
+			mysql:     "JSON_OVERLAPS(`ids`, ?)",
+			sqlite:    "EXISTS(SELECT * FROM JSON_EACH(`ids`) WHERE `value` IN (SELECT `value` FROM JSON_EACH(?)))",
+			pgArgs:    []any{"{1,2}"},
+			otherArgs: []any{"[1,2]"},
+		},
+		{
+			p:         ArrayLenGT("tags", 1),
+			postgres:  `CARDINALITY("tags") > $1`,
+			mysql:     "JSON_LENGTH(`tags`) > ?",
+			sqlite:    "JSON_ARRAY_LENGTH(`tags`) > ?",
+			pgArgs:    []any{1},
+			otherArgs: []any{1},
+		},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			q := func(name string) (string, []any) {
+				tt.p.SetDialect(name)
+				return tt.p.Query()
+			}
+			query, args := q(dialect.Postgres)
+			require.Equal(t, tt.postgres, query)
+			require.Equal(t, tt.pgArgs, args)
+			query, args = q(dialect.MySQL)
+			require.Equal(t, tt.mysql, query)
+			require.Equal(t, tt.otherArgs, args)
+			query, args = q(dialect.SQLite)
+			require.Equal(t, tt.sqlite, query)
+			require.Equal(t, tt.otherArgs, args)
+		})
+	}
+}
+
+func TestArrayAppend(t *testing.T) {
+	u := Dialect(dialect.Postgres).Update("users")
+	ArrayAppend(u, "tags", []string{"a", "b"})
+	query, args := u.Query()
+	require.Equal(t, `UPDATE "users" SET "tags" = ARRAY_CAT(COALESCE("tags", '{}'), $1)`, query)
+	require.Equal(t, []any{`{"a","b"}`}, args)
+
+	u = Dialect(dialect.MySQL).Update("users")
+	ArrayAppend(u, "tags", []string{"a"})
+	query, args = u.Query()
+	require.Equal(t, "UPDATE `users` SET `tags` = JSON_MERGE_PRESERVE(COALESCE(`tags`, JSON_ARRAY()), ?)", query)
+	require.Equal(t, []any{`["a"]`}, args)
+
+	u = Dialect(dialect.SQLite).Update("users")
+	ArrayAppend(u, "tags", []string{"a", "b"})
+	query, args = u.Query()
+	require.Equal(t, "UPDATE `users` SET `tags` = JSON_INSERT(COALESCE(`tags`, '[]'), '$[#]', JSON(?), '$[#]', JSON(?))", query)
+	require.Equal(t, []any{`"a"`, `"b"`}, args)
+
+	u = Dialect(dialect.SQLite).Update("users")
+	ArrayAppend(u, "tags", []string{})
+	require.Error(t, u.Err())
+}
+
+func TestArraySQLite(t *testing.T) {
+	ctx := context.Background()
+	drv, err := Open(dialect.SQLite, "file:array?mode=memory")
+	require.NoError(t, err)
+	defer drv.Close()
+	exec := func(q Querier) {
+		query, args := q.Query()
+		require.NoError(t, drv.Exec(ctx, query, args, nil))
+	}
+	exec(Raw("CREATE TABLE `users` (`id` integer PRIMARY KEY, `tags` json NULL)"))
+	exec(Dialect(dialect.SQLite).Insert("users").Columns("id", "tags").
+		Values(1, Array([]string{"a", "b"})).
+		Values(2, Array([]string{"b", "c", "d"})).
+		Values(3, Array([]string(nil))))
+	u := Dialect(dialect.SQLite).Update("users").Where(EQ("id", 3))
+	ArrayAppend(u, "tags", []string{"e"})
+	exec(u)
+	ids := func(p *Predicate) []int {
+		query, args := Dialect(dialect.SQLite).Select("id").From(Table("users")).Where(p).OrderBy("id").Query()
+		rows := &Rows{}
+		require.NoError(t, drv.Query(ctx, query, args, rows))
+		defer rows.Close()
+		var ids []int
+		require.NoError(t, ScanSlice(rows, &ids))
+		return ids
+	}
+	require.Equal(t, []int{1, 2}, ids(ArrayContains("tags", "b")))
+	require.Equal(t, []int{2}, ids(ArrayContains("tags", "b", "c")))
+	require.Equal(t, []int{1, 2, 3}, ids(ArrayContains[string]("tags")))
+	require.Equal(t, []int{1, 3}, ids(ArrayOverlaps("tags", "a", "e")))
+	require.Equal(t, []int{3}, ids(ArrayLenEQ("tags", 1)))
+	require.Equal(t, []int{2}, ids(ArrayLenGTE("tags", 3)))
+
+	query, args := Dialect(dialect.SQLite).Select("tags").From(Table("users")).Where(EQ("id", 3)).Query()
+	rows := &Rows{}
+	require.NoError(t, drv.Query(ctx, query, args, rows))
+	defer rows.Close()
+	require.True(t, rows.Next())
+	var data []byte
+	require.NoError(t, rows.Scan(&data))
+	var tags []string
+	require.NoError(t, UnmarshalArray(data, &tags))
+	require.Equal(t, []string{"e"}, tags)
+}
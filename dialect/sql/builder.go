@@ -145,6 +145,7 @@ type InsertBuilder struct {
 	defaults  bool
 	returning []string
 	values    [][]any
+	selector  *Selector
 	conflict  *conflict
 }
 
@@ -187,6 +188,17 @@ func (i *InsertBuilder) Values(values ...any) *InsertBuilder {
 	return i
 }
 
+// Select sets a query that provides the rows to insert (`INSERT INTO ... SELECT`),
+// instead of the value tuples.
+//
+//	Insert("users_history").
+//		Columns("id", "name").
+//		Select(Select("id", "name").From(Table("users")))
+func (i *InsertBuilder) Select(s *Selector) *InsertBuilder {
+	i.selector = s
+	return i
+}
+
 // Default sets the default values clause based on the dialect type.
 func (i *InsertBuilder) Default() *InsertBuilder {
 	i.defaults = true
@@ -445,9 +457,13 @@ func (i *InsertBuilder) QueryErr() (string, []any, error) {
 	b.WriteString("INSERT INTO ")
 	b.writeSchema(i.schema)
 	b.Ident(i.table).Pad()
-	if i.defaults && len(i.columns) == 0 {
+	switch {
+	case i.defaults && len(i.columns) == 0:
 		i.writeDefault(&b)
-	} else {
+	case i.selector != nil:
+		b.WriteByte('(').IdentComma(i.columns...).WriteByte(')').Pad()
+		b.Join(i.selector)
+	default:
 		b.WriteByte('(').IdentComma(i.columns...).WriteByte(')')
 		b.WriteString(" VALUES ")
 		for j, v := range i.values {
@@ -561,6 +577,11 @@ func (u *UpdateBuilder) Set(column string, v any) *UpdateBuilder {
 func (u *UpdateBuilder) Add(column string, v any) *UpdateBuilder {
 	u.columns = append(u.columns, column)
 	u.values = append(u.values, ExprFunc(func(b *Builder) {
+		// Durations are not stored as integers in all dialects.
+		if d, ok := v.(*DurationValue); ok {
+			d.add(b, Table(u.table).C(column))
+			return
+		}
 		b.WriteString("COALESCE")
 		b.Wrap(func(b *Builder) {
 			b.Ident(Table(u.table).C(column)).Comma().WriteByte('0')
@@ -2479,6 +2500,46 @@ func (s *Selector) ClearOrder() *Selector {
 	return s
 }
 
+// OrderKey describes a term of the ORDER BY clause that
+// carries its sorting direction separately from its expression.
+type OrderKey struct {
+	Expr       Querier // Ordered expression, without the sorting suffixes.
+	Desc       bool    // Whether the term is sorted in descending order.
+	NullsFirst bool    // Whether NULLS FIRST was set explicitly.
+	NullsLast  bool    // Whether NULLS LAST was set explicitly.
+}
+
+// OrderByKey appends the given keys to the `ORDER BY` clause of the `SELECT` statement.
+//
+//	t := Table("users")
+//	s := Select().From(t).OrderByKey(&OrderKey{Expr: Expr(t.C("age")), Desc: true})
+func (s *Selector) OrderByKey(keys ...*OrderKey) *Selector {
+	for i := range keys {
+		s.order = append(s.order, keys[i])
+	}
+	return s
+}
+
+// OrderKeys returns the terms of the ORDER BY clause of the selector. It is used
+// for building predicates on the ordered expressions, such as keyset pagination.
+// An error is returned if one of the terms was not added using OrderByKey, as its
+// sorting direction is unknown.
+//
+//	t := Table("users")
+//	s := Select().From(t).OrderByKey(&OrderKey{Expr: Expr(t.C("age")), Desc: true})
+//	keys, err := s.OrderKeys() // [{Expr: `users`.`age`, Desc: true}]
+func (s *Selector) OrderKeys() ([]*OrderKey, error) {
+	keys := make([]*OrderKey, 0, len(s.order))
+	for i, o := range s.order {
+		k, ok := o.(*OrderKey)
+		if !ok {
+			return nil, fmt.Errorf("sql: sorting direction of ORDER BY term %d is unknown", i)
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
 // GroupBy appends the `GROUP BY` clause to the `SELECT` statement.
 func (s *Selector) GroupBy(columns ...string) *Selector {
 	s.group = append(s.group, columns...)
@@ -2638,12 +2699,26 @@ func joinOrder(order []any, b *Builder) {
 		if i > 0 {
 			b.Comma()
 		}
-		switch r := order[i].(type) {
-		case string:
-			b.Ident(r)
-		case Querier:
-			b.Join(r)
+		writeOrderTerm(b, order[i])
+	}
+}
+
+func writeOrderTerm(b *Builder, term any) {
+	switch r := term.(type) {
+	case *OrderKey:
+		b.Join(r.Expr)
+		if r.Desc {
+			b.WriteString(" DESC")
 		}
+		if r.NullsFirst {
+			b.WriteString(" NULLS FIRST")
+		} else if r.NullsLast {
+			b.WriteString(" NULLS LAST")
+		}
+	case string:
+		b.Ident(r)
+	case Querier:
+		b.Join(r)
 	}
 }
 
@@ -3170,6 +3245,14 @@ func (b *Builder) Arg(a any) *Builder {
 	case Querier:
 		b.Join(v)
 		return b
+	case *ArrayValue:
+		enc, err := v.encode(b.dialect)
+		if err != nil {
+			b.AddError(err)
+		}
+		a = enc
+	case *DurationValue:
+		a = v.Encode(b.dialect)
 	}
 	// Default placeholder param (MySQL and SQLite).
 	format := "?"
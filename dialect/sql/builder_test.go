@@ -102,6 +102,20 @@ func TestBuilder(t *testing.T) {
 			wantQuery: `INSERT INTO "users" ("name", "age") VALUES ($1, $2), ($3, $4)`,
 			wantArgs:  []any{"a8m", 10, "foo", 20},
 		},
+		{
+			input: Insert("users_history").
+				Columns("id", "name", "op").
+				Select(Select("id", "name").AppendSelectExpr(Expr("?", "update")).From(Table("users")).Where(GT("age", 30))),
+			wantQuery: "INSERT INTO `users_history` (`id`, `name`, `op`) SELECT `id`, `name`, ? FROM `users` WHERE `age` > ?",
+			wantArgs:  []any{"update", 30},
+		},
+		{
+			input: Dialect(dialect.Postgres).Insert("users_history").
+				Columns("id", "name", "op").
+				Select(Select("id", "name").AppendSelectExpr(ExprFunc(func(b *Builder) { b.Arg("update") })).From(Table("users")).Where(GT("age", 30))),
+			wantQuery: `INSERT INTO "users_history" ("id", "name", "op") SELECT "id", "name", $1 FROM "users" WHERE "age" > $2`,
+			wantArgs:  []any{"update", 30},
+		},
 		{
 			input: Dialect(dialect.Postgres).Insert("users").
 				Columns("name", "age").
@@ -1525,6 +1539,47 @@ func TestSelector_OrderByExpr(t *testing.T) {
 	require.Equal(t, []any{28, 1, 2}, args)
 }
 
+func TestSelector_OrderKeys(t *testing.T) {
+	t1 := Table("users")
+	s := Dialect(dialect.Postgres).
+		Select().
+		From(t1)
+	s.OrderByKey(
+		&OrderKey{Expr: Expr(s.C("name")), Desc: true},
+		&OrderKey{Expr: ExprFunc(func(b *Builder) {
+			b.WriteString("COALESCE(").Ident(s.C("nickname")).Comma().Arg("a").WriteString(")")
+		}), Desc: true, NullsLast: true},
+	)
+	OrderByField("id", OrderNullsFirst()).ToFunc()(s)
+	query, args := s.Query()
+	require.Equal(t, `SELECT * FROM "users" ORDER BY "users"."name" DESC, COALESCE("users"."nickname", $1) DESC NULLS LAST, "users"."id" NULLS FIRST`, query)
+	require.Equal(t, []any{"a"}, args)
+	keys, err := s.OrderKeys()
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+	require.True(t, keys[0].Desc)
+	require.True(t, keys[1].Desc)
+	require.True(t, keys[1].NullsLast)
+	require.False(t, keys[2].Desc)
+	require.True(t, keys[2].NullsFirst)
+	exprs := make([]Querier, len(keys))
+	for i, k := range keys {
+		exprs[i] = k.Expr
+	}
+	query, args = Dialect(dialect.Postgres).
+		Select().
+		From(t1).
+		Where(EQ("id", 1)).
+		OrderExpr(exprs...).
+		Query()
+	require.Equal(t, `SELECT * FROM "users" WHERE "id" = $1 ORDER BY "users"."name", COALESCE("users"."nickname", $2), "users"."id"`, query)
+	require.Equal(t, []any{1, "a"}, args)
+
+	// The direction of raw terms is unknown.
+	_, err = s.OrderBy(Desc("age")).OrderKeys()
+	require.Error(t, err)
+}
+
 func TestSelector_ClearOrder(t *testing.T) {
 	query, args := Select("*").
 		From(Table("users")).
@@ -10,8 +10,11 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"entgo.io/ent/dialect"
 )
@@ -83,6 +86,71 @@ type Tx struct {
 	driver.Tx
 }
 
+// savepointSeq is used for generating unique savepoint names.
+var savepointSeq atomic.Uint64
+
+// SavepointTx implements the dialect.Tx interface for a savepoint within a
+// transaction. Committing it releases the savepoint, and rolling it back
+// rolls back all statements that were executed after it was created.
+type SavepointTx struct {
+	dialect.ExecQuerier
+	ctx  context.Context
+	name string
+}
+
+// Savepoint creates a new savepoint using the given transaction, and returns
+// a dialect.Tx that executes its statements using the same transaction. The
+// SAVEPOINT syntax is shared by MySQL, PostgreSQL and SQLite.
+func Savepoint(ctx context.Context, tx dialect.ExecQuerier) (*SavepointTx, error) {
+	name := fmt.Sprintf("ent_savepoint_%d", savepointSeq.Add(1))
+	if err := tx.Exec(ctx, "SAVEPOINT "+name, []any{}, nil); err != nil {
+		return nil, err
+	}
+	return &SavepointTx{ExecQuerier: tx, ctx: ctx, name: name}, nil
+}
+
+// Name returns the name of the savepoint.
+func (s *SavepointTx) Name() string {
+	return s.name
+}
+
+// ExecContext calls the ExecContext method of the transaction if it is supported by it.
+func (s *SavepointTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ex, ok := s.ExecQuerier.(interface {
+		ExecContext(context.Context, string, ...any) (sql.Result, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Tx.ExecContext is not supported")
+	}
+	return ex.ExecContext(ctx, query, args...)
+}
+
+// QueryContext calls the QueryContext method of the transaction if it is supported by it.
+func (s *SavepointTx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	q, ok := s.ExecQuerier.(interface {
+		QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Tx.QueryContext is not supported")
+	}
+	return q.QueryContext(ctx, query, args...)
+}
+
+// Commit releases the savepoint.
+func (s *SavepointTx) Commit() error {
+	return s.Exec(s.ctx, "RELEASE SAVEPOINT "+s.name, []any{}, nil)
+}
+
+// Rollback rolls back to the savepoint and releases it.
+func (s *SavepointTx) Rollback() error {
+	if err := s.Exec(s.ctx, "ROLLBACK TO SAVEPOINT "+s.name, []any{}, nil); err != nil {
+		return err
+	}
+	return s.Exec(s.ctx, "RELEASE SAVEPOINT "+s.name, []any{}, nil)
+}
+
+var _ dialect.Tx = (*SavepointTx)(nil)
+
 // ctyVarsKey is the key used for attaching and reading the context variables.
 type ctxVarsKey struct{}
 
@@ -92,6 +160,8 @@ type sessionVars struct {
 }
 
 // WithVar returns a new context that holds the session variable to be executed before every query.
+// In PostgreSQL, variables that are set in transactions are local to the transaction. In MySQL, they
+// are kept in the session until they are set again.
 func WithVar(ctx context.Context, name, value string) context.Context {
 	sv, _ := ctx.Value(ctxVarsKey{}).(sessionVars)
 	sv.vars = append(sv.vars, struct {
@@ -119,6 +189,95 @@ func WithIntVar(ctx context.Context, name string, value int) context.Context {
 	return WithVar(ctx, name, strconv.Itoa(value))
 }
 
+// VarFunc resolves the value of a session variable from the context,
+// and reports whether the variable should be set.
+type VarFunc func(context.Context) (string, bool)
+
+// VarsDriver is a driver that sets session variables, resolved from the context, before every statement
+// it executes, including the statements that are executed by its transactions. Variables that were already
+// attached to the context using WithVar are not resolved. For example:
+//
+//	drv = sql.NewVarsDriver(drv, map[string]sql.VarFunc{
+//		"app.current_tenant": func(ctx context.Context) (string, bool) {
+//			id, ok := TenantFromContext(ctx)
+//			return strconv.Itoa(id), ok
+//		},
+//	})
+type VarsDriver struct {
+	dialect.Driver
+	names []string
+	vars  map[string]VarFunc
+}
+
+// NewVarsDriver returns a VarsDriver that wraps the given driver and sets the given session variables.
+func NewVarsDriver(drv dialect.Driver, vars map[string]VarFunc) *VarsDriver {
+	return &VarsDriver{Driver: drv, names: slices.Sorted(maps.Keys(vars)), vars: vars}
+}
+
+// withVars attaches the resolved session variables to the context.
+func (d *VarsDriver) withVars(ctx context.Context) context.Context {
+	for _, name := range d.names {
+		if _, ok := VarFromContext(ctx, name); ok {
+			continue
+		}
+		if v, ok := d.vars[name](ctx); ok {
+			ctx = WithVar(ctx, name, v)
+		}
+	}
+	return ctx
+}
+
+// Exec sets the session variables and calls the underlying driver Exec method.
+func (d *VarsDriver) Exec(ctx context.Context, query string, args, v any) error {
+	return d.Driver.Exec(d.withVars(ctx), query, args, v)
+}
+
+// Query sets the session variables and calls the underlying driver Query method.
+func (d *VarsDriver) Query(ctx context.Context, query string, args, v any) error {
+	return d.Driver.Query(d.withVars(ctx), query, args, v)
+}
+
+// Tx starts a transaction that sets the session variables before every statement.
+func (d *VarsDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &varsTx{Tx: tx, drv: d}, nil
+}
+
+// BeginTx calls the underlying driver BeginTx method if it is supported, and returns
+// a transaction that sets the session variables before every statement.
+func (d *VarsDriver) BeginTx(ctx context.Context, opts *TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.BeginTx is not supported")
+	}
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &varsTx{Tx: tx, drv: d}, nil
+}
+
+// varsTx is a transaction that sets the session variables of its driver.
+type varsTx struct {
+	dialect.Tx
+	drv *VarsDriver
+}
+
+// Exec sets the session variables and calls the underlying transaction Exec method.
+func (t *varsTx) Exec(ctx context.Context, query string, args, v any) error {
+	return t.Tx.Exec(t.drv.withVars(ctx), query, args, v)
+}
+
+// Query sets the session variables and calls the underlying transaction Query method.
+func (t *varsTx) Query(ctx context.Context, query string, args, v any) error {
+	return t.Tx.Query(t.drv.withVars(ctx), query, args, v)
+}
+
 // ExecQuerier wraps the standard Exec and Query methods.
 type ExecQuerier interface {
 	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
@@ -201,6 +360,7 @@ func (c Conn) maySetVars(ctx context.Context) (ExecQuerier, func() error, error)
 		reset []string     // Reset variables.
 		seen  = make(map[string]struct{}, len(sv.vars))
 	)
+	_, local := c.ExecQuerier.(*sql.Tx)
 	switch e := c.ExecQuerier.(type) {
 	case *sql.Tx:
 		ex = e
@@ -221,7 +381,8 @@ func (c Conn) maySetVars(ctx context.Context) (ExecQuerier, func() error, error)
 			}
 			seen[s.k] = struct{}{}
 		}
-		if _, err := ex.ExecContext(ctx, fmt.Sprintf("SET %s = '%s'", s.k, s.v)); err != nil {
+		query, args := c.setVar(s.k, s.v, local)
+		if _, err := ex.ExecContext(ctx, query, args...); err != nil {
 			if cf != nil {
 				err = errors.Join(err, cf())
 			}
@@ -243,6 +404,16 @@ func (c Conn) maySetVars(ctx context.Context) (ExecQuerier, func() error, error)
 	return ex, cf, nil
 }
 
+// setVar returns the statement that sets the given variable, and its arguments. In PostgreSQL,
+// variables that are set in transactions are local to the transaction, and are not kept in the
+// session (i.e. the pooled connection) after the transaction is completed.
+func (c Conn) setVar(k, v string, local bool) (string, []any) {
+	if c.dialect == dialect.Postgres {
+		return "SELECT set_config($1, $2, $3)", []any{k, v, local}
+	}
+	return fmt.Sprintf("SET %s = ?", k), []any{v}
+}
+
 var _ dialect.Driver = (*Driver)(nil)
 
 type (
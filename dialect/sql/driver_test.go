@@ -6,6 +6,7 @@ package sql
 
 import (
 	"context"
+	"regexp"
 	"testing"
 
 	"entgo.io/ent/dialect"
@@ -19,7 +20,7 @@ func TestWithVars(t *testing.T) {
 	require.NoError(t, err)
 	db.SetMaxOpenConns(1)
 	drv := OpenDB(dialect.Postgres, db)
-	mock.ExpectExec("SET foo = 'bar'").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT set_config($1, $2, $3)")).WithArgs("foo", "bar", false).WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectQuery("SELECT 1").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
 	mock.ExpectExec("RESET foo").WillReturnResult(sqlmock.NewResult(0, 0))
 	rows := &Rows{}
@@ -33,8 +34,8 @@ func TestWithVars(t *testing.T) {
 	require.NoError(t, rows.Close(), "rows should be closed to release the connection")
 	require.NoError(t, mock.ExpectationsWereMet())
 
-	mock.ExpectExec("SET foo = 'bar'").WillReturnResult(sqlmock.NewResult(0, 0))
-	mock.ExpectExec("SET foo = 'baz'").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT set_config($1, $2, $3)")).WithArgs("foo", "bar", false).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT set_config($1, $2, $3)")).WithArgs("foo", "baz", false).WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectQuery("SELECT 1").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
 	mock.ExpectExec("RESET foo").WillReturnResult(sqlmock.NewResult(0, 0))
 	err = drv.Query(
@@ -48,7 +49,7 @@ func TestWithVars(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 
 	mock.ExpectBegin()
-	mock.ExpectExec("SET foo = 'bar'").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT set_config($1, $2, $3)")).WithArgs("foo", "bar", true).WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectQuery("SELECT 1").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
 	mock.ExpectCommit()
 	tx, err := drv.Tx(context.Background())
@@ -65,7 +66,7 @@ func TestWithVars(t *testing.T) {
 	// Rows should not be closed to release the session,
 	// as a transaction is always scoped to a single connection.
 
-	mock.ExpectExec("SET foo = 'qux'").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT set_config($1, $2, $3)")).WithArgs("foo", "qux", false).WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectExec("INSERT INTO users DEFAULT VALUES").WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectExec("RESET foo").WillReturnResult(sqlmock.NewResult(0, 0))
 	err = drv.Exec(
@@ -78,7 +79,7 @@ func TestWithVars(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 	// No rows are returned, so no need to close them.
 
-	mock.ExpectExec("SET foo = 'foo'").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT set_config($1, $2, $3)")).WithArgs("foo", "foo", false).WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectExec("INSERT INTO users DEFAULT VALUES").WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectExec("RESET foo").WillReturnResult(sqlmock.NewResult(0, 0))
 	err = drv.Exec(
@@ -91,3 +92,87 @@ func TestWithVars(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 	// No rows are returned, so no need to close them.
 }
+
+func TestVarsDriver(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	type tenantKey struct{}
+	drv := NewVarsDriver(OpenDB(dialect.Postgres, db), map[string]VarFunc{
+		"app.tenant": func(ctx context.Context) (string, bool) {
+			v, ok := ctx.Value(tenantKey{}).(string)
+			return v, ok
+		},
+	})
+	ctx := context.WithValue(context.Background(), tenantKey{}, "1")
+	mock.ExpectExec(regexp.QuoteMeta("SELECT set_config($1, $2, $3)")).WithArgs("app.tenant", "1", false).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT 1").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectExec("RESET app.tenant").WillReturnResult(sqlmock.NewResult(0, 0))
+	rows := &Rows{}
+	require.NoError(t, drv.Query(ctx, "SELECT 1", []any{}, rows))
+	require.NoError(t, rows.Close())
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// Variables are not set if they cannot be resolved, or were set explicitly.
+	mock.ExpectExec("INSERT INTO users DEFAULT VALUES").WillReturnResult(sqlmock.NewResult(0, 0))
+	require.NoError(t, drv.Exec(context.Background(), "INSERT INTO users DEFAULT VALUES", []any{}, nil))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT set_config($1, $2, $3)")).WithArgs("app.tenant", "2", false).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO users DEFAULT VALUES").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RESET app.tenant").WillReturnResult(sqlmock.NewResult(0, 0))
+	require.NoError(t, drv.Exec(WithVar(ctx, "app.tenant", "2"), "INSERT INTO users DEFAULT VALUES", []any{}, nil))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT set_config($1, $2, $3)")).WithArgs("app.tenant", "1", true).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO users DEFAULT VALUES").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	tx, err := drv.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, tx.Exec(ctx, "INSERT INTO users DEFAULT VALUES", []any{}, nil))
+	require.NoError(t, tx.Commit())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithVars_Escape(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	ctx := WithVar(context.Background(), "app.tenant", "1'; DROP TABLE users; --")
+	// Values are passed as arguments, and not interpolated into the statement.
+	mock.ExpectExec(regexp.QuoteMeta("SET app.tenant = ?")).WithArgs("1'; DROP TABLE users; --").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO users DEFAULT VALUES").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("SET app.tenant = NULL")).WillReturnResult(sqlmock.NewResult(0, 0))
+	require.NoError(t, OpenDB(dialect.MySQL, db).Exec(ctx, "INSERT INTO users DEFAULT VALUES", []any{}, nil))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSavepoint(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	drv := OpenDB(dialect.SQLite, db)
+	ctx := context.Background()
+	mock.ExpectBegin()
+	tx, err := drv.Tx(ctx)
+	require.NoError(t, err)
+
+	mock.ExpectExec("SAVEPOINT ent_savepoint_\\d+").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO users DEFAULT VALUES").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("DELETE FROM users").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("RELEASE SAVEPOINT ent_savepoint_\\d+").WillReturnResult(sqlmock.NewResult(0, 0))
+	sp, err := Savepoint(ctx, tx)
+	require.NoError(t, err)
+	require.NoError(t, sp.Exec(ctx, "INSERT INTO users DEFAULT VALUES", []any{}, nil))
+	_, err = sp.ExecContext(ctx, "DELETE FROM users")
+	require.NoError(t, err)
+	require.NoError(t, sp.Commit())
+
+	mock.ExpectExec("SAVEPOINT ent_savepoint_\\d+").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT ent_savepoint_\\d+").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RELEASE SAVEPOINT ent_savepoint_\\d+").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	sp2, err := Savepoint(ctx, tx)
+	require.NoError(t, err)
+	require.NotEqual(t, sp.Name(), sp2.Name())
+	require.NoError(t, sp2.Rollback())
+	require.NoError(t, tx.Commit())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,221 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect"
+)
+
+// DurationFormat defines the storage format of duration values. By default, durations are
+// stored as interval in PostgreSQL, and as bigint (nanoseconds) in other dialects.
+type DurationFormat string
+
+// Duration storage formats.
+const (
+	// DurationNanoseconds stores durations as bigint (nanoseconds) in all dialects, including PostgreSQL.
+	DurationNanoseconds DurationFormat = "nanoseconds"
+	// DurationTime stores durations as TIME in MySQL. The format is ignored by other dialects,
+	// and it is limited to the range of the MySQL TIME type (-838:59:59 to 838:59:59).
+	DurationTime DurationFormat = "time"
+)
+
+// DurationValue wraps a time.Duration that is stored based on its format and the dialect
+// of the Builder. For example, as an interval in PostgreSQL and as a bigint in SQLite.
+type DurationValue struct {
+	D      time.Duration  // Wrapped duration.
+	Format DurationFormat // Optional storage format.
+}
+
+// Duration wraps the given duration as a DurationValue.
+//
+//	Update("users").
+//		Set("timeout", Duration(time.Minute))
+func Duration(d time.Duration, format ...DurationFormat) *DurationValue {
+	v := &DurationValue{D: d}
+	if len(format) > 0 {
+		v.Format = format[0]
+	}
+	return v
+}
+
+// Durations wraps the given durations as DurationValues. It is used
+// by variadic predicates, like In and NotIn.
+//
+//	FieldIn("timeout", Durations([]time.Duration{time.Second, time.Minute})...)
+func Durations(ds []time.Duration, format ...DurationFormat) []any {
+	vs := make([]any, len(ds))
+	for i := range ds {
+		vs[i] = Duration(ds[i], format...)
+	}
+	return vs
+}
+
+// Encode returns the representation of the duration in the given dialect. It is
+// used by the Builder for encoding arguments, and by the migration for defaults.
+func (v *DurationValue) Encode(name string) any {
+	switch {
+	case v.Format == DurationNanoseconds:
+		return int64(v.D)
+	case name == dialect.Postgres:
+		return formatDuration(v.D, false)
+	case name == dialect.MySQL && v.Format == DurationTime:
+		return formatDuration(v.D, true)
+	default:
+		return int64(v.D)
+	}
+}
+
+// add writes the expression for adding the duration to the given column.
+func (v *DurationValue) add(b *Builder, column string) {
+	switch {
+	case v.Format != DurationNanoseconds && b.postgres():
+		b.WriteString("COALESCE").Wrap(func(b *Builder) {
+			b.Ident(column).Comma().WriteString("INTERVAL '0'")
+		})
+		b.WriteString(" + ").Arg(v)
+	case v.Format == DurationTime && b.Dialect() == dialect.MySQL:
+		b.WriteString("ADDTIME").Wrap(func(b *Builder) {
+			b.WriteString("COALESCE").Wrap(func(b *Builder) {
+				b.Ident(column).Comma().WriteString("'00:00:00'")
+			})
+			b.Comma().Arg(v)
+		})
+	default:
+		b.WriteString("COALESCE").Wrap(func(b *Builder) {
+			b.Ident(column).Comma().WriteByte('0')
+		})
+		b.WriteString(" + ").Arg(v)
+	}
+}
+
+// NullDuration represents a time.Duration that may be null. It scans
+// durations that were stored as PostgreSQL intervals, MySQL TIME values
+// or integers (nanoseconds).
+type NullDuration struct {
+	Duration time.Duration
+	Valid    bool // Valid is true if Duration is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullDuration) Scan(src any) (err error) {
+	n.Duration, n.Valid = 0, false
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case int64:
+		n.Duration = time.Duration(v)
+	case float64:
+		n.Duration = time.Duration(v)
+	case []byte:
+		n.Duration, err = ParseDuration(string(v))
+	case string:
+		n.Duration, err = ParseDuration(v)
+	default:
+		return fmt.Errorf("sql: unsupported duration value %T", src)
+	}
+	n.Valid = err == nil
+	return err
+}
+
+// ParseDuration parses a duration that was stored in the database. The value can be an
+// integer of nanoseconds, a PostgreSQL interval in its default output format (e.g.
+// "1 day 02:00:00"), or a MySQL TIME value (e.g. "-01:30:00.000000"). Note that, as
+// in PostgreSQL, months are considered to be 30 days and years to be 365.25 days.
+func ParseDuration(s string) (time.Duration, error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Duration(n), nil
+	}
+	var (
+		d      time.Duration
+		fields = strings.Fields(s)
+	)
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		if strings.Contains(f, ":") {
+			t, err := parseClock(f)
+			if err != nil {
+				return 0, fmt.Errorf("sql: invalid duration %q: %w", s, err)
+			}
+			d += t
+			continue
+		}
+		if i+1 == len(fields) {
+			return 0, fmt.Errorf("sql: invalid duration %q: missing unit for %q", s, f)
+		}
+		n, err := strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("sql: invalid duration %q: %w", s, err)
+		}
+		i++
+		switch unit := strings.TrimSuffix(fields[i], "s"); unit {
+		case "year":
+			d += time.Duration(n) * 8766 * time.Hour
+		case "mon":
+			d += time.Duration(n) * 30 * 24 * time.Hour
+		case "day":
+			d += time.Duration(n) * 24 * time.Hour
+		default:
+			return 0, fmt.Errorf("sql: invalid duration %q: unknown unit %q", s, fields[i])
+		}
+	}
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("sql: invalid duration %q", s)
+	}
+	return d, nil
+}
+
+// parseClock parses a duration in the format of [+-]hh:mm:ss[.ffffff].
+func parseClock(s string) (time.Duration, error) {
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimLeft(s, "+-")
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("unexpected time format %q", s)
+	}
+	h, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	m, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	sec, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return 0, err
+	}
+	d := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(sec*float64(time.Second)+0.5)
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// formatDuration formats the duration in the format of [-]hh:mm:ss[.ffffff]. Fractional
+// seconds are printed with a fixed precision (as in MySQL), or without trailing zeros (as
+// in PostgreSQL).
+func formatDuration(d time.Duration, fixed bool) string {
+	var b strings.Builder
+	if d < 0 {
+		b.WriteByte('-')
+		d = -d
+	}
+	h, d := d/time.Hour, d%time.Hour
+	m, d := d/time.Minute, d%time.Minute
+	s, us := d/time.Second, (d%time.Second)/time.Microsecond
+	fmt.Fprintf(&b, "%02d:%02d:%02d", h, m, s)
+	switch frac := fmt.Sprintf("%06d", us); {
+	case fixed:
+		b.WriteString("." + frac)
+	case us > 0:
+		b.WriteString("." + strings.TrimRight(frac, "0"))
+	}
+	return b.String()
+}
@@ -0,0 +1,108 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sql
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationValue(t *testing.T) {
+	d := time.Hour + 30*time.Minute + 1500*time.Millisecond
+	query, args := Dialect(dialect.Postgres).
+		Insert("jobs").
+		Columns("timeout", "delay", "ttl").
+		Values(Duration(d), Duration(-time.Second, DurationTime), Duration(time.Second, DurationNanoseconds)).
+		Query()
+	require.Equal(t, `INSERT INTO "jobs" ("timeout", "delay", "ttl") VALUES ($1, $2, $3)`, query)
+	require.Equal(t, []any{"01:30:01.5", "-00:00:01", int64(time.Second)}, args)
+
+	query, args = Dialect(dialect.MySQL).
+		Select().
+		From(Table("jobs")).
+		Where(And(GT("timeout", Duration(d)), In("delay", Durations([]time.Duration{time.Second, 100 * time.Hour}, DurationTime)...))).
+		Query()
+	require.Equal(t, "SELECT * FROM `jobs` WHERE `timeout` > ? AND `delay` IN (?, ?)", query)
+	require.Equal(t, []any{int64(d), "00:00:01.000000", "100:00:00.000000"}, args)
+
+	query, args = Dialect(dialect.SQLite).Update("jobs").Set("delay", Duration(time.Second, DurationTime)).Query()
+	require.Equal(t, "UPDATE `jobs` SET `delay` = ?", query)
+	require.Equal(t, []any{int64(time.Second)}, args)
+}
+
+func TestDurationAdd(t *testing.T) {
+	query, args := Dialect(dialect.Postgres).
+		Update("jobs").
+		Add("timeout", Duration(time.Minute)).
+		Add("ttl", Duration(time.Minute, DurationNanoseconds)).
+		Query()
+	require.Equal(t, `UPDATE "jobs" SET "timeout" = COALESCE("jobs"."timeout", INTERVAL '0') + $1, "ttl" = COALESCE("jobs"."ttl", 0) + $2`, query)
+	require.Equal(t, []any{"00:01:00", int64(time.Minute)}, args)
+
+	query, args = Dialect(dialect.MySQL).
+		Update("jobs").
+		Add("timeout", Duration(time.Minute)).
+		Add("delay", Duration(time.Minute, DurationTime)).
+		Query()
+	require.Equal(t, "UPDATE `jobs` SET `timeout` = COALESCE(`jobs`.`timeout`, 0) + ?, `delay` = ADDTIME(COALESCE(`jobs`.`delay`, '00:00:00'), ?)", query)
+	require.Equal(t, []any{int64(time.Minute), "00:01:00.000000"}, args)
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "60000000000", want: time.Minute},
+		{in: "-5", want: -5},
+		{in: "00:00:00", want: 0},
+		{in: "01:30:01.5", want: time.Hour + 30*time.Minute + 1500*time.Millisecond},
+		{in: "-00:00:01.000001", want: -time.Second - time.Microsecond},
+		{in: "838:59:59.000000", want: 838*time.Hour + 59*time.Minute + 59*time.Second},
+		{in: "1 day", want: 24 * time.Hour},
+		{in: "3 days 02:00:00", want: 74 * time.Hour},
+		{in: "-1 days +02:00:00", want: -22 * time.Hour},
+		{in: "1 mon 1 day", want: 31 * 24 * time.Hour},
+		{in: "1 year", want: 8766 * time.Hour},
+		{in: "", wantErr: true},
+		{in: "1h", wantErr: true},
+		{in: "1 week", wantErr: true},
+		{in: "01:00", wantErr: true},
+		{in: "3", want: 3},
+		{in: "3 days", want: 72 * time.Hour},
+		{in: "x days", wantErr: true},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			d, err := ParseDuration(tt.in)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
+
+func TestNullDuration(t *testing.T) {
+	var n NullDuration
+	require.NoError(t, n.Scan(nil))
+	require.False(t, n.Valid)
+	require.NoError(t, n.Scan(int64(time.Second)))
+	require.Equal(t, NullDuration{Duration: time.Second, Valid: true}, n)
+	require.NoError(t, n.Scan([]byte("2 days 00:00:01")))
+	require.Equal(t, NullDuration{Duration: 48*time.Hour + time.Second, Valid: true}, n)
+	require.NoError(t, n.Scan("-00:01:00.000000"))
+	require.Equal(t, NullDuration{Duration: -time.Minute, Valid: true}, n)
+	require.Error(t, n.Scan(true))
+	require.False(t, n.Valid)
+}
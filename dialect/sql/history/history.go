@@ -0,0 +1,243 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package history implements the row history that is recorded by the code generated
+// for the types that were annotated with entsql.History. Each mutation of an entity
+// writes a revision to the history table of its type, which mirrors the columns of the
+// entity table, and holds the operation, the actor and the time of the revision.
+package history
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/schema"
+	"entgo.io/ent/schema/field"
+)
+
+// Suffix and column names of the history tables.
+const (
+	TableSuffix = "_history"
+	ColumnID    = "history_id"
+	ColumnOp    = "history_op"
+	ColumnActor = "history_actor"
+	ColumnTime  = "history_time"
+)
+
+// TableName returns the name of the history table of the given table.
+func TableName(table string) string {
+	return table + TableSuffix
+}
+
+// NewTable returns the schema definition of the history table of the given table. It is added by
+// the code generator to the migration of the schema, for the types that were annotated with History.
+// The columns of the table are mirrored as nullable columns without constraints and defaults, as
+// revisions of deleted entities hold only their identifiers.
+func NewTable(t *schema.Table) *schema.Table {
+	id := &schema.Column{Name: ColumnID, Type: field.TypeInt64, Increment: true}
+	h := schema.NewTable(TableName(t.Name)).
+		SetSchema(t.Schema).
+		SetComment(fmt.Sprintf("Revisions of the %s table", t.Name)).
+		AddPrimary(id)
+	for _, c := range t.Columns {
+		h.AddColumn(&schema.Column{
+			Name:       c.Name,
+			Type:       c.Type,
+			SchemaType: c.SchemaType,
+			Attr:       c.Attr,
+			Size:       c.Size,
+			Nullable:   true,
+			Enums:      c.Enums,
+			EnumType:   c.EnumType,
+			Collation:  c.Collation,
+		})
+	}
+	h.AddColumn(&schema.Column{Name: ColumnOp, Type: field.TypeString}).
+		AddColumn(&schema.Column{Name: ColumnActor, Type: field.TypeString, Nullable: true}).
+		AddColumn(&schema.Column{Name: ColumnTime, Type: field.TypeTime})
+	columns := make([]string, 0, len(t.PrimaryKey)+1)
+	for _, c := range t.PrimaryKey {
+		columns = append(columns, c.Name)
+	}
+	columns = append(columns, ColumnTime)
+	h.AddIndex(strings.ToLower(h.Name+"_"+strings.Join(columns, "_")), false, columns)
+	return h
+}
+
+// actorKey is the context key that holds the actor of the mutations.
+type actorKey struct{}
+
+// WithActor returns a new context that holds the given actor (e.g. the identifier
+// of the authenticated user), which is recorded in the revisions of the mutations
+// that are executed with it.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor that was stored in the context by WithActor, if exists.
+func ActorFromContext(ctx context.Context) (string, bool) {
+	actor, ok := ctx.Value(actorKey{}).(string)
+	return actor, ok
+}
+
+// Revision holds the information of a revision that was recorded in a history table.
+type Revision struct {
+	// HistoryID is the identifier of the revision. Revisions of the same
+	// entity are ordered by their identifiers.
+	HistoryID int64 `json:"history_id"`
+	// Op is the operation that created the revision.
+	Op ent.Op `json:"history_op"`
+	// Actor that was stored in the context of the operation (see WithActor).
+	Actor string `json:"history_actor,omitempty"`
+	// Time is the time the revision was recorded.
+	Time time.Time `json:"history_time"`
+}
+
+// Columns holds the columns of the history tables that hold the revision information.
+var Columns = []string{ColumnID, ColumnOp, ColumnActor, ColumnTime}
+
+// ScanValues returns the scan destinations for the Columns of the revision.
+func (r *Revision) ScanValues() []any {
+	return []any{&r.HistoryID, (*opScanner)(&r.Op), (*actorScanner)(&r.Actor), &r.Time}
+}
+
+// Deleted reports if the revision records the deletion of the entity.
+func (r *Revision) Deleted() bool {
+	return r.Op.Is(ent.OpDelete | ent.OpDeleteOne)
+}
+
+// opScanner scans the name of an operation into an ent.Op.
+type opScanner ent.Op
+
+// Scan implements the sql.Scanner interface.
+func (o *opScanner) Scan(src any) error {
+	var s sql.NullString
+	if err := s.Scan(src); err != nil {
+		return err
+	}
+	for _, op := range []ent.Op{ent.OpCreate, ent.OpUpdate, ent.OpUpdateOne, ent.OpDelete, ent.OpDeleteOne} {
+		if op.String() == s.String {
+			*o = opScanner(op)
+			return nil
+		}
+	}
+	return fmt.Errorf("history: unknown operation %q", s.String)
+}
+
+// actorScanner scans a nullable actor into a string.
+type actorScanner string
+
+// Scan implements the sql.Scanner interface.
+func (a *actorScanner) Scan(src any) error {
+	var s sql.NullString
+	if err := s.Scan(src); err != nil {
+		return err
+	}
+	*a = actorScanner(s.String)
+	return nil
+}
+
+// Table describes an entity table whose rows are recorded in its history table.
+type Table struct {
+	// Name of the entity table.
+	Name string
+	// ID is the identifier column of the entity table.
+	ID string
+	// Columns of the entity table that are copied to the
+	// history table (including the identifier column).
+	Columns []string
+}
+
+// Record writes the revisions of the given entities to the history table, and should be called after
+// the mutation was executed. The current rows of the entities are copied to the history table, except
+// for deletions that record only the identifiers of the entities. It should be called with the
+// same driver (or transaction) of the mutation.
+func (t *Table) Record(ctx context.Context, drv dialect.Driver, op ent.Op, ids ...any) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	var (
+		b     = sql.Dialect(drv.Dialect())
+		now   = time.Now().UTC()
+		actor any
+	)
+	if a, ok := ActorFromContext(ctx); ok {
+		actor = a
+	}
+	insert := b.Insert(TableName(t.Name))
+	if op.Is(ent.OpDelete | ent.OpDeleteOne) {
+		insert.Columns(t.ID, ColumnOp, ColumnActor, ColumnTime)
+		for _, id := range ids {
+			insert.Values(id, op.String(), actor, now)
+		}
+	} else {
+		et := sql.Table(t.Name)
+		selector := b.Select(et.Columns(t.Columns...)...).
+			AppendSelectExpr(
+				arg(op.String(), "VARCHAR(255)"),
+				arg(actor, "VARCHAR(255)"),
+				arg(now, "TIMESTAMP WITH TIME ZONE"),
+			).
+			From(et).
+			Where(sql.In(et.C(t.ID), ids...))
+		insert.Columns(append(t.Columns[:len(t.Columns):len(t.Columns)], ColumnOp, ColumnActor, ColumnTime)...).
+			Select(selector)
+	}
+	query, args, err := insert.QueryErr()
+	if err != nil {
+		return err
+	}
+	if err := drv.Exec(ctx, query, args, nil); err != nil {
+		return fmt.Errorf("history: recording revisions of %q: %w", t.Name, err)
+	}
+	return nil
+}
+
+// arg returns an expression for an argument in the selection of an INSERT INTO ... SELECT statement.
+// PostgreSQL cannot infer the types of such arguments, and therefore, they are casted explicitly.
+func arg(v any, typ string) sql.Querier {
+	return sql.ExprFunc(func(b *sql.Builder) {
+		if b.Dialect() != dialect.Postgres {
+			b.Arg(v)
+			return
+		}
+		b.WriteString("CAST(").Arg(v).WriteString(" AS " + typ + ")")
+	})
+}
+
+// Query returns a selector for the revisions of the entities with the given identifiers, or
+// all entities if no identifiers were provided, ordered by their identifiers. The selection
+// starts with the Columns of the Table, followed by the Columns of the revisions.
+//
+// If asOf is not nil, the selector returns only the last revision of each entity that was
+// recorded at or before the given time, unless the entity was deleted by then. That is, the
+// state of the entities at the given time.
+func (t *Table) Query(d string, asOf *time.Time, ids ...any) *sql.Selector {
+	ht := sql.Table(TableName(t.Name))
+	columns := append(ht.Columns(t.Columns...), ht.Columns(Columns...)...)
+	s := sql.Dialect(d).Select(columns...).From(ht)
+	if len(ids) > 0 {
+		s.Where(sql.In(ht.C(t.ID), ids...))
+	}
+	if asOf != nil {
+		lt := sql.Table(TableName(t.Name)).As("l")
+		last := sql.Dialect(d).Select(sql.Max(lt.C(ColumnID))).
+			From(lt).
+			Where(sql.LTE(lt.C(ColumnTime), asOf.UTC())).
+			GroupBy(lt.C(t.ID))
+		if len(ids) > 0 {
+			last.Where(sql.In(lt.C(t.ID), ids...))
+		}
+		s.Where(sql.And(
+			sql.In(ht.C(ColumnID), last),
+			sql.NotIn(ht.C(ColumnOp), ent.OpDelete.String(), ent.OpDeleteOne.String()),
+		))
+	}
+	return s.OrderBy(ht.C(ColumnID))
+}
@@ -0,0 +1,109 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package history
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/schema"
+	"entgo.io/ent/schema/field"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+var users = &Table{Name: "users", ID: "id", Columns: []string{"id", "name", "group_users"}}
+
+func TestNewTable(t *testing.T) {
+	id := &schema.Column{Name: "id", Type: field.TypeInt, Increment: true}
+	name := &schema.Column{Name: "name", Type: field.TypeString, Unique: true, Default: "a8m"}
+	tb := schema.NewTable("users").AddPrimary(id).AddColumn(name)
+	h := NewTable(tb)
+	require.Equal(t, "users_history", h.Name)
+	require.Equal(t, []string{ColumnID, "id", "name", ColumnOp, ColumnActor, ColumnTime}, func() (names []string) {
+		for _, c := range h.Columns {
+			names = append(names, c.Name)
+		}
+		return
+	}())
+	require.True(t, h.PrimaryKey[0].Increment)
+	for _, c := range h.Columns[1:3] {
+		require.True(t, c.Nullable)
+		require.False(t, c.Unique || c.Increment)
+		require.Nil(t, c.Default)
+	}
+	require.Len(t, h.Indexes, 1)
+	require.Equal(t, "users_history_id_history_time", h.Indexes[0].Name)
+	require.Equal(t, []*schema.Column{h.Columns[1], h.Columns[5]}, h.Indexes[0].Columns)
+}
+
+func TestTable_Record(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	ctx := WithActor(context.Background(), "a8m")
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users_history` (`id`, `name`, `group_users`, `history_op`, `history_actor`, `history_time`) SELECT `users`.`id`, `users`.`name`, `users`.`group_users`, ?, ?, ? FROM `users` WHERE `users`.`id` IN (?, ?)")).
+		WithArgs("OpUpdate", "a8m", sqlmock.AnyArg(), 1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users_history` (`id`, `history_op`, `history_actor`, `history_time`) VALUES (?, ?, NULL, ?), (?, ?, NULL, ?)")).
+		WithArgs(1, "OpDelete", sqlmock.AnyArg(), 2, "OpDelete", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	drv := sql.OpenDB(dialect.MySQL, db)
+	require.NoError(t, users.Record(ctx, drv, ent.OpUpdate, 1, 2))
+	require.NoError(t, users.Record(context.Background(), drv, ent.OpDelete, 1, 2))
+	require.NoError(t, users.Record(ctx, drv, ent.OpCreate))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	db, mock, err = sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "users_history" ("id", "name", "group_users", "history_op", "history_actor", "history_time") SELECT "users"."id", "users"."name", "users"."group_users", CAST($1 AS VARCHAR(255)), CAST(NULL AS VARCHAR(255)), CAST($2 AS TIMESTAMP WITH TIME ZONE) FROM "users" WHERE "users"."id" IN ($3)`)).
+		WithArgs("OpCreate", sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, users.Record(context.Background(), sql.OpenDB(dialect.Postgres, db), ent.OpCreate, 1))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTable_Query(t *testing.T) {
+	query, args := users.Query(dialect.Postgres, nil).Query()
+	require.Equal(t, `SELECT "users_history"."id", "users_history"."name", "users_history"."group_users", "users_history"."history_id", "users_history"."history_op", "users_history"."history_actor", "users_history"."history_time" FROM "users_history" ORDER BY "users_history"."history_id"`, query)
+	require.Empty(t, args)
+
+	asOf := time.Now()
+	query, args = users.Query(dialect.Postgres, &asOf, 1).Query()
+	require.Equal(t, `SELECT "users_history"."id", "users_history"."name", "users_history"."group_users", "users_history"."history_id", "users_history"."history_op", "users_history"."history_actor", "users_history"."history_time" FROM "users_history" WHERE "users_history"."id" IN ($1) AND ("users_history"."history_id" IN (SELECT MAX("l"."history_id") FROM "users_history" AS "l" WHERE "l"."history_time" <= $2 AND "l"."id" IN ($3) GROUP BY "l"."id") AND "users_history"."history_op" NOT IN ($4, $5)) ORDER BY "users_history"."history_id"`, query)
+	require.Equal(t, []any{1, asOf.UTC(), 1, "OpDelete", "OpDeleteOne"}, args)
+}
+
+func TestRevision_ScanValues(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	now := time.Now()
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(Columns).
+		AddRow(1, "OpUpdateOne", "a8m", now).
+		AddRow(2, "OpDeleteOne", nil, now).
+		AddRow(3, "OpUnknown", nil, now))
+	rows, err := db.Query("SELECT")
+	require.NoError(t, err)
+	defer rows.Close()
+	var revs []*Revision
+	for rows.Next() {
+		var r Revision
+		if err := rows.Scan(r.ScanValues()...); err != nil {
+			require.EqualError(t, err, `sql: Scan error on column index 1, name "history_op": history: unknown operation "OpUnknown"`)
+			break
+		}
+		revs = append(revs, &r)
+	}
+	require.Len(t, revs, 2)
+	require.Equal(t, &Revision{HistoryID: 1, Op: ent.OpUpdateOne, Actor: "a8m", Time: now}, revs[0])
+	require.False(t, revs[0].Deleted())
+	require.Empty(t, revs[1].Actor)
+	require.True(t, revs[1].Deleted())
+}
@@ -0,0 +1,264 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package otelsql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Driver is a dialect.Driver that instruments all operations of the
+// underlying driver with OpenTelemetry spans and metrics.
+type Driver struct {
+	dialect.Driver // underlying driver.
+	*instruments
+}
+
+// NewDriver returns a new instrumented driver that wraps the given driver.
+func NewDriver(drv dialect.Driver, opts ...Option) *Driver {
+	return &Driver{Driver: drv, instruments: newInstruments(drv.Dialect(), opts)}
+}
+
+// Exec records a span and metrics for the statement and calls the underlying driver Exec method.
+func (d *Driver) Exec(ctx context.Context, query string, args, v any) error {
+	return d.record(ctx, "Exec", query, nil, func(ctx context.Context) (sql.Result, error) {
+		if err := d.Driver.Exec(ctx, query, args, v); err != nil {
+			return nil, err
+		}
+		return result(v), nil
+	})
+}
+
+// ExecContext records a span and metrics for the statement and calls the underlying driver
+// ExecContext method if it is supported.
+func (d *Driver) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	drv, ok := d.Driver.(interface {
+		ExecContext(context.Context, string, ...any) (sql.Result, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.ExecContext is not supported")
+	}
+	var res sql.Result
+	err := d.record(ctx, "ExecContext", query, nil, func(ctx context.Context) (r sql.Result, err error) {
+		res, err = drv.ExecContext(ctx, query, args...)
+		return res, err
+	})
+	return res, err
+}
+
+// Query records a span and metrics for the statement and calls the underlying driver Query method.
+func (d *Driver) Query(ctx context.Context, query string, args, v any) error {
+	return d.record(ctx, "Query", query, nil, func(ctx context.Context) (sql.Result, error) {
+		return nil, d.Driver.Query(ctx, query, args, v)
+	})
+}
+
+// QueryContext records a span and metrics for the statement and calls the underlying driver
+// QueryContext method if it is supported.
+func (d *Driver) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	drv, ok := d.Driver.(interface {
+		QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.QueryContext is not supported")
+	}
+	var rows *sql.Rows
+	err := d.record(ctx, "QueryContext", query, nil, func(ctx context.Context) (_ sql.Result, err error) {
+		rows, err = drv.QueryContext(ctx, query, args...)
+		return nil, err
+	})
+	return rows, err
+}
+
+// Tx starts a span that lives until the transaction is committed or rolled
+// back, and calls the underlying driver Tx method.
+func (d *Driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	return d.begin(ctx, "Tx", d.Driver.Tx)
+}
+
+// BeginTx starts a span that lives until the transaction is committed or rolled
+// back, and calls the underlying driver BeginTx method if it is supported.
+func (d *Driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.BeginTx is not supported")
+	}
+	return d.begin(ctx, "BeginTx", func(ctx context.Context) (dialect.Tx, error) {
+		return drv.BeginTx(ctx, opts)
+	})
+}
+
+// begin starts the transaction span and wraps the transaction returned by f.
+func (d *Driver) begin(ctx context.Context, name string, f func(context.Context) (dialect.Tx, error)) (dialect.Tx, error) {
+	ctx, span := d.tracer.Start(ctx, d.formatSpanName(ctx, Operation{Name: name}),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(append(d.attrs, OperationAttribute.String(name))...),
+	)
+	tx, err := f(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+		return nil, err
+	}
+	return &Tx{
+		Tx:          tx,
+		instruments: d.instruments,
+		ctx:         ctx,
+		span:        span,
+		id:          span.SpanContext().SpanID().String(),
+	}, nil
+}
+
+// Tx is a dialect.Tx that instruments all operations of the underlying
+// transaction with OpenTelemetry spans and metrics.
+type Tx struct {
+	dialect.Tx // underlying transaction.
+	*instruments
+	ctx  context.Context // context of the transaction span.
+	span trace.Span      // transaction span.
+	id   string          // transaction identifier.
+}
+
+// Exec records a span and metrics for the statement and calls the underlying transaction Exec method.
+func (t *Tx) Exec(ctx context.Context, query string, args, v any) error {
+	return t.record(ctx, "Exec", query, t.txAttrs(), func(ctx context.Context) (sql.Result, error) {
+		if err := t.Tx.Exec(ctx, query, args, v); err != nil {
+			return nil, err
+		}
+		return result(v), nil
+	})
+}
+
+// ExecContext records a span and metrics for the statement and calls the underlying transaction
+// ExecContext method if it is supported.
+func (t *Tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	tx, ok := t.Tx.(interface {
+		ExecContext(context.Context, string, ...any) (sql.Result, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Tx.ExecContext is not supported")
+	}
+	var res sql.Result
+	err := t.record(ctx, "ExecContext", query, t.txAttrs(), func(ctx context.Context) (r sql.Result, err error) {
+		res, err = tx.ExecContext(ctx, query, args...)
+		return res, err
+	})
+	return res, err
+}
+
+// Query records a span and metrics for the statement and calls the underlying transaction Query method.
+func (t *Tx) Query(ctx context.Context, query string, args, v any) error {
+	return t.record(ctx, "Query", query, t.txAttrs(), func(ctx context.Context) (sql.Result, error) {
+		return nil, t.Tx.Query(ctx, query, args, v)
+	})
+}
+
+// QueryContext records a span and metrics for the statement and calls the underlying transaction
+// QueryContext method if it is supported.
+func (t *Tx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	tx, ok := t.Tx.(interface {
+		QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Tx.QueryContext is not supported")
+	}
+	var rows *sql.Rows
+	err := t.record(ctx, "QueryContext", query, t.txAttrs(), func(ctx context.Context) (_ sql.Result, err error) {
+		rows, err = tx.QueryContext(ctx, query, args...)
+		return nil, err
+	})
+	return rows, err
+}
+
+// Commit commits the underlying transaction and ends the transaction span.
+func (t *Tx) Commit() error {
+	return t.end(StatusCommitted, t.Tx.Commit())
+}
+
+// Rollback rolls back the underlying transaction and ends the transaction span.
+func (t *Tx) Rollback() error {
+	return t.end(StatusRolledBack, t.Tx.Rollback())
+}
+
+// end ends the transaction span with the given status.
+func (t *Tx) end(status string, err error) error {
+	if err != nil {
+		t.span.RecordError(err)
+		t.span.SetStatus(codes.Error, err.Error())
+		status = StatusError
+	}
+	t.span.SetAttributes(StatusAttribute.String(status))
+	t.span.End()
+	t.txs.Add(t.ctx, 1, metric.WithAttributes(append(t.attrs, StatusAttribute.String(status))...))
+	return err
+}
+
+// txAttrs returns the attributes recorded on the statements executed in the transaction.
+func (t *Tx) txAttrs() []attribute.KeyValue {
+	return []attribute.KeyValue{TxAttribute.String(t.id)}
+}
+
+// record executes f in a new span and records the metrics for the statement.
+func (i *instruments) record(ctx context.Context, name, query string, extra []attribute.KeyValue, f func(context.Context) (sql.Result, error)) error {
+	op := operation(ctx, name)
+	attrs := append(append([]attribute.KeyValue{}, i.attrs...), OperationAttribute.String(name))
+	if op.Type != "" {
+		attrs = append(attrs, TypeAttribute.String(op.Type))
+	}
+	if op.Op != "" {
+		attrs = append(attrs, OpAttribute.String(op.Op))
+	}
+	ctx, span := i.tracer.Start(ctx, i.formatSpanName(ctx, op),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(append(attrs, extra...)...),
+	)
+	defer span.End()
+	if i.withStatement {
+		span.SetAttributes(StatementAttribute.String(query))
+	}
+	start := time.Now()
+	res, err := f(ctx)
+	elapsed := float64(time.Since(start)) / float64(time.Millisecond)
+	status := StatusOK
+	switch {
+	case err != nil:
+		status = StatusError
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case res != nil:
+		if n, err := res.RowsAffected(); err == nil {
+			span.SetAttributes(RowsAffectedAttribute.Int64(n))
+		}
+	}
+	opts := metric.WithAttributes(append(attrs, StatusAttribute.String(status))...)
+	i.queries.Add(ctx, 1, opts)
+	i.duration.Record(ctx, elapsed, opts)
+	return err
+}
+
+// result returns the sql.Result that was scanned into v, if any.
+func result(v any) sql.Result {
+	if r, ok := v.(*sql.Result); ok && r != nil {
+		return *r
+	}
+	return nil
+}
+
+var (
+	_ dialect.Driver = (*Driver)(nil)
+	_ dialect.Tx     = (*Tx)(nil)
+)
@@ -0,0 +1,115 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package otelsql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestDriver(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	var (
+		ctx      = context.Background()
+		exporter = tracetest.NewInMemoryExporter()
+		reader   = sdkmetric.NewManualReader()
+		drv      = NewDriver(
+			sql.OpenDB(dialect.Postgres, db),
+			WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))),
+			WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		)
+	)
+	require.Equal(t, dialect.Postgres, drv.Dialect())
+
+	// Query executed by a generated query builder.
+	mock.ExpectQuery("SELECT id FROM users").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	rows := &sql.Rows{}
+	qctx := ent.NewQueryContext(ctx, &ent.QueryContext{Type: "User", Op: ent.OpQueryAll})
+	require.NoError(t, drv.Query(qctx, "SELECT id FROM users", []any{}, rows))
+	require.NoError(t, rows.Close())
+
+	// Mutations executed in a transaction.
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE users SET name").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("DELETE FROM users").WillReturnError(errors.New("boom"))
+	mock.ExpectRollback()
+	tx, err := drv.Tx(ctx)
+	require.NoError(t, err)
+	var res sql.Result
+	mctx := context.WithValue(ctx, mutationCtxKey{}, Operation{Type: "User", Op: "Update"})
+	require.NoError(t, tx.Exec(mctx, "UPDATE users SET name = $1", []any{"a8m"}, &res))
+	require.Error(t, tx.Exec(ctx, "DELETE FROM users", []any{}, nil))
+	require.NoError(t, tx.Rollback())
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 4)
+	require.Equal(t, "User.All", spans[0].Name)
+	require.Contains(t, spans[0].Attributes, SystemAttribute.String("postgresql"))
+	require.Contains(t, spans[0].Attributes, StatementAttribute.String("SELECT id FROM users"))
+	require.Contains(t, spans[0].Attributes, OpAttribute.String(ent.OpQueryAll))
+
+	txID := TxAttribute.String(spans[3].SpanContext.SpanID().String())
+	require.Equal(t, "User.Update", spans[1].Name)
+	require.Contains(t, spans[1].Attributes, RowsAffectedAttribute.Int64(2))
+	require.Contains(t, spans[1].Attributes, txID)
+	require.Equal(t, "sql.Exec", spans[2].Name)
+	require.Equal(t, codes.Error, spans[2].Status.Code)
+	require.Contains(t, spans[2].Attributes, txID)
+	require.Equal(t, "sql.Tx", spans[3].Name)
+	require.Contains(t, spans[3].Attributes, StatusAttribute.String(StatusRolledBack))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	counts := make(map[string]int64)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		sum, ok := m.Data.(metricdata.Sum[int64])
+		if !ok {
+			continue
+		}
+		for _, p := range sum.DataPoints {
+			s, _ := p.Attributes.Value(StatusAttribute)
+			counts[m.Name+"/"+s.AsString()] += p.Value
+		}
+	}
+	require.Equal(t, map[string]int64{
+		"ent.sql.statements/ok":                    2,
+		"ent.sql.statements/error":                 1,
+		"ent.sql.transactions/" + StatusRolledBack: 1,
+	}, counts)
+}
+
+func TestHook(t *testing.T) {
+	var op Operation
+	mutator := Hook()(ent.MutateFunc(func(ctx context.Context, _ ent.Mutation) (ent.Value, error) {
+		op = operation(ctx, "Exec")
+		return nil, nil
+	}))
+	_, err := mutator.Mutate(context.Background(), mutation{})
+	require.NoError(t, err)
+	require.Equal(t, Operation{Name: "Exec", Type: "User", Op: "UpdateOne"}, op)
+	require.Equal(t, []attribute.KeyValue{SystemAttribute.String("sqlite")}, newInstruments(dialect.SQLite, nil).attrs)
+}
+
+type mutation struct{ ent.Mutation }
+
+func (mutation) Op() ent.Op   { return ent.OpUpdateOne }
+func (mutation) Type() string { return "User" }
@@ -0,0 +1,217 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package otelsql provides an OpenTelemetry instrumentation for the SQL dialect.
+// It is released as a separate module, so the OpenTelemetry dependencies are not
+// required by applications that do not use it.
+//
+//	drv, err := sql.Open(dialect.Postgres, dsn)
+//	if err != nil {
+//		return err
+//	}
+//	client := ent.NewClient(ent.Driver(otelsql.NewDriver(drv)))
+//	// Attach the mutation information to the spans.
+//	client.Use(otelsql.Hook())
+package otelsql
+
+import (
+	"context"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name used by the tracer and the meter.
+const ScopeName = "entgo.io/ent/dialect/sql/otelsql"
+
+// Attributes recorded on the spans and the metrics.
+const (
+	SystemAttribute       = attribute.Key("db.system")
+	StatementAttribute    = attribute.Key("db.statement")
+	OperationAttribute    = attribute.Key("db.operation")
+	RowsAffectedAttribute = attribute.Key("db.rows_affected")
+	TypeAttribute         = attribute.Key("ent.type")
+	OpAttribute           = attribute.Key("ent.op")
+	TxAttribute           = attribute.Key("ent.tx")
+	StatusAttribute       = attribute.Key("ent.status")
+)
+
+// Values of the StatusAttribute.
+const (
+	StatusOK         = "ok"
+	StatusError      = "error"
+	StatusCommitted  = "committed"
+	StatusRolledBack = "rolled_back"
+)
+
+type (
+	// Option allows configuring the instrumentation using functional options.
+	Option func(*config)
+
+	// config holds the instrumentation configuration.
+	config struct {
+		tp             trace.TracerProvider
+		mp             metric.MeterProvider
+		withStatement  bool
+		formatSpanName func(context.Context, Operation) string
+	}
+)
+
+// WithTracerProvider sets the tracer provider. Defaults to otel.GetTracerProvider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tp = tp
+	}
+}
+
+// WithMeterProvider sets the meter provider. Defaults to otel.GetMeterProvider.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.mp = mp
+	}
+}
+
+// WithStatement controls the recording of SQL statements in spans. Statements are
+// recorded by default. Note that statement arguments are never recorded.
+func WithStatement(b bool) Option {
+	return func(c *config) {
+		c.withStatement = b
+	}
+}
+
+// WithSpanNameFormatter sets the function used for generating the span names.
+// By default, the name is the ent operation, e.g. "User.Create" or "User.All",
+// or the SQL operation, e.g. "sql.Exec", if the statement was not executed by
+// the generated builders.
+func WithSpanNameFormatter(f func(context.Context, Operation) string) Option {
+	return func(c *config) {
+		c.formatSpanName = f
+	}
+}
+
+// Operation describes the operation that is being executed by the driver.
+type Operation struct {
+	// Name of the driver method. e.g. Exec, Query, Commit, etc.
+	Name string
+	// Type is the ent type that executed the statement, if known. e.g. User.
+	Type string
+	// Op is the ent operation that executed the statement, if known.
+	// e.g. Create, UpdateOne, All, Count, etc.
+	Op string
+}
+
+// String returns the default span name of the operation.
+func (o Operation) String() string {
+	if o.Type == "" || o.Op == "" {
+		return "sql." + o.Name
+	}
+	return o.Type + "." + o.Op
+}
+
+// mutationCtxKey is the key used for attaching the mutation information to the context.
+type mutationCtxKey struct{}
+
+// Hook returns a hook that attaches the mutation type and operation to the context,
+// in order to record them on the spans and metrics of the executed statements.
+//
+//	client.Use(otelsql.Hook())
+func Hook() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			op := m.Op().String()
+			if len(op) > 2 && op[:2] == "Op" {
+				op = op[2:]
+			}
+			return next.Mutate(context.WithValue(ctx, mutationCtxKey{}, Operation{Type: m.Type(), Op: op}), m)
+		})
+	}
+}
+
+// operation returns the operation that is executed by the given context.
+func operation(ctx context.Context, name string) Operation {
+	if op, ok := ctx.Value(mutationCtxKey{}).(Operation); ok {
+		op.Name = name
+		return op
+	}
+	op := Operation{Name: name}
+	if qc := ent.QueryFromContext(ctx); qc != nil {
+		op.Type, op.Op = qc.Type, qc.Op
+	}
+	return op
+}
+
+// system returns the OpenTelemetry name of the given dialect.
+func system(name string) string {
+	switch name {
+	case dialect.Postgres:
+		return "postgresql"
+	case dialect.SQLite:
+		return "sqlite"
+	default:
+		return name
+	}
+}
+
+// instruments holds the tracer and the metric instruments.
+type instruments struct {
+	*config
+	tracer   trace.Tracer
+	queries  metric.Int64Counter
+	duration metric.Float64Histogram
+	txs      metric.Int64Counter
+	attrs    []attribute.KeyValue
+}
+
+// newInstruments creates the instruments for the given dialect. Errors in
+// creating the metric instruments are reported to the global otel.Handle,
+// and the returned no-op instruments are used instead.
+func newInstruments(name string, opts []Option) *instruments {
+	cfg := &config{
+		tp:             otel.GetTracerProvider(),
+		mp:             otel.GetMeterProvider(),
+		withStatement:  true,
+		formatSpanName: func(_ context.Context, op Operation) string { return op.String() },
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	meter := cfg.mp.Meter(ScopeName)
+	queries, err := meter.Int64Counter(
+		"ent.sql.statements",
+		metric.WithDescription("Number of SQL statements executed"),
+		metric.WithUnit("{statement}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	duration, err := meter.Float64Histogram(
+		"ent.sql.duration",
+		metric.WithDescription("Duration of SQL statements"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	txs, err := meter.Int64Counter(
+		"ent.sql.transactions",
+		metric.WithDescription("Number of SQL transactions completed, by status"),
+		metric.WithUnit("{transaction}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	return &instruments{
+		config:   cfg,
+		tracer:   cfg.tp.Tracer(ScopeName),
+		queries:  queries,
+		duration: duration,
+		txs:      txs,
+		attrs:    []attribute.KeyValue{SystemAttribute.String(system(name))},
+	}
+}
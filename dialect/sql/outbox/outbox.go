@@ -0,0 +1,414 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package outbox implements the transactional outbox that is used by the code generated
+// with the "sql/outbox" feature-flag. Change records are written to the outbox table in the
+// same transaction as the mutations that created them, and are read by a Relay that passes
+// them to an external system (e.g. a message broker) and marks them as processed.
+package outbox
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/schema"
+	"entgo.io/ent/schema/field"
+)
+
+// Table and column names of the outbox table.
+const (
+	Table             = "ent_outbox"
+	ColumnID          = "id"
+	ColumnType        = "type"
+	ColumnOp          = "op"
+	ColumnEntityID    = "entity_id"
+	ColumnPayload     = "payload"
+	ColumnCreatedAt   = "created_at"
+	ColumnProcessedAt = "processed_at"
+)
+
+// Columns holds all the columns of the outbox table.
+var Columns = []string{
+	ColumnID,
+	ColumnType,
+	ColumnOp,
+	ColumnEntityID,
+	ColumnPayload,
+	ColumnCreatedAt,
+	ColumnProcessedAt,
+}
+
+// NewTable returns the schema definition of the outbox table. It is added by the code
+// generator to the migration of the schema, when the "sql/outbox" feature is enabled.
+func NewTable() *schema.Table {
+	id := &schema.Column{Name: ColumnID, Type: field.TypeInt64, Increment: true}
+	created := &schema.Column{Name: ColumnCreatedAt, Type: field.TypeTime}
+	processed := &schema.Column{Name: ColumnProcessedAt, Type: field.TypeTime, Nullable: true}
+	t := schema.NewTable(Table).
+		SetComment("Change records of the mutations that were not relayed yet").
+		AddPrimary(id).
+		AddColumn(&schema.Column{Name: ColumnType, Type: field.TypeString}).
+		AddColumn(&schema.Column{Name: ColumnOp, Type: field.TypeString}).
+		AddColumn(&schema.Column{Name: ColumnEntityID, Type: field.TypeString}).
+		AddColumn(&schema.Column{Name: ColumnPayload, Type: field.TypeJSON}).
+		AddColumn(created).
+		AddColumn(processed)
+	t.AddIndex("ent_outbox_processed_at_id", false, []string{ColumnProcessedAt, ColumnID})
+	return t
+}
+
+type (
+	// Change is a change record of a single entity that was written to the outbox table.
+	Change struct {
+		// ID of the record. Set by the database.
+		ID int64 `json:"id"`
+		// Type of the mutated entity. e.g. "User".
+		Type string `json:"type"`
+		// Op is the mutation operation. e.g. "OpUpdateOne".
+		Op string `json:"op"`
+		// EntityID holds the JSON encoded identifier of the mutated entity.
+		EntityID json.RawMessage `json:"entity_id"`
+		// Fields holds the changes of the fields that were set or cleared by the mutation.
+		Fields map[string]*FieldChange `json:"fields,omitempty"`
+		// AddedEdges and RemovedEdges hold the JSON encoded identifiers of the neighbors
+		// that were added or removed by the mutation, per edge.
+		AddedEdges   map[string]json.RawMessage `json:"added_edges,omitempty"`
+		RemovedEdges map[string]json.RawMessage `json:"removed_edges,omitempty"`
+		// ClearedEdges holds the names of the edges that were cleared by the mutation.
+		ClearedEdges []string `json:"cleared_edges,omitempty"`
+		// CreatedAt is the time the record was written.
+		CreatedAt time.Time `json:"created_at"`
+		// ProcessedAt is the time the record was marked as processed by the relay.
+		ProcessedAt *time.Time `json:"processed_at,omitempty"`
+	}
+
+	// FieldChange holds the old and the new JSON encoded values of a field. The old value is
+	// available only for UpdateOne operations, and the new value is null for cleared fields.
+	FieldChange struct {
+		Old json.RawMessage `json:"old,omitempty"`
+		New json.RawMessage `json:"new,omitempty"`
+	}
+
+	// payload is the representation of the changes in the payload column.
+	payload struct {
+		Fields       map[string]*FieldChange    `json:"fields,omitempty"`
+		AddedEdges   map[string]json.RawMessage `json:"added_edges,omitempty"`
+		RemovedEdges map[string]json.RawMessage `json:"removed_edges,omitempty"`
+		ClearedEdges []string                   `json:"cleared_edges,omitempty"`
+	}
+)
+
+// Decode decodes the old and the new values of the field into the given values.
+// A nil target, or a missing value, is skipped.
+func (c *FieldChange) Decode(old, new any) error {
+	if old != nil && c.Old != nil {
+		if err := json.Unmarshal(c.Old, old); err != nil {
+			return fmt.Errorf("outbox: decoding old value: %w", err)
+		}
+	}
+	if new != nil && c.New != nil {
+		if err := json.Unmarshal(c.New, new); err != nil {
+			return fmt.Errorf("outbox: decoding new value: %w", err)
+		}
+	}
+	return nil
+}
+
+// OldValues returns the values of the fields that are changed by the mutation, before it is
+// executed. Old values are loaded only for UpdateOne operations, and nil is returned otherwise.
+func OldValues(ctx context.Context, m ent.Mutation) (map[string]ent.Value, error) {
+	if !m.Op().Is(ent.OpUpdateOne) {
+		return nil, nil
+	}
+	old := make(map[string]ent.Value)
+	for _, fields := range [][]string{m.Fields(), m.ClearedFields()} {
+		for _, name := range fields {
+			v, err := m.OldField(ctx, name)
+			if err != nil {
+				return nil, fmt.Errorf("outbox: loading old value of field %q: %w", name, err)
+			}
+			old[name] = v
+		}
+	}
+	return old, nil
+}
+
+// NewChanges returns the change records of the given mutation for each of the entities it
+// mutated. It should be called after the mutation was executed, with the old values that were
+// loaded by OldValues before it was executed.
+func NewChanges(m ent.Mutation, old map[string]ent.Value, ids ...any) ([]*Change, error) {
+	var (
+		err error
+		tmp = &Change{Type: m.Type(), Op: m.Op().String()}
+	)
+	encode := func(v any) json.RawMessage {
+		if err != nil {
+			return nil
+		}
+		buf, merr := json.Marshal(v)
+		if merr != nil {
+			// Values that cannot be encoded as JSON (e.g. a net.IP that holds
+			// a textual address) are encoded using their database representation.
+			dv, derr := driver.DefaultParameterConverter.ConvertValue(v)
+			if derr != nil {
+				err = merr
+				return nil
+			}
+			buf, err = json.Marshal(dv)
+		}
+		return buf
+	}
+	if fields, cleared := m.Fields(), m.ClearedFields(); len(fields)+len(cleared) > 0 {
+		tmp.Fields = make(map[string]*FieldChange, len(fields)+len(cleared))
+		for _, name := range fields {
+			v, _ := m.Field(name)
+			tmp.Fields[name] = &FieldChange{New: encode(v)}
+		}
+		for _, name := range cleared {
+			tmp.Fields[name] = &FieldChange{}
+		}
+		for name, v := range old {
+			if c, ok := tmp.Fields[name]; ok {
+				c.Old = encode(v)
+			}
+		}
+	}
+	if edges := m.AddedEdges(); len(edges) > 0 {
+		tmp.AddedEdges = make(map[string]json.RawMessage, len(edges))
+		for _, name := range edges {
+			tmp.AddedEdges[name] = encode(m.AddedIDs(name))
+		}
+	}
+	if edges := m.RemovedEdges(); len(edges) > 0 {
+		tmp.RemovedEdges = make(map[string]json.RawMessage, len(edges))
+		for _, name := range edges {
+			tmp.RemovedEdges[name] = encode(m.RemovedIDs(name))
+		}
+	}
+	tmp.ClearedEdges = m.ClearedEdges()
+	changes := make([]*Change, len(ids))
+	for i, id := range ids {
+		c := *tmp
+		c.EntityID = encode(id)
+		changes[i] = &c
+	}
+	if err != nil {
+		return nil, fmt.Errorf("outbox: encoding %s change: %w", m.Type(), err)
+	}
+	return changes, nil
+}
+
+// Omit returns a mutation that hides the given fields from OldValues and NewChanges. It
+// is used by the generated code for keeping the values of sensitive fields out of the
+// change records.
+func Omit(m ent.Mutation, fields ...string) ent.Mutation {
+	if len(fields) == 0 {
+		return m
+	}
+	return &omitMutation{Mutation: m, omit: fields}
+}
+
+// omitMutation wraps a mutation and hides some of its fields.
+type omitMutation struct {
+	ent.Mutation
+	omit []string
+}
+
+// Fields returns the fields of the mutation that are not omitted.
+func (m *omitMutation) Fields() []string {
+	return m.filter(m.Mutation.Fields())
+}
+
+// ClearedFields returns the cleared fields of the mutation that are not omitted.
+func (m *omitMutation) ClearedFields() []string {
+	return m.filter(m.Mutation.ClearedFields())
+}
+
+func (m *omitMutation) filter(fields []string) []string {
+	return slices.DeleteFunc(slices.Clone(fields), func(f string) bool {
+		return slices.Contains(m.omit, f)
+	})
+}
+
+// Write writes the given change records to the outbox table. In order to be atomic with the
+// mutations that created them, the given driver should be the transaction they executed in.
+func Write(ctx context.Context, drv dialect.Driver, changes ...*Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	now := time.Now()
+	insert := sql.Dialect(drv.Dialect()).Insert(Table).
+		Columns(ColumnType, ColumnOp, ColumnEntityID, ColumnPayload, ColumnCreatedAt)
+	for _, c := range changes {
+		buf, err := json.Marshal(payload{
+			Fields:       c.Fields,
+			AddedEdges:   c.AddedEdges,
+			RemovedEdges: c.RemovedEdges,
+			ClearedEdges: c.ClearedEdges,
+		})
+		if err != nil {
+			return fmt.Errorf("outbox: encoding payload: %w", err)
+		}
+		c.CreatedAt = now
+		insert.Values(c.Type, c.Op, string(c.EntityID), buf, c.CreatedAt)
+	}
+	query, args := insert.Query()
+	if err := drv.Exec(ctx, query, args, nil); err != nil {
+		return fmt.Errorf("outbox: writing changes: %w", err)
+	}
+	return nil
+}
+
+// Relay reads the change records from the outbox table and marks them as processed.
+type Relay struct {
+	drv dialect.Driver
+}
+
+// NewRelay returns a new Relay for the outbox table of the given driver.
+func NewRelay(drv dialect.Driver) *Relay {
+	return &Relay{drv: drv}
+}
+
+// Pending returns up to limit change records that were not marked as processed, in the
+// order they were written.
+func (r *Relay) Pending(ctx context.Context, limit int) ([]*Change, error) {
+	return r.pending(ctx, r.drv, limit, false)
+}
+
+// MarkProcessed marks the change records with the given identifiers as processed.
+func (r *Relay) MarkProcessed(ctx context.Context, ids ...int64) error {
+	return r.markProcessed(ctx, r.drv, ids)
+}
+
+// Process passes up to limit pending change records to fn, and marks them as processed if fn
+// returns without an error. All operations are executed in one transaction, and the records are
+// locked using FOR UPDATE SKIP LOCKED (if supported by the dialect), which allows running multiple
+// relays concurrently. It returns the number of records that were processed.
+//
+//	for {
+//		n, err := client.Outbox().Process(ctx, 100, func(ctx context.Context, changes []*outbox.Change) error {
+//			return publish(ctx, changes)
+//		})
+//		if err != nil {
+//			return err
+//		}
+//		if n == 0 {
+//			time.Sleep(time.Second)
+//		}
+//	}
+func (r *Relay) Process(ctx context.Context, limit int, fn func(context.Context, []*Change) error) (int, error) {
+	tx, err := r.drv.Tx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	rollback := func(err error) (int, error) {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: %v", err, rerr)
+		}
+		return 0, err
+	}
+	changes, err := r.pending(ctx, tx, limit, r.drv.Dialect() != dialect.SQLite)
+	if err != nil {
+		return rollback(err)
+	}
+	if len(changes) == 0 {
+		return 0, tx.Commit()
+	}
+	if err := fn(ctx, changes); err != nil {
+		return rollback(err)
+	}
+	ids := make([]int64, len(changes))
+	for i, c := range changes {
+		ids[i] = c.ID
+	}
+	if err := r.markProcessed(ctx, tx, ids); err != nil {
+		return rollback(err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(changes), nil
+}
+
+// row is the scanned representation of a row in the outbox table.
+type row struct {
+	ID          int64      `sql:"id"`
+	Type        string     `sql:"type"`
+	Op          string     `sql:"op"`
+	EntityID    string     `sql:"entity_id"`
+	Payload     []byte     `sql:"payload"`
+	CreatedAt   time.Time  `sql:"created_at"`
+	ProcessedAt *time.Time `sql:"processed_at"`
+}
+
+func (r *Relay) pending(ctx context.Context, drv dialect.ExecQuerier, limit int, lock bool) ([]*Change, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("outbox: invalid limit: %d", limit)
+	}
+	selector := sql.Dialect(r.drv.Dialect()).
+		Select(Columns...).
+		From(sql.Table(Table)).
+		Where(sql.IsNull(ColumnProcessedAt)).
+		OrderBy(ColumnID).
+		Limit(limit)
+	if lock {
+		selector.ForUpdate(sql.WithLockAction(sql.SkipLocked))
+	}
+	query, args := selector.Query()
+	rows := &sql.Rows{}
+	if err := drv.Query(ctx, query, args, rows); err != nil {
+		return nil, fmt.Errorf("outbox: reading changes: %w", err)
+	}
+	defer rows.Close()
+	var scanned []*row
+	if err := sql.ScanSlice(rows, &scanned); err != nil {
+		return nil, fmt.Errorf("outbox: scanning changes: %w", err)
+	}
+	changes := make([]*Change, len(scanned))
+	for i, s := range scanned {
+		var p payload
+		if err := json.Unmarshal(s.Payload, &p); err != nil {
+			return nil, fmt.Errorf("outbox: decoding payload of change %d: %w", s.ID, err)
+		}
+		changes[i] = &Change{
+			ID:           s.ID,
+			Type:         s.Type,
+			Op:           s.Op,
+			EntityID:     json.RawMessage(s.EntityID),
+			Fields:       p.Fields,
+			AddedEdges:   p.AddedEdges,
+			RemovedEdges: p.RemovedEdges,
+			ClearedEdges: p.ClearedEdges,
+			CreatedAt:    s.CreatedAt,
+			ProcessedAt:  s.ProcessedAt,
+		}
+	}
+	return changes, nil
+}
+
+func (r *Relay) markProcessed(ctx context.Context, drv dialect.ExecQuerier, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	args := make([]any, len(ids))
+	for i := range ids {
+		args[i] = ids[i]
+	}
+	query, args := sql.Dialect(r.drv.Dialect()).
+		Update(Table).
+		Set(ColumnProcessedAt, time.Now()).
+		Where(sql.In(ColumnID, args...)).
+		Query()
+	if err := drv.Exec(ctx, query, args, nil); err != nil {
+		return fmt.Errorf("outbox: marking changes as processed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package outbox
+
+import (
+	"context"
+	"errors"
+	"net"
+	"regexp"
+	"testing"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+type mutation struct {
+	ent.Mutation
+	op      ent.Op
+	fields  map[string]ent.Value
+	old     map[string]ent.Value
+	cleared []string
+	added   map[string][]ent.Value
+}
+
+func (m *mutation) Type() string            { return "User" }
+func (m *mutation) Op() ent.Op              { return m.op }
+func (m *mutation) ClearedFields() []string { return m.cleared }
+func (m *mutation) RemovedEdges() []string  { return nil }
+func (m *mutation) ClearedEdges() []string  { return nil }
+
+func (m *mutation) Fields() []string {
+	var names []string
+	for name := range m.fields {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (m *mutation) Field(name string) (ent.Value, bool) {
+	v, ok := m.fields[name]
+	return v, ok
+}
+
+func (m *mutation) OldField(_ context.Context, name string) (ent.Value, error) {
+	return m.old[name], nil
+}
+
+func (m *mutation) AddedEdges() []string {
+	var names []string
+	for name := range m.added {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (m *mutation) AddedIDs(name string) []ent.Value {
+	return m.added[name]
+}
+
+func TestNewChanges(t *testing.T) {
+	m := &mutation{
+		op:      ent.OpUpdateOne,
+		fields:  map[string]ent.Value{"name": "a8m"},
+		old:     map[string]ent.Value{"name": "a", "age": 30},
+		cleared: []string{"age"},
+		added:   map[string][]ent.Value{"pets": {1, 2}},
+	}
+	old, err := OldValues(context.Background(), m)
+	require.NoError(t, err)
+	require.Equal(t, map[string]ent.Value{"name": "a", "age": 30}, old)
+	changes, err := NewChanges(m, old, 1)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	c := changes[0]
+	require.Equal(t, "User", c.Type)
+	require.Equal(t, "OpUpdateOne", c.Op)
+	require.JSONEq(t, "1", string(c.EntityID))
+	var oldName, newName string
+	require.NoError(t, c.Fields["name"].Decode(&oldName, &newName))
+	require.Equal(t, "a", oldName)
+	require.Equal(t, "a8m", newName)
+	require.JSONEq(t, "30", string(c.Fields["age"].Old))
+	require.Nil(t, c.Fields["age"].New)
+	require.JSONEq(t, "[1,2]", string(c.AddedEdges["pets"]))
+
+	m.op = ent.OpUpdate
+	old, err = OldValues(context.Background(), m)
+	require.NoError(t, err)
+	require.Nil(t, old)
+	changes, err = NewChanges(m, old, 1, 2)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	require.JSONEq(t, "2", string(changes[1].EntityID))
+	require.Nil(t, changes[1].Fields["name"].Old)
+
+	m.op = ent.OpUpdateOne
+	om := Omit(m, "name", "age")
+	old, err = OldValues(context.Background(), om)
+	require.NoError(t, err)
+	require.Empty(t, old)
+	changes, err = NewChanges(om, old, 1)
+	require.NoError(t, err)
+	require.Empty(t, changes[0].Fields)
+	require.JSONEq(t, "[1,2]", string(changes[0].AddedEdges["pets"]))
+	require.Equal(t, []string{"age"}, m.ClearedFields(), "wrapped mutation is not changed")
+
+	m.fields["name"] = net.IP("127.0.0.1")
+	changes, err = NewChanges(m, nil, 1)
+	require.NoError(t, err)
+	var ip []byte
+	require.NoError(t, changes[0].Fields["name"].Decode(nil, &ip))
+	require.Equal(t, "127.0.0.1", string(ip))
+
+	m.fields["name"] = func() {}
+	_, err = NewChanges(m, nil, 1)
+	require.Error(t, err)
+}
+
+func TestWrite(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `ent_outbox` (`type`, `op`, `entity_id`, `payload`, `created_at`) VALUES (?, ?, ?, ?, ?), (?, ?, ?, ?, ?)")).
+		WithArgs("User", "OpCreate", "1", []byte(`{"fields":{"name":{"new":"a8m"}}}`), sqlmock.AnyArg(), "User", "OpDelete", "2", []byte(`{}`), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(2, 2))
+	err = Write(context.Background(), sql.OpenDB(dialect.MySQL, db),
+		&Change{Type: "User", Op: "OpCreate", EntityID: []byte("1"), Fields: map[string]*FieldChange{"name": {New: []byte(`"a8m"`)}}},
+		&Change{Type: "User", Op: "OpDelete", EntityID: []byte("2")},
+	)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRelay_Process(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	now := time.Now()
+	rows := func() *sqlmock.Rows {
+		return sqlmock.NewRows(Columns).
+			AddRow(1, "User", "OpCreate", "1", []byte(`{"fields":{"name":{"new":"a8m"}}}`), now, nil).
+			AddRow(2, "User", "OpDeleteOne", "1", []byte(`{}`), now, nil)
+	}
+	query := regexp.QuoteMeta(`SELECT "id", "type", "op", "entity_id", "payload", "created_at", "processed_at" FROM "ent_outbox" WHERE "processed_at" IS NULL ORDER BY "id" LIMIT 10 FOR UPDATE SKIP LOCKED`)
+	// Failure.
+	mock.ExpectBegin()
+	mock.ExpectQuery(query).WillReturnRows(rows())
+	mock.ExpectRollback()
+	// Success.
+	mock.ExpectBegin()
+	mock.ExpectQuery(query).WillReturnRows(rows())
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "ent_outbox" SET "processed_at" = $1 WHERE "id" IN ($2, $3)`)).
+		WithArgs(sqlmock.AnyArg(), int64(1), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	r := NewRelay(sql.OpenDB(dialect.Postgres, db))
+	errFail := errors.New("fail")
+	_, err = r.Process(context.Background(), 10, func(context.Context, []*Change) error { return errFail })
+	require.ErrorIs(t, err, errFail)
+	n, err := r.Process(context.Background(), 10, func(_ context.Context, changes []*Change) error {
+		require.Len(t, changes, 2)
+		require.Equal(t, int64(1), changes[0].ID)
+		require.Equal(t, "OpCreate", changes[0].Op)
+		require.JSONEq(t, `"a8m"`, string(changes[0].Fields["name"].New))
+		require.Equal(t, "OpDeleteOne", changes[1].Op)
+		require.Nil(t, changes[1].ProcessedAt)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNewTable(t *testing.T) {
+	tb := NewTable()
+	require.Equal(t, Table, tb.Name)
+	require.Len(t, tb.Columns, len(Columns))
+	for i, c := range tb.Columns {
+		require.Equal(t, Columns[i], c.Name)
+	}
+	require.Equal(t, ColumnID, tb.PrimaryKey[0].Name)
+	require.True(t, tb.PrimaryKey[0].Increment)
+}
@@ -0,0 +1,183 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand"
+	"strings"
+	"sync/atomic"
+
+	"entgo.io/ent/dialect"
+)
+
+// ReplicaDriver is a dialect.Driver implementation that routes read-only
+// queries to a set of replica drivers, and writes, transactions and queries
+// that must observe the latest state to the primary driver.
+//
+//	drv := sql.NewReplicaDriver(primary, []*sql.Driver{replica1, replica2})
+//	client := ent.NewClient(ent.Driver(drv))
+//
+// Note that schema migration should be executed against the primary driver,
+// as inspecting the database may be routed to a lagging replica otherwise.
+type ReplicaDriver struct {
+	primary  *Driver
+	replicas []*Driver
+	policy   ReplicaPolicy
+}
+
+// ReplicaPolicy picks the replica that serves the next read query.
+type ReplicaPolicy interface {
+	Pick(ctx context.Context, replicas []*Driver) *Driver
+}
+
+// The ReplicaPolicyFunc type is an adapter to allow the use of ordinary
+// functions as replica policies.
+type ReplicaPolicyFunc func(context.Context, []*Driver) *Driver
+
+// Pick calls f(ctx, replicas).
+func (f ReplicaPolicyFunc) Pick(ctx context.Context, replicas []*Driver) *Driver {
+	return f(ctx, replicas)
+}
+
+// RoundRobin returns a ReplicaPolicy that iterates over the replicas in order.
+func RoundRobin() ReplicaPolicy {
+	var next atomic.Uint64
+	return ReplicaPolicyFunc(func(_ context.Context, replicas []*Driver) *Driver {
+		return replicas[(next.Add(1)-1)%uint64(len(replicas))]
+	})
+}
+
+// RandomReplica returns a ReplicaPolicy that picks a replica at random.
+func RandomReplica() ReplicaPolicy {
+	return ReplicaPolicyFunc(func(_ context.Context, replicas []*Driver) *Driver {
+		return replicas[rand.Intn(len(replicas))]
+	})
+}
+
+// ReplicaOption allows configuring the ReplicaDriver using functional options.
+type ReplicaOption func(*ReplicaDriver)
+
+// WithReplicaPolicy sets the policy used for balancing reads between replicas.
+// Defaults to RoundRobin.
+func WithReplicaPolicy(p ReplicaPolicy) ReplicaOption {
+	return func(d *ReplicaDriver) {
+		d.policy = p
+	}
+}
+
+// NewReplicaDriver returns a new ReplicaDriver that routes writes to the
+// primary driver and reads to the given replicas. If no replicas are given,
+// all operations are executed on the primary.
+func NewReplicaDriver(primary *Driver, replicas []*Driver, opts ...ReplicaOption) *ReplicaDriver {
+	d := &ReplicaDriver{primary: primary, replicas: replicas, policy: RoundRobin()}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// ctxPrimaryKey is the key used for marking a context as primary-only.
+type ctxPrimaryKey struct{}
+
+// WithPrimary returns a new context that forces all queries executed with
+// it to be routed to the primary database. For example, for reading your
+// own writes after executing a mutation.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxPrimaryKey{}, true)
+}
+
+// IsPrimary reports if the context was marked with WithPrimary.
+func IsPrimary(ctx context.Context) bool {
+	p, _ := ctx.Value(ctxPrimaryKey{}).(bool)
+	return p
+}
+
+// Primary returns the primary driver.
+func (d *ReplicaDriver) Primary() *Driver {
+	return d.primary
+}
+
+// Replicas returns the replica drivers.
+func (d *ReplicaDriver) Replicas() []*Driver {
+	return d.replicas
+}
+
+// Exec implements the dialect.Exec method. Statements are always executed on the primary.
+func (d *ReplicaDriver) Exec(ctx context.Context, query string, args, v any) error {
+	return d.primary.Exec(ctx, query, args, v)
+}
+
+// ExecContext executes a query that does not return records on the primary.
+func (d *ReplicaDriver) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return d.primary.ExecContext(ctx, query, args...)
+}
+
+// Query implements the dialect.Query method. Read-only statements are executed on
+// one of the replicas, unless the context is marked with WithPrimary.
+func (d *ReplicaDriver) Query(ctx context.Context, query string, args, v any) error {
+	return d.reader(ctx, query).Query(ctx, query, args, v)
+}
+
+// QueryContext executes a query that returns rows on the primary or on one of the replicas.
+func (d *ReplicaDriver) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return d.reader(ctx, query).QueryContext(ctx, query, args...)
+}
+
+// Tx starts and returns a transaction on the primary.
+func (d *ReplicaDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	return d.primary.Tx(ctx)
+}
+
+// BeginTx starts a transaction with options on the primary.
+func (d *ReplicaDriver) BeginTx(ctx context.Context, opts *TxOptions) (dialect.Tx, error) {
+	return d.primary.BeginTx(ctx, opts)
+}
+
+// Dialect implements the dialect.Dialect method.
+func (d *ReplicaDriver) Dialect() string {
+	return d.primary.Dialect()
+}
+
+// Close closes the underlying connections of the primary and the replicas.
+func (d *ReplicaDriver) Close() error {
+	err := d.primary.Close()
+	for _, r := range d.replicas {
+		err = errors.Join(err, r.Close())
+	}
+	return err
+}
+
+// reader returns the driver that should execute the given query.
+func (d *ReplicaDriver) reader(ctx context.Context, query string) *Driver {
+	if len(d.replicas) == 0 || IsPrimary(ctx) || !readOnly(query) {
+		return d.primary
+	}
+	if r := d.policy.Pick(ctx, d.replicas); r != nil {
+		return r
+	}
+	return d.primary
+}
+
+// readOnly reports if the given statement is safe to be executed on a replica.
+// Statements that may modify data, like INSERT ... RETURNING on Postgres, or
+// acquire row locks, are executed on the primary.
+func readOnly(query string) bool {
+	query = strings.TrimSpace(query)
+	if len(query) < 6 || !strings.EqualFold(query[:6], "SELECT") {
+		return false
+	}
+	upper := strings.ToUpper(query)
+	for _, s := range []string{" FOR UPDATE", " FOR SHARE", " FOR NO KEY UPDATE", " FOR KEY SHARE", " LOCK IN SHARE MODE"} {
+		if strings.Contains(upper, s) {
+			return false
+		}
+	}
+	return true
+}
+
+var _ dialect.Driver = (*ReplicaDriver)(nil)
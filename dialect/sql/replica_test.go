@@ -0,0 +1,93 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/dialect"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicaDriver(t *testing.T) {
+	var (
+		drvs  []*Driver
+		mocks []sqlmock.Sqlmock
+	)
+	for i := 0; i < 3; i++ {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		drvs = append(drvs, OpenDB(dialect.Postgres, db))
+		mocks = append(mocks, mock)
+	}
+	var (
+		ctx     = context.Background()
+		drv     = NewReplicaDriver(drvs[0], drvs[1:])
+		rows    = &Rows{}
+		primary = mocks[0]
+	)
+	require.Equal(t, dialect.Postgres, drv.Dialect())
+
+	// Reads are balanced between the replicas.
+	mocks[1].ExpectQuery("SELECT id FROM users").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mocks[2].ExpectQuery("SELECT id FROM users").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	for i := 0; i < 2; i++ {
+		require.NoError(t, drv.Query(ctx, "SELECT id FROM users", []any{}, rows))
+		require.NoError(t, rows.Close())
+	}
+
+	// Writes, locking reads and marked contexts are executed on the primary.
+	primary.ExpectExec("UPDATE users SET name").WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, drv.Exec(ctx, "UPDATE users SET name = $1", []any{"a8m"}, nil))
+	primary.ExpectQuery("INSERT INTO users").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	require.NoError(t, drv.Query(ctx, "INSERT INTO users DEFAULT VALUES RETURNING id", []any{}, rows))
+	require.NoError(t, rows.Close())
+	primary.ExpectQuery("SELECT id FROM users").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	require.NoError(t, drv.Query(ctx, "SELECT id FROM users FOR UPDATE", []any{}, rows))
+	require.NoError(t, rows.Close())
+	primary.ExpectQuery("SELECT id FROM users").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	require.NoError(t, drv.Query(WithPrimary(ctx), "SELECT id FROM users", []any{}, rows))
+	require.NoError(t, rows.Close())
+
+	// Transactions are started on the primary.
+	primary.ExpectBegin()
+	primary.ExpectQuery("SELECT id FROM users").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	primary.ExpectCommit()
+	tx, err := drv.Tx(ctx)
+	require.NoError(t, err)
+	require.NoError(t, tx.Query(ctx, "SELECT id FROM users", []any{}, rows))
+	require.NoError(t, rows.Close())
+	require.NoError(t, tx.Commit())
+
+	for _, m := range mocks {
+		m.ExpectClose()
+	}
+	require.NoError(t, drv.Close())
+	for _, m := range mocks {
+		require.NoError(t, m.ExpectationsWereMet())
+	}
+}
+
+func TestReplicaDriver_Policy(t *testing.T) {
+	db1, mock1, err := sqlmock.New()
+	require.NoError(t, err)
+	db2, mock2, err := sqlmock.New()
+	require.NoError(t, err)
+	last := OpenDB(dialect.MySQL, db2)
+	drv := NewReplicaDriver(OpenDB(dialect.MySQL, db1), []*Driver{OpenDB(dialect.MySQL, db1), last}, WithReplicaPolicy(
+		ReplicaPolicyFunc(func(_ context.Context, replicas []*Driver) *Driver {
+			return replicas[len(replicas)-1]
+		}),
+	))
+	mock2.ExpectQuery("SELECT 1").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	rows := &Rows{}
+	require.NoError(t, drv.Query(context.Background(), "SELECT 1", []any{}, rows))
+	require.NoError(t, rows.Close())
+	require.NoError(t, mock1.ExpectationsWereMet())
+	require.NoError(t, mock2.ExpectationsWereMet())
+}
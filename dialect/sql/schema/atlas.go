@@ -16,8 +16,10 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/postgres"
 	"ariga.io/atlas/sql/schema"
 	"ariga.io/atlas/sql/sqlclient"
 	"ariga.io/atlas/sql/sqltool"
@@ -46,6 +48,8 @@ type Atlas struct {
 	skip            ChangeKind          // what changes to skip and not apply
 	dir             migrate.Dir         // the migration directory to read from
 	fmt             migrate.Formatter   // how to format the plan into migration files
+	down            bool                // compute down migrations by diffing desired back to current
+	downHooks       []DiffHook          // diff hooks to run when diffing desired back to current
 
 	driver  dialect.Driver // driver passed in when not using an atlas URL
 	url     *url.URL       // url of database connection
@@ -183,6 +187,9 @@ func (a *Atlas) NamedDiff(ctx context.Context, name string, tables ...*Table) er
 		}
 		return nil
 	default:
+		if a.down {
+			markIrreversible(plan)
+		}
 		return migrate.NewPlanner(nil, a.dir, opts...).WritePlan(plan)
 	}
 }
@@ -490,6 +497,19 @@ func WithFormatter(fmt migrate.Formatter) MigrateOption {
 	}
 }
 
+// WithDownMigrations instructs NamedDiff to compute the down migration by diffing the desired state back to the
+// current state, and to write it to the down part of the migration files (e.g. the ".down.sql" files of golang-migrate,
+// or the "-- +goose Down" section of goose). By default, the down part holds only the reverse statements that Atlas
+// computes for the individual changes, and changes that cannot be reverted by a single statement are omitted.
+//
+// Changes that cannot be reverted without losing data, such as dropped columns, are flagged with an "irreversible"
+// comment in the down migration. This option has no effect on online migrations.
+func WithDownMigrations(b bool) MigrateOption {
+	return func(a *Atlas) {
+		a.down = b
+	}
+}
+
 // WithDialect configures the Ent dialect to use when migrating for an Atlas supported dialect flavor.
 // As an example, Ent can work with TiDB in MySQL dialect and Atlas can handle TiDB migrations.
 func WithDialect(d string) MigrateOption {
@@ -543,6 +563,7 @@ type atBuilder interface {
 	atIncrementC(*schema.Table, *schema.Column)
 	atIncrementT(*schema.Table, int64)
 	atIndex(*Index, *schema.Table, *schema.Index) error
+	atPartition(*Table, *schema.Table) error
 	atTypeRangeSQL(t ...string) string
 }
 
@@ -558,6 +579,17 @@ func (a *Atlas) init() error {
 	if a.dropColumns {
 		skip &= ^DropColumn
 	}
+	if a.down {
+		// Changes that are skipped by the up migration are skipped
+		// by the down migration in their reverse form.
+		a.downHooks = slices.Clone(a.diffHooks)
+		if skip != NoChange {
+			a.downHooks = append(a.downHooks, filterChanges(skip.reverse()))
+		}
+		if !a.withForeignKeys {
+			a.downHooks = append(a.downHooks, withoutForeignKeys)
+		}
+	}
 	if skip != NoChange {
 		a.diffHooks = append(a.diffHooks, filterChanges(skip))
 	}
@@ -661,6 +693,17 @@ var noQualifierOpt = func(opts *migrate.PlanOptions) {
 	opts.SchemaQualifier = &noQualifier
 }
 
+// inspectMode returns the mode for inspecting the current state. Ent supports table-level inspection only,
+// and the native enum types of PostgreSQL. Types are inspected even if the desired state does not use them,
+// in order to drop the ones that are no longer used by the ent tables.
+func (a *Atlas) inspectMode() schema.InspectMode {
+	mode := schema.InspectSchemas | schema.InspectTables
+	if a.dialect == dialect.Postgres {
+		mode |= schema.InspectTypes
+	}
+	return mode
+}
+
 // planInspect creates the current state by inspecting the connected database, computing the current state of the Ent schema
 // and proceeds to diff the changes to create a migration plan.
 func (a *Atlas) planInspect(ctx context.Context, conn dialect.ExecQuerier, name string, tables []*Table) (*migrate.Plan, error) {
@@ -671,8 +714,7 @@ func (a *Atlas) planInspect(ctx context.Context, conn dialect.ExecQuerier, name
 			}
 			return t
 		}(),
-		// Ent supports table-level inspection only.
-		Mode: schema.InspectSchemas | schema.InspectTables,
+		Mode: a.inspectMode(),
 	})
 	if err != nil {
 		return nil, err
@@ -697,7 +739,15 @@ func (a *Atlas) planInspect(ctx context.Context, conn dialect.ExecQuerier, name
 		desired = &schema.Schema{}
 	}
 	desired.Name, desired.Attrs = current.Name, current.Attrs
-	return a.diff(ctx, name, current, desired, a.types[len(types):], noQualifierOpt)
+	plan, err := a.diff(ctx, name, current, desired, a.types[len(types):], noQualifierOpt)
+	if err != nil {
+		return nil, err
+	}
+	routines, err := a.inspectRoutines(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	return a.planRoutines(plan, tables, routines)
 }
 
 func (a *Atlas) planReplay(ctx context.Context, name string, tables []*Table) (*migrate.Plan, error) {
@@ -722,6 +772,12 @@ func (a *Atlas) planReplay(ctx context.Context, name string, tables []*Table) (*
 	if err != nil {
 		return nil, a.cleanSchema(ctx, a.schema, err)
 	}
+	// Routines are inspected before the schema is cleaned, as
+	// dropping the tables drops their triggers and policies.
+	routines, err := a.inspectRoutines(ctx, a.sqlDialect)
+	if err != nil {
+		return nil, a.cleanSchema(ctx, a.schema, err)
+	}
 	var types []string
 	if a.universalID {
 		if types, err = a.loadTypes(ctx, a.sqlDialect); err != nil && !errors.Is(err, errTypeTableNotFound) {
@@ -754,10 +810,14 @@ func (a *Atlas) planReplay(ctx context.Context, name string, tables []*Table) (*
 			desired[i] = d
 		}
 	}
-	return a.diff(ctx, name, current,
+	plan, err := a.diff(ctx, name, current,
 		&schema.Schema{Name: current.Name, Attrs: current.Attrs, Tables: desired}, a.types[len(types):],
 		noQualifierOpt,
 	)
+	if err != nil {
+		return nil, err
+	}
+	return a.planRoutines(plan, tables, routines)
 }
 
 func (a *Atlas) diff(ctx context.Context, name string, current, desired *schema.Schema, newTypes []string, opts ...migrate.PlanOption) (*migrate.Plan, error) {
@@ -774,6 +834,10 @@ func (a *Atlas) diff(ctx context.Context, name string, current, desired *schema.
 		// match when compare them in code.
 		case *schema.AddTable, *schema.ModifyTable:
 			filtered = append(filtered, c)
+		case *schema.AddObject, *schema.ModifyObject, *schema.DropObject:
+			if enumChange(current, desired, c) {
+				filtered = append(filtered, c)
+			}
 		}
 	}
 	if a.indent != "" {
@@ -785,6 +849,11 @@ func (a *Atlas) diff(ctx context.Context, name string, current, desired *schema.
 	if err != nil {
 		return nil, err
 	}
+	if a.down && a.dir != nil {
+		if err := a.planDown(ctx, name, current, desired, plan, opts...); err != nil {
+			return nil, err
+		}
+	}
 	if len(newTypes) > 0 {
 		plan.Changes = append(plan.Changes, &migrate.Change{
 			Cmd:     a.sqlDialect.atTypeRangeSQL(newTypes...),
@@ -892,6 +961,12 @@ func (a *Atlas) realm(tables []*Table) (*schema.Realm, error) {
 		if err := a.aIndexes(et, at); err != nil {
 			return nil, err
 		}
+		if err := aEnums(et, at, s); err != nil {
+			return nil, err
+		}
+		if err := a.sqlDialect.atPartition(et, at); err != nil {
+			return nil, fmt.Errorf("partition of table %q: %w", et.Name, err)
+		}
 		s.AddTables(at)
 		byT[et] = at
 	}
@@ -968,6 +1043,9 @@ func (a *Atlas) aColumns(et *Table, at *schema.Table) error {
 		if err := a.atDefault(c1, c2); err != nil {
 			return err
 		}
+		if err := a.atGenerated(c1, c2); err != nil {
+			return err
+		}
 		if c1.Unique && (len(et.PrimaryKey) != 1 || et.PrimaryKey[0] != c1) {
 			a.sqlDialect.atUniqueC(et, c1, at, c2)
 		}
@@ -1000,6 +1078,91 @@ func (a *Atlas) aVColumns(et *Table, at *schema.View) error {
 	return nil
 }
 
+// aEnums adds the native enum types of the table columns (see Column.EnumType) to the
+// schema objects. Columns that use the same type are linked to the same object.
+func aEnums(et *Table, at *schema.Table, s *schema.Schema) error {
+	for _, c1 := range et.Columns {
+		if c1.EnumType == "" {
+			continue
+		}
+		c2, ok := at.Column(c1.Name)
+		if !ok {
+			continue
+		}
+		// Dialects without native enum types (or columns
+		// with an explicit SchemaType) are skipped.
+		e2, ok := c2.Type.Type.(*schema.EnumType)
+		if !ok || e2.T != c1.EnumType {
+			continue
+		}
+		o, ok := s.Object(func(o schema.Object) bool {
+			e1, ok := o.(*schema.EnumType)
+			return ok && e1.T == e2.T
+		})
+		if !ok {
+			e2.Schema = s
+			s.AddObjects(e2)
+			continue
+		}
+		e1 := o.(*schema.EnumType)
+		if !slices.Equal(e1.Values, e2.Values) {
+			return fmt.Errorf("enum type %q of column %q.%q is defined with different values: %q != %q", e2.T, et.Name, c1.Name, e2.Values, e1.Values)
+		}
+		c2.Type.Type = e1
+	}
+	return nil
+}
+
+// enumChange reports if the given change of a schema object should be planned. Ent manages only the
+// native enum types that are used by its tables (see Column.EnumType). Hence, types are created or
+// modified only if they are used by the desired state, and dropped only if they were used by the ent
+// tables in the current state, and not by other tables.
+func enumChange(current, desired *schema.Schema, c schema.Change) bool {
+	switch c := c.(type) {
+	case *schema.AddObject:
+		_, ok := c.O.(*schema.EnumType)
+		return ok
+	case *schema.ModifyObject:
+		_, ok := c.To.(*schema.EnumType)
+		return ok
+	case *schema.DropObject:
+		e, ok := c.O.(*schema.EnumType)
+		if !ok {
+			return false
+		}
+		var used bool
+		for _, t := range current.Tables {
+			_, managed := desired.Table(t.Name)
+			for _, c := range t.Columns {
+				if e1, ok := c.Type.Type.(*schema.EnumType); ok && e1.T == e.T {
+					if !managed {
+						return false
+					}
+					used = true
+				}
+			}
+		}
+		return used
+	}
+	return false
+}
+
+// atGenerated sets the generation expression of generated columns.
+func (a *Atlas) atGenerated(c1 *Column, c2 *schema.Column) error {
+	x := c1.Generated
+	if x == nil {
+		return nil
+	}
+	switch {
+	case c1.Default != nil:
+		return fmt.Errorf("generated column %q cannot have a default value", c1.Name)
+	case a.dialect == dialect.Postgres && x.Type != "" && !strings.EqualFold(x.Type, "STORED"):
+		return fmt.Errorf("generated column %q: PostgreSQL supports only STORED generated columns", c1.Name)
+	}
+	c2.SetGeneratedExpr(&schema.GeneratedExpr{Expr: x.Expr, Type: x.Type})
+	return nil
+}
+
 func (a *Atlas) atDefault(c1 *Column, c2 *schema.Column) error {
 	if c1.Default == nil || !a.sqlDialect.supportsDefault(c1) {
 		return nil
@@ -1027,6 +1190,25 @@ func (a *Atlas) atDefault(c1 *Column, c2 *schema.Column) error {
 				return fmt.Errorf("invalid default value for JSON column %q: %v", c1.Name, c1.Default)
 			}
 			c2.SetDefault(&schema.Literal{V: strings.ReplaceAll(s, "'", "''")})
+		case c1.Type == field.TypeDuration:
+			d, err := durationDefault(c1.Default)
+			if err != nil {
+				return fmt.Errorf("invalid default value for duration column %q: %w", c1.Name, err)
+			}
+			// The storage format is derived from the column type, as
+			// it might be defined explicitly using the SchemaType option.
+			format := entsql.DurationNanoseconds
+			switch c2.Type.Type.(type) {
+			case *postgres.IntervalType:
+				format = ""
+			case *schema.TimeType:
+				format = entsql.DurationTime
+			}
+			c2.SetDefault(&schema.Literal{V: fmt.Sprint(entsql.Duration(d, format).Encode(a.sqlDialect.Dialect()))})
+		case c1.Type == field.TypeDecimal:
+			// Decimal values are represented as strings, and
+			// are written to the database as numeric literals.
+			c2.SetDefault(&schema.Literal{V: fmt.Sprint(c1.Default)})
 		default:
 			// Keep backwards compatibility with the old default value format.
 			x := fmt.Sprint(c1.Default)
@@ -1040,6 +1222,25 @@ func (a *Atlas) atDefault(c1 *Column, c2 *schema.Column) error {
 	return nil
 }
 
+// durationDefault returns the duration of the given default value. Durations are
+// stored in the generated migration files as integers (nanoseconds).
+func durationDefault(v any) (time.Duration, error) {
+	switch v := v.(type) {
+	case time.Duration:
+		return v, nil
+	case int64:
+		return time.Duration(v), nil
+	case int:
+		return time.Duration(v), nil
+	case float64:
+		return time.Duration(v), nil
+	case string:
+		return entsql.ParseDuration(v)
+	default:
+		return 0, fmt.Errorf("unexpected type %T", v)
+	}
+}
+
 func (a *Atlas) aIndexes(et *Table, at *schema.Table) error {
 	// Primary-key index.
 	pk := make([]*schema.Column, 0, len(et.PrimaryKey))
@@ -1065,6 +1266,9 @@ func (a *Atlas) aIndexes(et *Table, at *schema.Table) error {
 		for _, p := range idx2.Parts {
 			p.Desc = desc[p.C.Name]
 		}
+		if err := atIndexExprs(idx1, idx2); err != nil {
+			return err
+		}
 		at.AddIndexes(idx2)
 	}
 	return nil
@@ -1183,6 +1387,31 @@ func descIndexes(idx *Index) map[string]bool {
 	return descs
 }
 
+// atIndexExprs replaces the index columns that were annotated with entsql.IndexExprColumn
+// with their expressions, and appends the expression parts defined by entsql.IndexExprs.
+func atIndexExprs(idx1 *Index, idx2 *schema.Index) error {
+	ant := idx1.Annotation
+	if ant == nil {
+		return nil
+	}
+	for column, x := range ant.ExprColumns {
+		i := slices.IndexFunc(idx2.Parts, func(p *schema.IndexPart) bool { return p.C != nil && p.C.Name == column })
+		if i == -1 {
+			return fmt.Errorf("unexpected index %q expression column: %q", idx1.Name, column)
+		}
+		c := idx2.Parts[i].C
+		c.Indexes = slices.DeleteFunc(c.Indexes, func(idx *schema.Index) bool { return idx == idx2 })
+		idx2.Parts[i].C, idx2.Parts[i].X = nil, &schema.RawExpr{X: x}
+	}
+	for _, x := range ant.Exprs {
+		idx2.AddParts(&schema.IndexPart{X: &schema.RawExpr{X: x}})
+	}
+	if len(idx2.Parts) == 0 {
+		return fmt.Errorf("missing columns or expressions for index %q", idx1.Name)
+	}
+	return nil
+}
+
 // driver decorates the atlas migrate.Driver and adds "diff hooking" and functionality.
 type diffDriver struct {
 	migrate.Driver
@@ -0,0 +1,123 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"fmt"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/schema"
+)
+
+// reverse returns the change kinds that mirror k in the down migration.
+// For example, columns that are not dropped by the up migration, are not
+// added back by the down migration.
+func (k ChangeKind) reverse() ChangeKind {
+	r := k & (ModifySchema | ModifyTable | ModifyColumn | ModifyIndex | ModifyForeignKey | ModifyCheck)
+	for _, p := range [][2]ChangeKind{
+		{AddSchema, DropSchema},
+		{AddTable, DropTable},
+		{AddColumn, DropColumn},
+		{AddIndex, DropIndex},
+		{AddForeignKey, DropForeignKey},
+		{AddCheck, DropCheck},
+	} {
+		if k.Is(p[0]) {
+			r |= p[1]
+		}
+		if k.Is(p[1]) {
+			r |= p[0]
+		}
+	}
+	return r
+}
+
+// planDown computes the down migration of the plan by diffing the desired state back to the current
+// state, and sets its statements as the reverse statements of the last table change of the plan. Hence,
+// formatters render them in the down part of the migration files, after the reverse statements of the
+// changes that follow the table changes (e.g. functions and triggers), and before the reverse statements
+// of the ones that precede them (e.g. enum types).
+func (a *Atlas) planDown(ctx context.Context, name string, current, desired *schema.Schema, plan *migrate.Plan, opts ...migrate.PlanOption) error {
+	changes, err := (&diffDriver{a.atDriver, a.downHooks}).SchemaDiff(desired, current, a.diffOptions...)
+	if err != nil {
+		return err
+	}
+	// The up migration only creates and modifies tables, and
+	// therefore, the down migration only drops and modifies them.
+	filtered := make([]schema.Change, 0, len(changes))
+	for _, c := range changes {
+		switch c.(type) {
+		case *schema.DropTable, *schema.ModifyTable:
+			filtered = append(filtered, c)
+		}
+	}
+	var stmts []string
+	if len(filtered) > 0 {
+		down, err := a.atDriver.PlanChanges(ctx, name, filtered, opts...)
+		if err != nil {
+			return fmt.Errorf("plan down migration: %w", err)
+		}
+		for _, c := range down.Changes {
+			cmd := c.Cmd
+			if c.Comment != "" {
+				cmd = fmt.Sprintf("-- %s\n%s", c.Comment, cmd)
+			}
+			stmts = append(stmts, cmd)
+		}
+	}
+	var (
+		last    *migrate.Change
+		flagged = make(map[*schema.ModifyTable]bool)
+	)
+	for _, c := range plan.Changes {
+		switch s := c.Source.(type) {
+		case *schema.AddTable, *schema.ModifyTable:
+			c.Reverse, last = nil, c
+			// A table modification might be planned as multiple changes.
+			m, ok := s.(*schema.ModifyTable)
+			if !ok || flagged[m] || len(stmts) == 0 {
+				continue
+			}
+			flagged[m] = true
+			for _, mc := range m.Changes {
+				if d, ok := mc.(*schema.DropColumn); ok {
+					stmts[0] = fmt.Sprintf("-- irreversible: column %q of table %q is added back without its data\n%s", d.C.Name, m.T.Name, stmts[0])
+				}
+			}
+		}
+	}
+	if last != nil && len(stmts) > 0 {
+		last.Reverse = stmts
+	}
+	return nil
+}
+
+// markIrreversible flags the schema changes of the plan that have no reverse statements,
+// as the down migration templates skip them. Table changes are flagged only if the plan
+// has no down statements for tables at all.
+func markIrreversible(plan *migrate.Plan) {
+	var tables bool
+	for _, c := range plan.Changes {
+		switch c.Source.(type) {
+		case *schema.AddTable, *schema.ModifyTable:
+			tables = tables || c.Reverse != nil
+		}
+	}
+	for _, c := range plan.Changes {
+		switch c.Source.(type) {
+		case nil:
+		case *schema.AddTable, *schema.ModifyTable:
+			if !tables {
+				c.Reverse = fmt.Sprintf("-- irreversible: %s", c.Comment)
+				tables = true
+			}
+		default:
+			if c.Reverse == nil {
+				c.Reverse = fmt.Sprintf("-- irreversible: %s", c.Comment)
+			}
+		}
+	}
+}
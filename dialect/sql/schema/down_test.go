@@ -0,0 +1,96 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/field"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqltool"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtlas_DownMigrations(t *testing.T) {
+	ctx := context.Background()
+	drv, err := sql.Open(dialect.SQLite, "file:down?mode=memory&_fk=1")
+	require.NoError(t, err)
+	defer drv.Close()
+	users := NewTable("users").
+		AddPrimary(&Column{Name: "id", Type: field.TypeInt, Increment: true}).
+		AddColumn(&Column{Name: "name", Type: field.TypeString}).
+		AddColumn(&Column{Name: "age", Type: field.TypeInt})
+	m, err := NewMigrate(drv)
+	require.NoError(t, err)
+	require.NoError(t, m.Create(ctx, users))
+
+	users = NewTable("users").
+		AddPrimary(&Column{Name: "id", Type: field.TypeInt, Increment: true}).
+		AddColumn(&Column{Name: "name", Type: field.TypeString})
+	pets := NewTable("pets").
+		AddPrimary(&Column{Name: "id", Type: field.TypeInt, Increment: true}).
+		AddColumn(&Column{Name: "name", Type: field.TypeString})
+	p := t.TempDir()
+	d, err := migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	m, err = NewMigrate(drv, WithDir(d), WithDropColumn(true), WithDownMigrations(true))
+	require.NoError(t, err)
+	require.NoError(t, m.NamedDiff(ctx, "changes", users, pets))
+	files, err := filepath.Glob(filepath.Join(p, "*_changes.down.sql"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	requireFileEqual(t, files[0], `-- reverse: create "pets" table
+-- irreversible: column "age" of table "users" is added back without its data
+-- disable the enforcement of foreign-keys constraints
+PRAGMA foreign_keys = off;
+-- add column "age" to table: "users"
+ALTER TABLE `+"`users` ADD COLUMN `age` integer NOT NULL;"+`
+-- drop "pets" table
+DROP TABLE `+"`pets`;"+`
+-- enable back the enforcement of foreign-keys constraints
+PRAGMA foreign_keys = on;
+`)
+
+	// Columns that are not dropped by the up migration are not added back.
+	p = t.TempDir()
+	gd, err := sqltool.NewGooseDir(p)
+	require.NoError(t, err)
+	m, err = NewMigrate(drv, WithDir(gd), WithDownMigrations(true))
+	require.NoError(t, err)
+	require.NoError(t, m.NamedDiff(ctx, "changes", users, pets))
+	files, err = filepath.Glob(filepath.Join(p, "*_changes.sql"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	requireFileEqual(t, files[0], "-- +goose Up\n-- create \"pets\" table\nCREATE TABLE `pets` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `name` text NOT NULL);\n\n-- +goose Down\n-- reverse: create \"pets\" table\n-- disable the enforcement of foreign-keys constraints\nPRAGMA foreign_keys = off;\n-- drop \"pets\" table\nDROP TABLE `pets`;\n-- enable back the enforcement of foreign-keys constraints\nPRAGMA foreign_keys = on;\n")
+}
+
+func TestChangeKind_Reverse(t *testing.T) {
+	require.Equal(t, AddColumn|AddIndex, (DropColumn | DropIndex).reverse())
+	require.Equal(t, DropTable|ModifyTable, (AddTable | ModifyTable).reverse())
+	require.Equal(t, NoChange, NoChange.reverse())
+}
+
+func TestMarkIrreversible(t *testing.T) {
+	users := &schema.Table{Name: "users"}
+	plan := &migrate.Plan{
+		Changes: []*migrate.Change{
+			{Cmd: "PRAGMA foreign_keys = off"},
+			{Cmd: "CREATE TYPE", Comment: "create enum type \"status\"", Source: &schema.AddObject{}},
+			{Cmd: "ALTER TABLE", Comment: "modify \"users\" table", Source: &schema.ModifyTable{T: users}},
+			{Cmd: "CREATE INDEX", Comment: "create index", Source: &schema.ModifyTable{T: users}},
+		},
+	}
+	markIrreversible(plan)
+	require.Nil(t, plan.Changes[0].Reverse)
+	require.Equal(t, `-- irreversible: create enum type "status"`, plan.Changes[1].Reverse)
+	require.Equal(t, `-- irreversible: modify "users" table`, plan.Changes[2].Reverse)
+	require.Nil(t, plan.Changes[3].Reverse)
+}
@@ -0,0 +1,285 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	entsql "entgo.io/ent/dialect/sql"
+
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlclient"
+)
+
+// DriftKind describes the kind of difference between the database and the Ent schema.
+type DriftKind uint
+
+// List of drift kinds.
+const (
+	DriftMissingTable       DriftKind = iota + 1 // The table is defined by Ent, but is missing in the database.
+	DriftExtraTable                              // The table exists in the database, but is not defined by Ent.
+	DriftMissingColumn                           // The column is defined by Ent, but is missing in the database.
+	DriftExtraColumn                             // The column exists in the database, but is not defined by Ent.
+	DriftColumnType                              // The type of the column does not match its Ent definition.
+	DriftColumnNullable                          // The nullability of the column does not match its Ent definition.
+	DriftMissingIndex                            // The index is defined by Ent, but is missing in the database.
+	DriftExtraIndex                              // The index exists in the database, but is not defined by Ent.
+	DriftIndexMismatch                           // The index does not match its Ent definition.
+	DriftMissingForeignKey                       // The foreign-key is defined by Ent, but is missing in the database.
+	DriftExtraForeignKey                         // The foreign-key exists in the database, but is not defined by Ent.
+	DriftForeignKeyMismatch                      // The foreign-key does not match its Ent definition.
+)
+
+// String implements the fmt.Stringer interface.
+func (k DriftKind) String() string {
+	switch k {
+	case DriftMissingTable:
+		return "missing table"
+	case DriftExtraTable:
+		return "extra table"
+	case DriftMissingColumn:
+		return "missing column"
+	case DriftExtraColumn:
+		return "extra column"
+	case DriftColumnType:
+		return "column type mismatch"
+	case DriftColumnNullable:
+		return "column nullability mismatch"
+	case DriftMissingIndex:
+		return "missing index"
+	case DriftExtraIndex:
+		return "extra index"
+	case DriftIndexMismatch:
+		return "index mismatch"
+	case DriftMissingForeignKey:
+		return "missing foreign-key"
+	case DriftExtraForeignKey:
+		return "extra foreign-key"
+	case DriftForeignKeyMismatch:
+		return "foreign-key mismatch"
+	default:
+		return fmt.Sprintf("DriftKind(%d)", k)
+	}
+}
+
+// Drift describes a difference between the database and the Ent schema.
+type Drift struct {
+	Kind       DriftKind // Kind of the drift.
+	Table      string    // Table name in the database.
+	TypeName   string    // Ent type name of the table, if set by the sql/drift feature.
+	Column     string    // Column name, for column drifts.
+	FieldName  string    // Ent field name of the column, if set by the sql/drift feature.
+	Index      string    // Index name, for index drifts.
+	ForeignKey string    // Foreign-key symbol, for foreign-key drifts.
+	Current    string    // Current definition in the database, for type and nullability mismatches.
+	Desired    string    // Desired definition by the Ent schema, for type and nullability mismatches.
+}
+
+// String implements the fmt.Stringer interface.
+func (d *Drift) String() string {
+	var b strings.Builder
+	b.WriteString(d.Kind.String())
+	switch {
+	case d.Column != "":
+		fmt.Fprintf(&b, " %q of table %q", d.Column, d.Table)
+	case d.Index != "":
+		fmt.Fprintf(&b, " %q of table %q", d.Index, d.Table)
+	case d.ForeignKey != "":
+		fmt.Fprintf(&b, " %q of table %q", d.ForeignKey, d.Table)
+	default:
+		fmt.Fprintf(&b, " %q", d.Table)
+	}
+	switch {
+	case d.TypeName != "" && d.FieldName != "":
+		fmt.Fprintf(&b, " (%s.%s)", d.TypeName, d.FieldName)
+	case d.TypeName != "":
+		fmt.Fprintf(&b, " (%s)", d.TypeName)
+	}
+	if d.Current != "" || d.Desired != "" {
+		fmt.Fprintf(&b, ": %s != %s", d.Current, d.Desired)
+	}
+	return b.String()
+}
+
+// DriftReport describes the differences between the database and the Ent schema.
+type DriftReport struct {
+	Drifts []*Drift
+}
+
+// Empty reports if the database is in sync with the Ent schema.
+func (r *DriftReport) Empty() bool {
+	return len(r.Drifts) == 0
+}
+
+// Err returns an error describing the drifts of the report, or nil if it is empty.
+func (r *DriftReport) Err() error {
+	if r.Empty() {
+		return nil
+	}
+	errs := make([]error, len(r.Drifts))
+	for i, d := range r.Drifts {
+		errs[i] = errors.New(d.String())
+	}
+	return fmt.Errorf("sql/schema: database drifted from the ent schema: %w", errors.Join(errs...))
+}
+
+// Drift compares the state read from the connected database with the state defined by Ent, and reports
+// their differences. Unlike Create and Diff, the DiffHook and WithSkipChanges options are not applied,
+// and no migration is planned, executed or written. Hence, it can be used for checking at startup or in
+// health checks that the database was migrated, and that it was not changed manually.
+//
+//	m, err := schema.NewMigrate(drv)
+//	if err != nil {
+//		log.Fatalln(err)
+//	}
+//	report, err := m.Drift(ctx, migrate.Tables...)
+//	if err != nil {
+//		log.Fatalln(err)
+//	}
+//	for _, d := range report.Drifts {
+//		log.Println(d.Kind, d.TypeName, d.FieldName)
+//	}
+func (a *Atlas) Drift(ctx context.Context, tables ...*Table) (*DriftReport, error) {
+	var err error
+	a.setupTables(tables)
+	if a.universalID {
+		tables = append(tables, NewTypesTable())
+	}
+	if a.driver != nil {
+		a.sqlDialect, err = a.entDialect(ctx, a.driver)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		c, err := sqlclient.OpenURL(ctx, a.url)
+		if err != nil {
+			return nil, err
+		}
+		defer c.Close()
+		a.sqlDialect, err = a.entDialect(ctx, entsql.OpenDB(a.dialect, c.DB))
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer func() { a.sqlDialect = nil }()
+	if err := a.sqlDialect.init(ctx); err != nil {
+		return nil, err
+	}
+	a.atDriver, err = a.sqlDialect.atOpen(a.sqlDialect)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { a.atDriver = nil }()
+	current, err := a.atDriver.InspectSchema(ctx, a.schema, &schema.InspectOptions{Mode: a.inspectMode()})
+	if err != nil {
+		return nil, err
+	}
+	if a.universalID {
+		types, err := a.loadTypes(ctx, a.sqlDialect)
+		if err != nil && !errors.Is(err, errTypeTableNotFound) {
+			return nil, err
+		}
+		a.types = types
+	}
+	realm, err := a.StateReader(tables...).ReadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	desired := &schema.Schema{}
+	if len(realm.Schemas) > 0 {
+		desired = realm.Schemas[0]
+	}
+	desired.Name, desired.Attrs = current.Name, current.Attrs
+	changes, err := a.atDriver.SchemaDiff(current, desired, a.diffOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return a.driftReport(tables, changes), nil
+}
+
+// driftReport converts the changes of the current state to the desired state to a drift report.
+func (a *Atlas) driftReport(tables []*Table, changes []schema.Change) *DriftReport {
+	var (
+		report = &DriftReport{}
+		byName = make(map[string]*Table, len(tables))
+	)
+	for _, t := range tables {
+		byName[t.Name] = t
+	}
+	drift := func(kind DriftKind, table string) *Drift {
+		d := &Drift{Kind: kind, Table: table}
+		if t, ok := byName[table]; ok {
+			d.TypeName = t.TypeName
+		}
+		report.Drifts = append(report.Drifts, d)
+		return d
+	}
+	column := func(kind DriftKind, table, name string) *Drift {
+		d := drift(kind, table)
+		d.Column = name
+		if t, ok := byName[table]; ok {
+			if c, ok := t.Column(name); ok {
+				d.FieldName = c.FieldName
+			}
+		}
+		return d
+	}
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.AddTable:
+			drift(DriftMissingTable, c.T.Name)
+		case *schema.DropTable:
+			drift(DriftExtraTable, c.T.Name)
+		case *schema.ModifyTable:
+			for _, tc := range c.Changes {
+				switch tc := tc.(type) {
+				case *schema.AddColumn:
+					column(DriftMissingColumn, c.T.Name, tc.C.Name)
+				case *schema.DropColumn:
+					column(DriftExtraColumn, c.T.Name, tc.C.Name)
+				case *schema.ModifyColumn:
+					if tc.Change.Is(schema.ChangeType) {
+						d := column(DriftColumnType, c.T.Name, tc.To.Name)
+						d.Current, d.Desired = typeName(tc.From.Type.Type), typeName(tc.To.Type.Type)
+					}
+					if tc.Change.Is(schema.ChangeNull) {
+						d := column(DriftColumnNullable, c.T.Name, tc.To.Name)
+						d.Current, d.Desired = nullability(tc.From), nullability(tc.To)
+					}
+				case *schema.AddIndex:
+					drift(DriftMissingIndex, c.T.Name).Index = tc.I.Name
+				case *schema.DropIndex:
+					drift(DriftExtraIndex, c.T.Name).Index = tc.I.Name
+				case *schema.ModifyIndex:
+					drift(DriftIndexMismatch, c.T.Name).Index = tc.To.Name
+				case *schema.AddForeignKey:
+					if a.withForeignKeys {
+						drift(DriftMissingForeignKey, c.T.Name).ForeignKey = tc.F.Symbol
+					}
+				case *schema.DropForeignKey:
+					if a.withForeignKeys {
+						drift(DriftExtraForeignKey, c.T.Name).ForeignKey = tc.F.Symbol
+					}
+				case *schema.ModifyForeignKey:
+					if a.withForeignKeys {
+						drift(DriftForeignKeyMismatch, c.T.Name).ForeignKey = tc.To.Symbol
+					}
+				}
+			}
+		}
+	}
+	return report
+}
+
+// nullability returns the nullability of the column in SQL.
+func nullability(c *schema.Column) string {
+	if c.Type.Null {
+		return "NULL"
+	}
+	return "NOT NULL"
+}
@@ -0,0 +1,89 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/field"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtlas_Drift(t *testing.T) {
+	ctx := context.Background()
+	drv, err := sql.Open(dialect.SQLite, "file:drift?mode=memory&_fk=1")
+	require.NoError(t, err)
+	defer drv.Close()
+	usersT := func() *Table {
+		t := &Table{
+			Name:     "users",
+			TypeName: "User",
+			Columns: []*Column{
+				{Name: "id", Type: field.TypeInt, Increment: true, FieldName: "id"},
+				{Name: "name", Type: field.TypeString, FieldName: "name"},
+				{Name: "age", Type: field.TypeInt, Nullable: true, FieldName: "age"},
+				{Name: "nickname", Type: field.TypeString, FieldName: "nickname"},
+			},
+		}
+		t.PrimaryKey = t.Columns[:1]
+		t.Indexes = []*Index{{Name: "user_name", Columns: t.Columns[1:2]}}
+		return t
+	}
+	users := usersT()
+	groups := &Table{
+		Name:     "groups",
+		TypeName: "Group",
+		Columns:  []*Column{{Name: "id", Type: field.TypeInt, Increment: true, FieldName: "id"}},
+	}
+	groups.PrimaryKey = groups.Columns
+	m, err := NewMigrate(drv)
+	require.NoError(t, err)
+	require.NoError(t, m.Create(ctx, users, groups))
+
+	m, err = NewMigrate(drv)
+	require.NoError(t, err)
+	report, err := m.Drift(ctx, usersT(), groups)
+	require.NoError(t, err)
+	require.Empty(t, report.Drifts)
+	require.True(t, report.Empty())
+	require.NoError(t, report.Err())
+
+	// Change the Ent schema.
+	users = usersT()
+	users.Columns[2].Type, users.Columns[2].Nullable = field.TypeString, false
+	users.Columns[3] = &Column{Name: "email", Type: field.TypeString, FieldName: "email"}
+	users.Indexes = []*Index{{Name: "user_email", Columns: users.Columns[3:4]}}
+	pets := &Table{
+		Name:     "pets",
+		TypeName: "Pet",
+		Columns:  []*Column{{Name: "id", Type: field.TypeInt, Increment: true, FieldName: "id"}},
+	}
+	pets.PrimaryKey = pets.Columns
+	report, err = m.Drift(ctx, users, pets)
+	require.NoError(t, err)
+	require.Equal(t, []*Drift{
+		{Kind: DriftColumnType, Table: "users", TypeName: "User", Column: "age", FieldName: "age", Current: "integer", Desired: "text"},
+		{Kind: DriftColumnNullable, Table: "users", TypeName: "User", Column: "age", FieldName: "age", Current: "NULL", Desired: "NOT NULL"},
+		{Kind: DriftExtraColumn, Table: "users", TypeName: "User", Column: "nickname"},
+		{Kind: DriftMissingColumn, Table: "users", TypeName: "User", Column: "email", FieldName: "email"},
+		{Kind: DriftExtraIndex, Table: "users", TypeName: "User", Index: "user_name"},
+		{Kind: DriftMissingIndex, Table: "users", TypeName: "User", Index: "user_email"},
+		{Kind: DriftExtraTable, Table: "groups"},
+		{Kind: DriftMissingTable, Table: "pets", TypeName: "Pet"},
+	}, report.Drifts)
+	require.False(t, report.Empty())
+	require.EqualError(t, report.Err(), `sql/schema: database drifted from the ent schema: column type mismatch "age" of table "users" (User.age): integer != text
+column nullability mismatch "age" of table "users" (User.age): NULL != NOT NULL
+extra column "nickname" of table "users" (User)
+missing column "email" of table "users" (User.email)
+extra index "user_name" of table "users" (User)
+missing index "user_email" of table "users" (User)
+extra table "groups"
+missing table "pets" (Pet)`)
+}
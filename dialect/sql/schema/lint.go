@@ -0,0 +1,424 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	stdsql "database/sql"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"entgo.io/ent/dialect"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlclient"
+)
+
+// Severity describes the severity of a lint finding.
+type Severity uint
+
+// List of finding severities.
+const (
+	SeverityWarning Severity = iota + 1 // The change might fail or lock the table on some databases.
+	SeverityError                       // The change deletes data or fails on non-empty tables.
+)
+
+// String implements the fmt.Stringer interface.
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("Severity(%d)", s)
+	}
+}
+
+// ParseSeverity returns the severity of the given name.
+func ParseSeverity(name string) (Severity, error) {
+	switch strings.ToLower(name) {
+	case "warning":
+		return SeverityWarning, nil
+	case "error":
+		return SeverityError, nil
+	default:
+		return 0, fmt.Errorf("sql/schema: unknown lint severity %q", name)
+	}
+}
+
+// List of codes reported by the migration linter.
+const (
+	LintDropTable     = "drop-table"     // A table is dropped.
+	LintDropColumn    = "drop-column"    // A column is dropped.
+	LintNarrowType    = "narrow-type"    // The type of column is narrowed or changed.
+	LintNotNull       = "not-null"       // A non-nullable column is added or set without a default value.
+	LintBlockingIndex = "blocking-index" // An index is created on an existing table without the CONCURRENTLY option.
+)
+
+// Finding describes a schema change that was reported by the migration linter.
+type Finding struct {
+	Severity Severity      // Severity of the finding.
+	Code     string        // Code of the finding, e.g. "drop-column".
+	Table    string        // Table affected by the change.
+	Message  string        // Human-readable description of the finding.
+	Change   schema.Change // Change that was reported.
+}
+
+// String implements the fmt.Stringer interface.
+func (f *Finding) String() string {
+	return fmt.Sprintf("%s [%s]: %s", f.Severity, f.Code, f.Message)
+}
+
+// LintChanges analyzes the given schema changes and reports the ones that might cause data loss or
+// break the migration on non-empty tables. Note that the analysis is offline, and therefore, indexes
+// that are created on existing Postgres tables without the CONCURRENTLY option are reported regardless
+// of the table size.
+//
+//	schema.WithDiffHook(func(next schema.Differ) schema.Differ {
+//		return schema.DiffFunc(func(current, desired *atlas.Schema) ([]atlas.Change, error) {
+//			changes, err := next.Diff(current, desired)
+//			if err != nil {
+//				return nil, err
+//			}
+//			for _, f := range schema.LintChanges(dialect.Postgres, changes) {
+//				log.Println(f)
+//			}
+//			return changes, nil
+//		})
+//	})
+func LintChanges(dialectName string, changes []schema.Change) []*Finding {
+	var fs []*Finding
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.DropTable:
+			fs = append(fs, &Finding{
+				Severity: SeverityError,
+				Code:     LintDropTable,
+				Table:    c.T.Name,
+				Message:  fmt.Sprintf("dropping table %q deletes its data", c.T.Name),
+				Change:   c,
+			})
+		case *schema.ModifyTable:
+			fs = append(fs, lintTable(dialectName, c)...)
+		}
+	}
+	return fs
+}
+
+// LintPlan analyzes the schema changes of the given plan, as computed by the diff and
+// set as the source of the planned changes. See LintChanges for more info.
+func LintPlan(dialectName string, plan *migrate.Plan) []*Finding {
+	var (
+		changes []schema.Change
+		seen    = make(map[schema.Change]bool)
+	)
+	for _, c := range plan.Changes {
+		// A schema change might be planned as multiple changes.
+		if s, ok := c.Source.(schema.Change); ok && !seen[s] {
+			seen[s] = true
+			changes = append(changes, s)
+		}
+	}
+	return LintChanges(dialectName, changes)
+}
+
+// lintTable analyzes the changes of an existing table.
+func lintTable(dialectName string, m *schema.ModifyTable) []*Finding {
+	var fs []*Finding
+	add := func(s Severity, code string, c schema.Change, format string, args ...any) {
+		fs = append(fs, &Finding{Severity: s, Code: code, Table: m.T.Name, Message: fmt.Sprintf(format, args...), Change: c})
+	}
+	for _, c := range m.Changes {
+		switch c := c.(type) {
+		case *schema.DropColumn:
+			add(SeverityError, LintDropColumn, c, "dropping column %q of table %q deletes its data", c.C.Name, m.T.Name)
+		case *schema.AddColumn:
+			if !c.C.Type.Null && c.C.Default == nil && !hasAttr[*schema.GeneratedExpr](c.C.Attrs) {
+				add(SeverityError, LintNotNull, c, "adding non-nullable column %q to table %q without a default value fails if the table is not empty", c.C.Name, m.T.Name)
+			}
+		case *schema.ModifyColumn:
+			if c.Change.Is(schema.ChangeNull) && c.From.Type.Null && !c.To.Type.Null && c.To.Default == nil {
+				add(SeverityWarning, LintNotNull, c, "setting column %q of table %q to non-nullable without a default value fails if it contains NULL values", c.To.Name, m.T.Name)
+			}
+			if c.Change.Is(schema.ChangeType) {
+				if from, to := c.From.Type.Type, c.To.Type.Type; narrows(from, to) {
+					add(SeverityWarning, LintNarrowType, c, "changing the type of column %q of table %q from %s to %s might fail or truncate its data", c.To.Name, m.T.Name, typeName(from), typeName(to))
+				}
+			}
+		case *schema.AddIndex:
+			if dialectName == dialect.Postgres && !hasAttr[*postgres.Concurrently](c.Extra) {
+				add(SeverityWarning, LintBlockingIndex, c, "creating index %q on table %q without the CONCURRENTLY option blocks writes to the table until the index is built", c.I.Name, m.T.Name)
+			}
+		}
+	}
+	return fs
+}
+
+// narrows reports if converting a column from one type to the other might fail or lose data.
+func narrows(from, to schema.Type) bool {
+	if reflect.TypeOf(from) != reflect.TypeOf(to) {
+		return true
+	}
+	switch from := from.(type) {
+	case *schema.StringType:
+		to := to.(*schema.StringType)
+		return to.Size > 0 && (from.Size == 0 || to.Size < from.Size)
+	case *schema.BinaryType:
+		to := to.(*schema.BinaryType)
+		return to.Size != nil && (from.Size == nil || *to.Size < *from.Size)
+	case *schema.IntegerType:
+		to := to.(*schema.IntegerType)
+		return from.Unsigned != to.Unsigned || intSize(to.T) < intSize(from.T)
+	case *schema.DecimalType:
+		to := to.(*schema.DecimalType)
+		return from.Unsigned != to.Unsigned || to.Precision < from.Precision || to.Scale < from.Scale
+	case *schema.FloatType:
+		to := to.(*schema.FloatType)
+		return floatSize(to) < floatSize(from)
+	case *schema.EnumType:
+		to := to.(*schema.EnumType)
+		for _, v := range from.Values {
+			if !slices.Contains(to.Values, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasAttr reports if the given attributes contain an attribute of type T.
+func hasAttr[T any, A any](attrs []A) bool {
+	for _, a := range attrs {
+		if _, ok := any(a).(T); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// intSize returns the storage size of the given integer type, or zero if it is unknown.
+func intSize(t string) int {
+	switch strings.ToLower(t) {
+	case "tinyint":
+		return 1
+	case "smallint", "int2", "smallserial", "serial2":
+		return 2
+	case "mediumint":
+		return 3
+	case "int", "integer", "int4", "serial", "serial4":
+		return 4
+	case "bigint", "int8", "bigserial", "serial8":
+		return 8
+	default:
+		return 0
+	}
+}
+
+// floatSize returns the precision of the given floating-point type in bits.
+func floatSize(t *schema.FloatType) int {
+	switch strings.ToLower(t.T) {
+	case "real", "float4":
+		return 24
+	case "double", "double precision", "float8":
+		return 53
+	default:
+		return t.Precision
+	}
+}
+
+// typeName returns the name of the column type as reported by the database.
+func typeName(t schema.Type) string {
+	switch t := t.(type) {
+	case *schema.StringType:
+		if t.Size > 0 {
+			return fmt.Sprintf("%s(%d)", t.T, t.Size)
+		}
+		return t.T
+	case *schema.BinaryType:
+		if t.Size != nil {
+			return fmt.Sprintf("%s(%d)", t.T, *t.Size)
+		}
+		return t.T
+	case *schema.DecimalType:
+		return fmt.Sprintf("%s(%d,%d)", t.T, t.Precision, t.Scale)
+	case *schema.IntegerType:
+		return t.T
+	case *schema.FloatType:
+		return t.T
+	case *schema.BoolType:
+		return t.T
+	case *schema.TimeType:
+		return t.T
+	case *schema.JSONType:
+		return t.T
+	case *schema.EnumType:
+		return t.T
+	case *schema.UUIDType:
+		return t.T
+	case *schema.SpatialType:
+		return t.T
+	case *schema.UnsupportedType:
+		return t.T
+	default:
+		return fmt.Sprintf("%T", t)
+	}
+}
+
+// FileFindings holds the lint findings of a migration file.
+type FileFindings struct {
+	File     migrate.File
+	Findings []*Finding
+}
+
+// devDrivers maps the schemes of the dev URLs to the names of the database/sql
+// drivers they are opened with, and to the packages that register them.
+var devDrivers = map[string]struct{ name, pkg string }{
+	"sqlite":       {"sqlite3", "github.com/mattn/go-sqlite3"},
+	"sqlite3":      {"sqlite3", "github.com/mattn/go-sqlite3"},
+	"postgres":     {"postgres", "github.com/lib/pq"},
+	"postgresql":   {"postgres", "github.com/lib/pq"},
+	"mysql":        {"mysql", "github.com/go-sql-driver/mysql"},
+	"mysql+unix":   {"mysql", "github.com/go-sql-driver/mysql"},
+	"maria":        {"mysql", "github.com/go-sql-driver/mysql"},
+	"maria+unix":   {"mysql", "github.com/go-sql-driver/mysql"},
+	"mariadb":      {"mysql", "github.com/go-sql-driver/mysql"},
+	"mariadb+unix": {"mysql", "github.com/go-sql-driver/mysql"},
+}
+
+// checkDevDriver ensures the database driver of the given dev URL is registered, as
+// it should be imported by the program that runs the linter, and not by this package.
+func checkDevDriver(devURL string) error {
+	u, err := url.Parse(devURL)
+	if err != nil {
+		return fmt.Errorf("sql/schema: parse dev URL: %w", err)
+	}
+	d, ok := devDrivers[u.Scheme]
+	if !ok || slices.Contains(stdsql.Drivers(), d.name) {
+		return nil
+	}
+	return fmt.Errorf("sql/schema: database driver %q of the dev database is not registered. Import it in the program that runs the linter (e.g. import _ %q)", d.name, d.pkg)
+}
+
+// concurrentIndexRe matches the name of indexes created concurrently by migration files.
+var concurrentIndexRe = regexp.MustCompile(`(?is)^\s*CREATE\s+(?:UNIQUE\s+)?INDEX\s+CONCURRENTLY\s+(?:IF\s+NOT\s+EXISTS\s+)?"?([^\s"(]+)"?`)
+
+// LintDir replays the migration files of the given directory on the dev database of the given URL,
+// and lints the schema changes of each of the last n files, or all files if n is zero. The dev database
+// is expected to be empty, as it is used only for computing the changes of the files, and no connection
+// to other services is made. Note that the database driver of the dev URL should be registered by the caller.
+//
+//	dir, err := sqltool.NewGolangMigrateDir("ent/migrate/migrations")
+//	if err != nil {
+//		log.Fatalln(err)
+//	}
+//	files, err := schema.LintDir(ctx, "sqlite://dev?mode=memory&_fk=1", dir, 0)
+func LintDir(ctx context.Context, devURL string, dir migrate.Dir, n int) ([]*FileFindings, error) {
+	if err := checkDevDriver(devURL); err != nil {
+		return nil, err
+	}
+	if err := migrate.Validate(dir); err != nil {
+		return nil, fmt.Errorf("sql/schema: validate migration directory: %w", err)
+	}
+	files, err := dir.Files()
+	if err != nil {
+		return nil, fmt.Errorf("sql/schema: read migration files: %w", err)
+	}
+	if n <= 0 || n > len(files) {
+		n = len(files)
+	}
+	dev, err := sqlclient.Open(ctx, devURL)
+	if err != nil {
+		return nil, fmt.Errorf("sql/schema: open dev database: %w", err)
+	}
+	defer dev.Close()
+	var (
+		current *schema.Realm
+		lints   []*FileFindings
+	)
+	for i, f := range files {
+		stmts, err := migrate.FileStmtDecls(dev, f)
+		if err != nil {
+			return nil, fmt.Errorf("sql/schema: scan migration file %q: %w", f.Name(), err)
+		}
+		if i == len(files)-n {
+			if current, err = dev.InspectRealm(ctx, nil); err != nil {
+				return nil, err
+			}
+		}
+		for _, s := range stmts {
+			if _, err := dev.ExecContext(ctx, s.Text); err != nil {
+				return nil, fmt.Errorf("sql/schema: execute statement of migration file %q at position %d: %w", f.Name(), s.Pos, err)
+			}
+		}
+		if current == nil {
+			continue
+		}
+		desired, err := dev.InspectRealm(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		changes, err := dev.RealmDiff(current, desired)
+		if err != nil {
+			return nil, err
+		}
+		// Concurrently created indexes cannot be inspected,
+		// and therefore, they are detected by their statements.
+		if dev.Name == dialect.Postgres {
+			concurrent := make(map[string]bool)
+			for _, s := range stmts {
+				if m := concurrentIndexRe.FindStringSubmatch(s.Text); m != nil {
+					concurrent[m[1]] = true
+				}
+			}
+			markConcurrent(changes, concurrent)
+		}
+		if fs := LintChanges(dev.Name, flatSchemas(changes)); len(fs) > 0 {
+			lints = append(lints, &FileFindings{File: f, Findings: fs})
+		}
+		current = desired
+	}
+	return lints, nil
+}
+
+// flatSchemas returns the table changes of the given realm changes.
+func flatSchemas(changes []schema.Change) []schema.Change {
+	flat := make([]schema.Change, 0, len(changes))
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.ModifySchema:
+			flat = append(flat, flatSchemas(c.Changes)...)
+		case *schema.DropSchema:
+			for _, t := range c.S.Tables {
+				flat = append(flat, &schema.DropTable{T: t})
+			}
+		default:
+			flat = append(flat, c)
+		}
+	}
+	return flat
+}
+
+// markConcurrent marks the created indexes of the given names as concurrent.
+func markConcurrent(changes []schema.Change, names map[string]bool) {
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.ModifySchema:
+			markConcurrent(c.Changes, names)
+		case *schema.ModifyTable:
+			markConcurrent(c.Changes, names)
+		case *schema.AddIndex:
+			if names[c.I.Name] {
+				c.Extra = append(c.Extra, &postgres.Concurrently{})
+			}
+		}
+	}
+}
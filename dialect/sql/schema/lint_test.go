@@ -0,0 +1,148 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/dialect"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintChanges(t *testing.T) {
+	var (
+		users = schema.NewTable("users")
+		name  = schema.NewStringColumn("name", "varchar", schema.StringSize(255))
+		age   = schema.NewIntColumn("age", "bigint")
+		idx   = schema.NewIndex("users_name").AddColumns(name)
+	)
+	changes := []schema.Change{
+		&schema.AddTable{T: schema.NewTable("pets").AddColumns(schema.NewIntColumn("id", "bigint"))},
+		&schema.DropTable{T: schema.NewTable("groups")},
+		&schema.ModifyTable{
+			T: users,
+			Changes: []schema.Change{
+				&schema.DropColumn{C: schema.NewStringColumn("nickname", "text")},
+				&schema.AddColumn{C: schema.NewStringColumn("email", "text")},
+				&schema.AddColumn{C: schema.NewStringColumn("role", "text").SetDefault(&schema.Literal{V: "'user'"})},
+				&schema.AddColumn{C: schema.NewNullStringColumn("bio", "text")},
+				&schema.ModifyColumn{
+					From:   schema.NewNullStringColumn("name", "text"),
+					To:     name,
+					Change: schema.ChangeNull | schema.ChangeType,
+				},
+				&schema.ModifyColumn{
+					From:   age,
+					To:     schema.NewIntColumn("age", "integer"),
+					Change: schema.ChangeType,
+				},
+				&schema.ModifyColumn{
+					From:   schema.NewIntColumn("score", "integer"),
+					To:     schema.NewIntColumn("score", "bigint"),
+					Change: schema.ChangeType,
+				},
+				&schema.AddIndex{I: idx},
+				&schema.AddIndex{I: schema.NewIndex("users_age").AddColumns(age), Extra: []schema.Clause{&postgres.Concurrently{}}},
+			},
+		},
+	}
+	fs := LintChanges(dialect.Postgres, changes)
+	msgs := make([]string, len(fs))
+	for i, f := range fs {
+		msgs[i] = f.String()
+	}
+	require.Equal(t, []string{
+		`error [drop-table]: dropping table "groups" deletes its data`,
+		`error [drop-column]: dropping column "nickname" of table "users" deletes its data`,
+		`error [not-null]: adding non-nullable column "email" to table "users" without a default value fails if the table is not empty`,
+		`warning [not-null]: setting column "name" of table "users" to non-nullable without a default value fails if it contains NULL values`,
+		`warning [narrow-type]: changing the type of column "name" of table "users" from text to varchar(255) might fail or truncate its data`,
+		`warning [narrow-type]: changing the type of column "age" of table "users" from bigint to integer might fail or truncate its data`,
+		`warning [blocking-index]: creating index "users_name" on table "users" without the CONCURRENTLY option blocks writes to the table until the index is built`,
+	}, msgs)
+	require.Equal(t, "users", fs[1].Table)
+	require.Equal(t, changes[2].(*schema.ModifyTable).Changes[0], fs[1].Change)
+
+	// Indexes are reported only for Postgres.
+	fs = LintChanges(dialect.MySQL, changes)
+	require.Len(t, fs, 6)
+	for _, f := range fs {
+		require.NotEqual(t, LintBlockingIndex, f.Code)
+	}
+}
+
+func TestLintPlan(t *testing.T) {
+	m := &schema.ModifyTable{
+		T: schema.NewTable("users"),
+		Changes: []schema.Change{
+			&schema.DropColumn{C: schema.NewStringColumn("name", "text")},
+		},
+	}
+	fs := LintPlan(dialect.SQLite, &migrate.Plan{
+		Changes: []*migrate.Change{
+			{Cmd: "PRAGMA foreign_keys = off"},
+			{Cmd: "CREATE TABLE `new_users` (...)", Source: m},
+			{Cmd: "DROP TABLE `users`", Source: m},
+			{Cmd: "PRAGMA foreign_keys = on"},
+		},
+	})
+	require.Len(t, fs, 1)
+	require.Equal(t, SeverityError, fs[0].Severity)
+	require.Equal(t, LintDropColumn, fs[0].Code)
+}
+
+func TestLintDir(t *testing.T) {
+	ctx := context.Background()
+	dir, err := migrate.NewLocalDir(t.TempDir())
+	require.NoError(t, err)
+	for name, content := range map[string]string{
+		"1_init.sql":    "CREATE TABLE `users` (`id` integer NOT NULL, `name` text NULL, `age` integer NOT NULL, PRIMARY KEY (`id`));\nCREATE TABLE `groups` (`id` integer NOT NULL, PRIMARY KEY (`id`));\n",
+		"2_email.sql":   "ALTER TABLE `users` ADD COLUMN `email` text NOT NULL DEFAULT '';\nCREATE INDEX `users_email` ON `users` (`email`);\n",
+		"3_cleanup.sql": "ALTER TABLE `users` DROP COLUMN `age`;\nDROP TABLE `groups`;\n",
+	} {
+		require.NoError(t, dir.WriteFile(name, []byte(content)))
+	}
+	_, err = LintDir(ctx, "sqlite://lint?mode=memory&_fk=1", dir, 0)
+	require.ErrorIs(t, err, migrate.ErrChecksumNotFound)
+	sum, err := dir.Checksum()
+	require.NoError(t, err)
+	require.NoError(t, migrate.WriteSumFile(dir, sum))
+
+	files, err := LintDir(ctx, "sqlite://lint?mode=memory&_fk=1", dir, 0)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, "3_cleanup.sql", files[0].File.Name())
+	require.Len(t, files[0].Findings, 2)
+	require.Equal(t, LintDropColumn, files[0].Findings[0].Code)
+	require.Equal(t, "users", files[0].Findings[0].Table)
+	require.Equal(t, LintDropTable, files[0].Findings[1].Code)
+	require.Equal(t, "groups", files[0].Findings[1].Table)
+
+	// Lint only the last file, after the previous ones were replayed.
+	files, err = LintDir(ctx, "sqlite://lint2?mode=memory&_fk=1", dir, 1)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Len(t, files[0].Findings, 2)
+
+	// Drivers that were not imported are reported with their package.
+	_, err = LintDir(ctx, "postgres://localhost:5432/dev?sslmode=disable", dir, 0)
+	require.EqualError(t, err, `sql/schema: database driver "postgres" of the dev database is not registered. Import it in the program that runs the linter (e.g. import _ "github.com/lib/pq")`)
+}
+
+func TestParseSeverity(t *testing.T) {
+	s, err := ParseSeverity("warning")
+	require.NoError(t, err)
+	require.Equal(t, SeverityWarning, s)
+	s, err = ParseSeverity("ERROR")
+	require.NoError(t, err)
+	require.Equal(t, SeverityError, s)
+	_, err = ParseSeverity("fatal")
+	require.Error(t, err)
+}
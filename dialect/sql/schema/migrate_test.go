@@ -16,6 +16,8 @@ import (
 	"time"
 
 	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/mysql"
+	"ariga.io/atlas/sql/postgres"
 	"ariga.io/atlas/sql/schema"
 	"ariga.io/atlas/sql/sqlite"
 	"ariga.io/atlas/sql/sqltool"
@@ -39,8 +41,13 @@ func TestMigrate_SchemaName(t *testing.T) {
 	mk.ExpectQuery("SELECT nspname AS schema_name,.+").
 		WithArgs("public"). // Schema "public" param is used.
 		WillReturnRows(sqlmock.NewRows([]string{"schema_name", "comment"}).AddRow("public", "default schema"))
+	mk.ExpectQuery("SELECT n.nspname AS schema_name, e.enumtypid AS enum_id,.+").
+		WillReturnRows(sqlmock.NewRows([]string{"schema_name", "enum_id", "enum_name", "enum_value"}))
 	mk.ExpectQuery("SELECT t3.oid, t1.table_schema,.+").
 		WillReturnRows(sqlmock.NewRows([]string{}))
+	mk.ExpectQuery("SELECT 'function', p.proname.+").
+		WithArgs("public").
+		WillReturnRows(sqlmock.NewRows([]string{"kind", "name", "table", "args", "def", "comment"}))
 	m, err := NewMigrate(sql.OpenDB("postgres", db), WithSchemaName("public"), WithDiffHook(func(next Differ) Differ {
 		return DiffFunc(func(current, desired *schema.Schema) ([]schema.Change, error) {
 			return nil, nil // Noop.
@@ -57,8 +64,13 @@ func TestMigrate_SchemaName(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"current_setting", "current_setting", "current_setting"}).AddRow("130000", "heap", ""))
 	mk.ExpectQuery("SELECT nspname AS schema_name,.+CURRENT_SCHEMA().+").
 		WillReturnRows(sqlmock.NewRows([]string{"schema_name", "comment"}).AddRow("public", "default schema"))
+	mk.ExpectQuery("SELECT n.nspname AS schema_name, e.enumtypid AS enum_id,.+").
+		WillReturnRows(sqlmock.NewRows([]string{"schema_name", "enum_id", "enum_name", "enum_value"}))
 	mk.ExpectQuery("SELECT t3.oid, t1.table_schema,.+").
 		WillReturnRows(sqlmock.NewRows([]string{}))
+	mk.ExpectQuery("SELECT 'function', p.proname.+").
+		WithArgs("").
+		WillReturnRows(sqlmock.NewRows([]string{"kind", "name", "table", "args", "def", "comment"}))
 	m, err = NewMigrate(sql.OpenDB("postgres", db), WithDiffHook(func(next Differ) Differ {
 		return DiffFunc(func(current, desired *schema.Schema) ([]schema.Change, error) {
 			return nil, nil // Noop.
@@ -66,6 +78,7 @@ func TestMigrate_SchemaName(t *testing.T) {
 	}))
 	require.NoError(t, err)
 	require.NoError(t, m.Create(context.Background()))
+	require.NoError(t, mk.ExpectationsWereMet())
 }
 
 func escape(query string) string {
@@ -423,6 +436,8 @@ func TestAtlas_StateReader(t *testing.T) {
 			{Name: "id", Type: field.TypeInt64, Increment: true},
 			{Name: "name", Type: field.TypeString},
 			{Name: "active", Type: field.TypeBool},
+			{Name: "balance", Type: field.TypeDecimal, Precision: 10, Scale: 2, Default: "9.99"},
+			{Name: "timeout", Type: field.TypeDuration, Default: int64(time.Minute)},
 		},
 		Annotation: &entsql.Annotation{
 			IncrementStart: func(i int) *int { return &i }(100),
@@ -441,6 +456,50 @@ func TestAtlas_StateReader(t *testing.T) {
 				AddAttrs(&sqlite.AutoIncrement{}),
 			schema.NewStringColumn("name", "text"),
 			schema.NewBoolColumn("active", "bool"),
+			schema.NewDecimalColumn("balance", "decimal", schema.DecimalPrecision(10), schema.DecimalScale(2)).SetDefault(&schema.Literal{V: "9.99"}),
+			schema.NewIntColumn("timeout", "integer").SetDefault(&schema.Literal{V: "60000000000"}),
 		},
 	)
 }
+
+func TestAtlas_DurationColumn(t *testing.T) {
+	tests := []struct {
+		dialect sqlDialect
+		column  *Column
+		typ     schema.Type
+		def     string
+	}{
+		{
+			dialect: &Postgres{Driver: sql.OpenDB(dialect.Postgres, nil)},
+			column:  &Column{Name: "timeout", Type: field.TypeDuration, Default: int64(90 * time.Minute)},
+			typ:     &postgres.IntervalType{T: postgres.TypeInterval},
+			def:     "01:30:00",
+		},
+		{
+			dialect: &Postgres{Driver: sql.OpenDB(dialect.Postgres, nil)},
+			column:  &Column{Name: "timeout", Type: field.TypeDuration, Default: int64(time.Second), SchemaType: map[string]string{dialect.Postgres: "bigint"}},
+			typ:     &schema.IntegerType{T: postgres.TypeBigInt},
+			def:     "1000000000",
+		},
+		{
+			dialect: &MySQL{Driver: sql.OpenDB(dialect.MySQL, nil), version: "8.0.19"},
+			column:  &Column{Name: "timeout", Type: field.TypeDuration, Default: int64(time.Second)},
+			typ:     &schema.IntegerType{T: mysql.TypeBigInt},
+			def:     "1000000000",
+		},
+		{
+			dialect: &MySQL{Driver: sql.OpenDB(dialect.MySQL, nil), version: "8.0.19"},
+			column:  &Column{Name: "timeout", Type: field.TypeDuration, Default: int64(-time.Second), SchemaType: map[string]string{dialect.MySQL: "time(6)"}},
+			typ:     &schema.TimeType{T: mysql.TypeTime, Precision: func(i int) *int { return &i }(6)},
+			def:     "-00:00:01.000000",
+		},
+	}
+	for _, tt := range tests {
+		a := &Atlas{sqlDialect: tt.dialect}
+		c := &schema.Column{Name: tt.column.Name, Type: &schema.ColumnType{}}
+		require.NoError(t, tt.dialect.atTypeC(tt.column, c))
+		require.Equal(t, tt.typ, c.Type.Type)
+		require.NoError(t, a.atDefault(tt.column, c))
+		require.Equal(t, &schema.Literal{V: tt.def}, c.Default)
+	}
+}
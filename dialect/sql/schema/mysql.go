@@ -6,6 +6,7 @@ package schema
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"reflect"
@@ -13,6 +14,7 @@ import (
 	"strings"
 
 	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/schema/field"
 
@@ -188,6 +190,10 @@ func (d *MySQL) atTypeC(c1 *Column, c2 *schema.Column) error {
 		}
 	case field.TypeFloat32, field.TypeFloat64:
 		t = &schema.FloatType{T: c1.scanTypeOr(mysql.TypeDouble)}
+	case field.TypeDecimal:
+		t = &schema.DecimalType{T: mysql.TypeDecimal, Precision: int(c1.Precision), Scale: int(c1.Scale)}
+	case field.TypeDuration:
+		t = &schema.IntegerType{T: mysql.TypeBigInt}
 	case field.TypeTime:
 		t = &schema.TimeType{T: c1.scanTypeOr(mysql.TypeTimestamp)}
 		// In MariaDB or in MySQL < v8.0.2, the TIMESTAMP column has both `DEFAULT CURRENT_TIMESTAMP`
@@ -244,6 +250,35 @@ func (d *MySQL) atIncrementT(t *schema.Table, v int64) {
 	t.AddAttrs(&mysql.AutoIncrement{V: v})
 }
 
+// atPartition appends the partition clause to the table options, as Atlas does not support MySQL
+// partitioning. Hence, the clause is applied only when the table is created, and is not diffed.
+func (d *MySQL) atPartition(t1 *Table, t2 *schema.Table) error {
+	if t1.Annotation == nil || t1.Annotation.Partition == nil {
+		return nil
+	}
+	key, err := partitionKey(t1.Annotation.Partition, t2)
+	if err != nil {
+		return err
+	}
+	columns := make([]string, len(key))
+	for i, c := range key {
+		columns[i] = "`" + c.Name + "`"
+	}
+	var clause string
+	switch p := t1.Annotation.Partition; p.Type {
+	case entsql.PartitionRange:
+		clause = fmt.Sprintf("PARTITION BY RANGE COLUMNS(%s) (PARTITION `%s` VALUES LESS THAN (%s))", strings.Join(columns, ", "), PartitionMax, strings.Repeat("MAXVALUE, ", len(columns)-1)+"MAXVALUE")
+	case entsql.PartitionHash:
+		clause = fmt.Sprintf("PARTITION BY KEY(%s)", strings.Join(columns, ", "))
+	case entsql.PartitionList:
+		return errors.New("LIST partitioning is not supported by MySQL without explicit partitions")
+	default:
+		return fmt.Errorf("unknown partition type: %q", p.Type)
+	}
+	t2.AddAttrs(&mysql.CreateOptions{V: clause})
+	return nil
+}
+
 func (d *MySQL) atImplicitIndexName(idx *Index, c1 *Column) bool {
 	if idx.Name == c1.Name {
 		return true
@@ -0,0 +1,164 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/dialect/sql"
+)
+
+// PartitionMax is the name of the MAXVALUE partition that MySQL
+// RANGE-partitioned tables are created with (see entsql.Partition).
+const PartitionMax = "pmax"
+
+// partitionKey returns the columns of the partition key of the table, and appends the ones that are missing
+// from its primary key, as both PostgreSQL and MySQL require the primary key of a partitioned table to include
+// all the columns of its partition key.
+func partitionKey(p *entsql.PartitionOptions, t *schema.Table) ([]*schema.Column, error) {
+	switch p.Type {
+	case entsql.PartitionRange, entsql.PartitionList, entsql.PartitionHash:
+	default:
+		return nil, fmt.Errorf("unknown partition type: %q", p.Type)
+	}
+	if len(p.Columns) == 0 {
+		return nil, errors.New("missing partition columns")
+	}
+	columns := make([]*schema.Column, len(p.Columns))
+	for i, name := range p.Columns {
+		c, ok := t.Column(name)
+		if !ok {
+			return nil, fmt.Errorf("unexpected partition column: %q", name)
+		}
+		columns[i] = c
+		if t.PrimaryKey != nil && !slices.ContainsFunc(t.PrimaryKey.Parts, func(p *schema.IndexPart) bool { return p.C == c }) {
+			t.PrimaryKey.AddColumns(c)
+		}
+	}
+	return columns, nil
+}
+
+// Partition describes a partition of a table that was annotated with entsql.Partition.
+type Partition struct {
+	// Name of the partition. In PostgreSQL, partitions are tables.
+	Name string
+	// Table is the name of the partitioned table.
+	Table string
+	// From and To hold the bounds of a RANGE partition as SQL expressions (e.g. "'2025-01-01'"),
+	// one for each column of the partition key. The lower bound is inclusive, and the upper bound
+	// is exclusive. A missing lower bound defaults to MINVALUE. MySQL ignores the lower bound, as
+	// its partitions are defined by their upper bounds.
+	From, To []string
+	// In holds the values of a LIST partition as SQL expressions (PostgreSQL only).
+	In []string
+	// Modulus and Remainder define a HASH partition (PostgreSQL only).
+	Modulus, Remainder int
+}
+
+// CreatePartition creates the given partition of a partitioned table. In PostgreSQL, the partition
+// is created as a table that holds the rows of its bound, or the rows that do not belong to any other
+// partition, if no bound was defined. In MySQL, only RANGE partitions are supported, and they are split
+// from the PartitionMax partition of the table.
+//
+//	for _, p := range schema.MonthlyPartitions("events", time.Now(), 3) {
+//		if err := schema.CreatePartition(ctx, drv, p); err != nil {
+//			return err
+//		}
+//	}
+func CreatePartition(ctx context.Context, drv dialect.Driver, p *Partition) error {
+	var query string
+	switch d := drv.Dialect(); d {
+	case dialect.Postgres:
+		query = sql.Dialect(d).String(func(b *sql.Builder) {
+			b.WriteString("CREATE TABLE IF NOT EXISTS ").Ident(p.Name).WriteString(" PARTITION OF ").Ident(p.Table).Pad()
+			p.bound(b)
+		})
+	case dialect.MySQL:
+		if len(p.To) == 0 {
+			return fmt.Errorf("sql/schema: partition %q: MySQL supports only RANGE partitions with an upper bound", p.Name)
+		}
+		query = sql.Dialect(d).String(func(b *sql.Builder) {
+			b.WriteString("ALTER TABLE ").Ident(p.Table).WriteString(" REORGANIZE PARTITION ").Ident(PartitionMax).WriteString(" INTO ")
+			b.Wrap(func(b *sql.Builder) {
+				b.WriteString("PARTITION ").Ident(p.Name).WriteString(" VALUES LESS THAN ").Wrap(func(b *sql.Builder) {
+					b.WriteString(strings.Join(p.To, ", "))
+				})
+				b.Comma().WriteString("PARTITION ").Ident(PartitionMax).WriteString(" VALUES LESS THAN ").Wrap(func(b *sql.Builder) {
+					b.WriteString(strings.Repeat("MAXVALUE, ", len(p.To)-1) + "MAXVALUE")
+				})
+			})
+		})
+	default:
+		return fmt.Errorf("sql/schema: partitioning is not supported by dialect %q", d)
+	}
+	if err := drv.Exec(ctx, query, []any{}, nil); err != nil {
+		return fmt.Errorf("sql/schema: create partition %q: %w", p.Name, err)
+	}
+	return nil
+}
+
+// AttachPartition attaches an existing table as the given partition of a partitioned table (PostgreSQL only).
+// The table must match the columns of the partitioned table, and hold only rows that belong to the bound of
+// the partition.
+func AttachPartition(ctx context.Context, drv dialect.Driver, p *Partition) error {
+	if d := drv.Dialect(); d != dialect.Postgres {
+		return fmt.Errorf("sql/schema: attaching partitions is not supported by dialect %q", d)
+	}
+	query := sql.Dialect(dialect.Postgres).String(func(b *sql.Builder) {
+		b.WriteString("ALTER TABLE ").Ident(p.Table).WriteString(" ATTACH PARTITION ").Ident(p.Name).Pad()
+		p.bound(b)
+	})
+	if err := drv.Exec(ctx, query, []any{}, nil); err != nil {
+		return fmt.Errorf("sql/schema: attach partition %q: %w", p.Name, err)
+	}
+	return nil
+}
+
+// bound writes the PostgreSQL partition bound of the partition.
+func (p *Partition) bound(b *sql.Builder) {
+	switch {
+	case len(p.To) > 0:
+		from := p.From
+		if len(from) == 0 {
+			from = make([]string, len(p.To))
+			for i := range from {
+				from[i] = "MINVALUE"
+			}
+		}
+		b.WriteString("FOR VALUES FROM (" + strings.Join(from, ", ") + ") TO (" + strings.Join(p.To, ", ") + ")")
+	case len(p.In) > 0:
+		b.WriteString("FOR VALUES IN (" + strings.Join(p.In, ", ") + ")")
+	case p.Modulus > 0:
+		b.WriteString(fmt.Sprintf("FOR VALUES WITH (MODULUS %d, REMAINDER %d)", p.Modulus, p.Remainder))
+	default:
+		b.WriteString("DEFAULT")
+	}
+}
+
+// MonthlyPartitions returns the RANGE partitions of the given table for n months, starting with the
+// month of the given time. The partitions are named <table>_<YYYY>_<MM>, and their bounds are the
+// first days of the months (in the location of the given time).
+func MonthlyPartitions(table string, start time.Time, n int) []*Partition {
+	ps := make([]*Partition, n)
+	start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location())
+	for i := range ps {
+		from, to := start.AddDate(0, i, 0), start.AddDate(0, i+1, 0)
+		ps[i] = &Partition{
+			Name:  fmt.Sprintf("%s_%s", table, from.Format("2006_01")),
+			Table: table,
+			From:  []string{from.Format("'2006-01-02 15:04:05'")},
+			To:    []string{to.Format("'2006-01-02 15:04:05'")},
+		}
+	}
+	return ps
+}
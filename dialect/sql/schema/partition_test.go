@@ -0,0 +1,120 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/field"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func eventsTable(ant *entsql.Annotation) *Table {
+	return NewTable("events").
+		AddPrimary(&Column{Name: "id", Type: field.TypeInt, Increment: true}).
+		AddColumn(&Column{Name: "kind", Type: field.TypeString}).
+		AddColumn(&Column{Name: "created_at", Type: field.TypeTime, SchemaType: map[string]string{dialect.MySQL: "datetime"}}).
+		SetAnnotation(ant)
+}
+
+func TestDump_Partition(t *testing.T) {
+	ctx := context.Background()
+	ac, err := Dump(ctx, dialect.Postgres, "15", []*Table{eventsTable(entsql.Partition(entsql.PartitionRange, "created_at"))})
+	require.NoError(t, err)
+	require.Equal(t, `-- Create "events" table
+CREATE TABLE "events" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "kind" character varying NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id", "created_at")
+) PARTITION BY RANGE ("created_at");
+`, ac)
+
+	ac, err = Dump(ctx, dialect.MySQL, "8", []*Table{eventsTable(entsql.Partition(entsql.PartitionRange, "created_at"))})
+	require.NoError(t, err)
+	require.Equal(t, "-- Create \"events\" table\nCREATE TABLE `events` (\n  `id` bigint NOT NULL AUTO_INCREMENT,\n  `kind` varchar(255) NOT NULL,\n  `created_at` datetime NOT NULL,\n  PRIMARY KEY (`id`, `created_at`)\n) CHARSET utf8mb4 COLLATE utf8mb4_bin PARTITION BY RANGE COLUMNS(`created_at`) (PARTITION `pmax` VALUES LESS THAN (MAXVALUE));\n", ac)
+
+	ac, err = Dump(ctx, dialect.MySQL, "8", []*Table{eventsTable(entsql.Partition(entsql.PartitionHash, "id"))})
+	require.NoError(t, err)
+	require.Contains(t, ac, "  PRIMARY KEY (`id`)\n) CHARSET utf8mb4 COLLATE utf8mb4_bin PARTITION BY KEY(`id`);\n")
+
+	// Partitioning is ignored by SQLite.
+	ac, err = Dump(ctx, dialect.SQLite, "", []*Table{eventsTable(entsql.Partition(entsql.PartitionList, "kind"))})
+	require.NoError(t, err)
+	require.NotContains(t, ac, "PARTITION")
+
+	_, err = Dump(ctx, dialect.MySQL, "8", []*Table{eventsTable(entsql.Partition(entsql.PartitionList, "kind"))})
+	require.EqualError(t, err, `partition of table "events": LIST partitioning is not supported by MySQL without explicit partitions`)
+	_, err = Dump(ctx, dialect.Postgres, "15", []*Table{eventsTable(entsql.Partition(entsql.PartitionRange, "unknown"))})
+	require.EqualError(t, err, `partition of table "events": unexpected partition column: "unknown"`)
+	_, err = Dump(ctx, dialect.Postgres, "15", []*Table{eventsTable(entsql.Partition("INTERVAL", "created_at"))})
+	require.EqualError(t, err, `partition of table "events": unknown partition type: "INTERVAL"`)
+}
+
+func TestAtlas_PartitionChanges(t *testing.T) {
+	var (
+		drv = drivers("15")[dialect.Postgres]
+		a   = &Atlas{sqlDialect: drv, dialect: dialect.Postgres}
+	)
+	r1, err := a.realm([]*Table{eventsTable(entsql.Partition(entsql.PartitionRange, "created_at"))})
+	require.NoError(t, err)
+	r2, err := a.realm([]*Table{eventsTable(entsql.Partition(entsql.PartitionList, "kind"))})
+	require.NoError(t, err)
+	_, err = drv.SchemaDiff(r1.Schemas[0], r2.Schemas[0])
+	require.EqualError(t, err, `partition key of table "events" cannot be changed from PARTITION BY RANGE ("created_at") to PARTITION BY LIST ("kind") (drop and add is required)`)
+
+	r2, err = a.realm([]*Table{eventsTable(entsql.Partition(entsql.PartitionRange, "created_at"))})
+	require.NoError(t, err)
+	changes, err := drv.SchemaDiff(r1.Schemas[0], r2.Schemas[0])
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func TestCreatePartition(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE IF NOT EXISTS "events_2025_01" PARTITION OF "events" FOR VALUES FROM ('2025-01-01 00:00:00') TO ('2025-02-01 00:00:00')`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE IF NOT EXISTS "events_default" PARTITION OF "events" DEFAULT`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE IF NOT EXISTS "events_0" PARTITION OF "events" FOR VALUES WITH (MODULUS 2, REMAINDER 0)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`ALTER TABLE "events" ATTACH PARTITION "events_a" FOR VALUES IN ('a', 'b')`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	drv := sql.OpenDB(dialect.Postgres, db)
+	jan := time.Date(2025, time.January, 15, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, CreatePartition(ctx, drv, MonthlyPartitions("events", jan, 1)[0]))
+	require.NoError(t, CreatePartition(ctx, drv, &Partition{Name: "events_default", Table: "events"}))
+	require.NoError(t, CreatePartition(ctx, drv, &Partition{Name: "events_0", Table: "events", Modulus: 2}))
+	require.NoError(t, AttachPartition(ctx, drv, &Partition{Name: "events_a", Table: "events", In: []string{"'a'", "'b'"}}))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	db, mock, err = sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE `events` REORGANIZE PARTITION `pmax` INTO (PARTITION `events_2025_01` VALUES LESS THAN ('2025-02-01 00:00:00'), PARTITION `pmax` VALUES LESS THAN (MAXVALUE))")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	drv = sql.OpenDB(dialect.MySQL, db)
+	require.NoError(t, CreatePartition(ctx, drv, MonthlyPartitions("events", jan, 1)[0]))
+	require.EqualError(t, CreatePartition(ctx, drv, &Partition{Name: "events_a", Table: "events", In: []string{"'a'"}}), `sql/schema: partition "events_a": MySQL supports only RANGE partitions with an upper bound`)
+	require.EqualError(t, AttachPartition(ctx, drv, &Partition{Name: "events_a", Table: "events"}), `sql/schema: attaching partitions is not supported by dialect "mysql"`)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMonthlyPartitions(t *testing.T) {
+	ps := MonthlyPartitions("events", time.Date(2024, time.December, 31, 23, 0, 0, 0, time.UTC), 2)
+	require.Equal(t, []*Partition{
+		{Name: "events_2024_12", Table: "events", From: []string{"'2024-12-01 00:00:00'"}, To: []string{"'2025-01-01 00:00:00'"}},
+		{Name: "events_2025_01", Table: "events", From: []string{"'2025-01-01 00:00:00'"}, To: []string{"'2025-02-01 00:00:00'"}},
+	}, ps)
+}
@@ -125,6 +125,10 @@ func (d *Postgres) atTypeC(c1 *Column, c2 *schema.Column) error {
 		t = &schema.FloatType{T: c1.scanTypeOr(postgres.TypeReal)}
 	case field.TypeFloat64:
 		t = &schema.FloatType{T: c1.scanTypeOr(postgres.TypeDouble)}
+	case field.TypeDecimal:
+		t = &schema.DecimalType{T: postgres.TypeNumeric, Precision: int(c1.Precision), Scale: int(c1.Scale)}
+	case field.TypeDuration:
+		t = &postgres.IntervalType{T: postgres.TypeInterval}
 	case field.TypeBytes:
 		t = &schema.BinaryType{T: postgres.TypeBytea}
 	case field.TypeUUID:
@@ -140,8 +144,12 @@ func (d *Postgres) atTypeC(c1 *Column, c2 *schema.Column) error {
 		t = &schema.TimeType{T: c1.scanTypeOr(postgres.TypeTimestampWTZ)}
 	case field.TypeEnum:
 		// Although atlas supports enum types, we keep backwards compatibility
-		// with previous versions of ent and use varchar (see cType).
+		// with previous versions of ent and use varchar (see cType), unless a
+		// native enum type was defined explicitly for the column.
 		t = &schema.StringType{T: postgres.TypeVarChar}
+		if c1.EnumType != "" {
+			t = &schema.EnumType{T: c1.EnumType, Values: c1.Enums}
+		}
 	case field.TypeOther:
 		t = &schema.UnsupportedType{T: c1.typ}
 	default:
@@ -196,6 +204,22 @@ func (d *Postgres) atIncrementT(t *schema.Table, v int64) {
 	t.AddAttrs(&postgres.Identity{Sequence: &postgres.Sequence{Start: v}})
 }
 
+func (d *Postgres) atPartition(t1 *Table, t2 *schema.Table) error {
+	if t1.Annotation == nil || t1.Annotation.Partition == nil {
+		return nil
+	}
+	columns, err := partitionKey(t1.Annotation.Partition, t2)
+	if err != nil {
+		return err
+	}
+	key := &postgres.Partition{T: string(t1.Annotation.Partition.Type)}
+	for _, c := range columns {
+		key.Parts = append(key.Parts, &postgres.PartitionPart{C: c})
+	}
+	t2.AddAttrs(key)
+	return nil
+}
+
 // indexOpClass returns a map holding the operator-class mapping if exists.
 func indexOpClass(idx *Index) map[string]string {
 	opc := make(map[string]string)
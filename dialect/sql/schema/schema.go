@@ -45,7 +45,8 @@ type Table struct {
 	ForeignKeys []*ForeignKey
 	Annotation  *entsql.Annotation
 	Comment     string
-	View        bool // Indicate the table is a view.
+	View        bool   // Indicate the table is a view.
+	TypeName    string // Ent type name of the table, if any.
 }
 
 // NewTable returns a new table with the given name.
@@ -290,23 +291,34 @@ type Column struct {
 	SchemaType map[string]string // optional schema type per dialect.
 	Attr       string            // extra attributes.
 	Size       int64             // max size parameter for string, blob, etc.
+	Precision  int64             // precision of decimal columns.
+	Scale      int64             // scale of decimal columns.
 	Key        string            // key definition (PRI, UNI or MUL).
 	Unique     bool              // column with unique constraint.
 	Increment  bool              // auto increment attribute.
 	Nullable   bool              // null or not null attribute.
 	Default    any               // default value.
 	Enums      []string          // enum values.
+	EnumType   string            // native enum type name (PostgreSQL).
+	Generated  *GeneratedExpr    // generated column expression.
 	Collation  string            // collation type (utf8mb4_unicode_ci, utf8mb4_general_ci)
 	typ        string            // row column type (used for Rows.Scan).
 	indexes    Indexes           // linked indexes.
 	foreign    *ForeignKey       // linked foreign-key.
 	Comment    string            // optional column comment.
+	FieldName  string            // Ent field name of the column, if any.
 }
 
 // Expr represents a raw expression. It is used to distinguish between
 // literal values and raw expressions when defining default values.
 type Expr string
 
+// GeneratedExpr describes the expression of a generated column.
+type GeneratedExpr struct {
+	Expr string // SQL expression.
+	Type string // STORED or VIRTUAL.
+}
+
 // UniqueKey returns boolean indicates if this column is a unique key.
 // Used by the migration tool when parsing the `DESCRIBE TABLE` output Go objects.
 func (c *Column) UniqueKey() bool { return c.Key == UniqueKey }
@@ -369,6 +381,14 @@ func (c *Column) ScanDefault(value string) error {
 			return fmt.Errorf("scanning float value for column %q: %w", c.Name, err)
 		}
 		c.Default = v.Float64
+	case c.Type == field.TypeDecimal:
+		c.Default = strings.Trim(value, "'")
+	case c.Type == field.TypeDuration:
+		v := &sql.NullDuration{}
+		if err := v.Scan(strings.Trim(strings.TrimSuffix(value, "::interval"), "'")); err != nil {
+			return fmt.Errorf("scanning duration value for column %q: %w", c.Name, err)
+		}
+		c.Default = int64(v.Duration)
 	case c.Type == field.TypeBool:
 		v := &sql.NullBool{}
 		if err := v.Scan(value); err != nil {
@@ -631,6 +651,10 @@ func Dump(ctx context.Context, dialect, version string, tables []*Table, opts ..
 			Comment: fmt.Sprintf("Add %q view", v.Name),
 		})
 	}
+	// Like views, functions and triggers are planned by Ent and not by Atlas.
+	if p, err = (&Atlas{dialect: dialect}).planRoutines(p, tables, nil); err != nil {
+		return "", err
+	}
 	f, err := migrate.DefaultFormatter.FormatFile(p)
 	if err != nil {
 		return "", err
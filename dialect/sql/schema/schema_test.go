@@ -15,6 +15,10 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/schema/field"
 
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -334,3 +338,232 @@ CREATE VIEW $pets_without_fur$ ($id$, $name$, $owner_id$) AS SELECT id, name, ow
 		})
 	}
 }
+
+func TestDump_EnumType(t *testing.T) {
+	status := &Column{Name: "status", Type: field.TypeEnum, Enums: []string{"active", "inactive"}, EnumType: "status"}
+	users := NewTable("users").
+		AddPrimary(&Column{Name: "id", Type: field.TypeInt, Increment: true}).
+		AddColumn(status)
+	pets := NewTable("pets").
+		AddPrimary(&Column{Name: "id", Type: field.TypeInt, Increment: true}).
+		AddColumn(&Column{Name: "status", Type: field.TypeEnum, Enums: []string{"active", "inactive"}, EnumType: "status"})
+	ac, err := Dump(context.Background(), dialect.Postgres, "15", []*Table{users, pets})
+	require.NoError(t, err)
+	require.Equal(t, `-- Create enum type "status"
+CREATE TYPE "status" AS ENUM ('active', 'inactive');
+-- Create "users" table
+CREATE TABLE "users" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "status" "status" NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create "pets" table
+CREATE TABLE "pets" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "status" "status" NOT NULL,
+  PRIMARY KEY ("id")
+);
+`, ac)
+
+	// Native enum types are ignored by dialects that do not support them.
+	ac, err = Dump(context.Background(), dialect.MySQL, "8", []*Table{users})
+	require.NoError(t, err)
+	require.Contains(t, ac, "`status` enum('active','inactive') NOT NULL")
+
+	pets.Columns[1].Enums = []string{"active"}
+	_, err = Dump(context.Background(), dialect.Postgres, "15", []*Table{users, pets})
+	require.EqualError(t, err, `enum type "status" of column "pets"."status" is defined with different values: ["active"] != ["active" "inactive"]`)
+}
+
+func TestAtlas_EnumChanges(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	mk.ExpectQuery(escape("SELECT current_setting('server_version_num'), current_setting('default_table_access_method', true), current_setting('crdb_version', true)")).
+		WillReturnRows(sqlmock.NewRows([]string{"current_setting", "current_setting", "current_setting"}).AddRow("150000", "heap", ""))
+	atDriver, err := postgres.Open(db)
+	require.NoError(t, err)
+	var (
+		ctx   = context.Background()
+		a     = &Atlas{sqlDialect: drivers("15")[dialect.Postgres], dialect: dialect.Postgres, atDriver: atDriver}
+		users = func(c *Column) *Table {
+			return NewTable("users").
+				AddPrimary(&Column{Name: "id", Type: field.TypeInt, Increment: true}).
+				AddColumn(c)
+		}
+		plan = func(current, desired []*Table) string {
+			r1, err := a.realm(current)
+			require.NoError(t, err)
+			r2, err := a.realm(desired)
+			require.NoError(t, err)
+			p, err := a.diff(ctx, "", r1.Schemas[0], r2.Schemas[0], nil)
+			require.NoError(t, err)
+			var stmts []string
+			for _, c := range p.Changes {
+				stmts = append(stmts, c.Cmd)
+			}
+			return strings.Join(stmts, ";\n")
+		}
+		v1 = users(&Column{Name: "status", Type: field.TypeEnum, Enums: []string{"active", "inactive"}, EnumType: "status"})
+		v2 = users(&Column{Name: "status", Type: field.TypeEnum, Enums: []string{"active", "inactive", "banned"}, EnumType: "status"})
+		v3 = users(&Column{Name: "status", Type: field.TypeEnum, Enums: []string{"active", "inactive", "banned"}})
+	)
+	require.Equal(t, `ALTER TYPE "status" ADD VALUE 'banned'`, plan([]*Table{v1}, []*Table{v2}))
+	require.Equal(t, `ALTER TABLE "users" ALTER COLUMN "status" TYPE character varying;
+DROP TYPE "status"`, plan([]*Table{v2}, []*Table{v3}))
+
+	// Types that are used by other tables are not dropped.
+	other := NewTable("other").
+		AddPrimary(&Column{Name: "id", Type: field.TypeInt, Increment: true}).
+		AddColumn(&Column{Name: "status", Type: field.TypeEnum, Enums: []string{"active", "inactive", "banned"}, EnumType: "status"})
+	require.Equal(t, `ALTER TABLE "users" ALTER COLUMN "status" TYPE character varying`, plan([]*Table{v2, other}, []*Table{v3}))
+}
+
+func TestAtlas_PlanInspectEnumTypes(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	mk.ExpectQuery(escape("SELECT current_setting('server_version_num'), current_setting('default_table_access_method', true), current_setting('crdb_version', true)")).
+		WillReturnRows(sqlmock.NewRows([]string{"current_setting", "current_setting", "current_setting"}).AddRow("150000", "heap", ""))
+	atDriver, err := postgres.Open(db)
+	require.NoError(t, err)
+	var (
+		ctx  = context.Background()
+		drv  = &inspectDriver{Driver: atDriver}
+		a    = &Atlas{sqlDialect: drivers("15")[dialect.Postgres], dialect: dialect.Postgres, atDriver: drv}
+		enum = &Column{Name: "status", Type: field.TypeEnum, Enums: []string{"active", "inactive"}, EnumType: "status"}
+		str  = &Column{Name: "status", Type: field.TypeEnum, Enums: []string{"active", "inactive"}}
+	)
+	users := func(c *Column) *Table {
+		return NewTable("users").
+			AddPrimary(&Column{Name: "id", Type: field.TypeInt, Increment: true}).
+			AddColumn(c)
+	}
+	r, err := a.realm([]*Table{users(enum)})
+	require.NoError(t, err)
+	drv.current = r.Schemas[0]
+
+	// The enum type is no longer used by the desired state, but it is
+	// inspected and dropped as it was used by the ent tables.
+	mk.ExpectQuery("SELECT 'function'").WillReturnRows(sqlmock.NewRows([]string{"kind", "name", "table", "args", "def", "mark"}))
+	plan, err := a.planInspect(ctx, sql.OpenDB(dialect.Postgres, db), "", []*Table{users(str)})
+	require.NoError(t, err)
+	require.True(t, drv.mode.Is(schema.InspectTypes))
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, `ALTER TABLE "users" ALTER COLUMN "status" TYPE character varying`, plan.Changes[0].Cmd)
+	require.Equal(t, `DROP TYPE "status"`, plan.Changes[1].Cmd)
+	require.NoError(t, mk.ExpectationsWereMet())
+}
+
+// inspectDriver is an atlas driver that returns a fixed current state
+// from inspection, and records the inspection mode it was called with.
+type inspectDriver struct {
+	migrate.Driver
+	current *schema.Schema
+	mode    schema.InspectMode
+}
+
+func (d *inspectDriver) InspectSchema(_ context.Context, _ string, opts *schema.InspectOptions) (*schema.Schema, error) {
+	d.mode = opts.Mode
+	s := *d.current
+	if !opts.Mode.Is(schema.InspectTypes) {
+		s.Objects = nil
+	}
+	return &s, nil
+}
+
+func TestAtlas_IndexExprsDiff(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	mk.ExpectQuery(escape("SELECT current_setting('server_version_num'), current_setting('default_table_access_method', true), current_setting('crdb_version', true)")).
+		WillReturnRows(sqlmock.NewRows([]string{"current_setting", "current_setting", "current_setting"}).AddRow("150000", "heap", ""))
+	atDriver, err := postgres.Open(db)
+	require.NoError(t, err)
+	var (
+		ctx   = context.Background()
+		a     = &Atlas{sqlDialect: drivers("15")[dialect.Postgres], dialect: dialect.Postgres, atDriver: atDriver}
+		users = func(x string) *Table {
+			t := NewTable("users").
+				AddPrimary(&Column{Name: "id", Type: field.TypeInt, Increment: true}).
+				AddColumn(&Column{Name: "email", Type: field.TypeString, Size: 100}).
+				AddIndex("users_lower_email", true, nil)
+			t.Indexes[0].Annotation = entsql.IndexExprs(x)
+			return t
+		}
+		// plan returns the changes of a second migration run, after the index was created
+		// from the given expression, and stored by PostgreSQL in its normal form.
+		plan = func(x string) []*migrate.Change {
+			r1, err := a.realm([]*Table{users(x)})
+			require.NoError(t, err)
+			idx, ok := r1.Schemas[0].Tables[0].Index("users_lower_email")
+			require.True(t, ok)
+			idx.Parts[0].X = &schema.RawExpr{X: "lower((email)::text)"}
+			r2, err := a.realm([]*Table{users(x)})
+			require.NoError(t, err)
+			p, err := a.diff(ctx, "", r1.Schemas[0], r2.Schemas[0], nil)
+			require.NoError(t, err)
+			return p.Changes
+		}
+	)
+	require.NotEmpty(t, plan("lower(email)"), "expressions that are not in normal form are recreated")
+	require.Empty(t, plan("lower((email)::text)"))
+}
+
+func TestDump_Generated(t *testing.T) {
+	items := func(typ string) *Table {
+		return NewTable("items").
+			AddPrimary(&Column{Name: "id", Type: field.TypeInt, Increment: true}).
+			AddColumn(&Column{Name: "price", Type: field.TypeInt}).
+			AddColumn(&Column{Name: "quantity", Type: field.TypeInt}).
+			AddColumn(&Column{Name: "total", Type: field.TypeInt, Generated: &GeneratedExpr{Expr: "price * quantity", Type: typ}})
+	}
+	ctx := context.Background()
+	ac, err := Dump(ctx, dialect.Postgres, "15", []*Table{items("STORED")})
+	require.NoError(t, err)
+	require.Contains(t, ac, `  "total" bigint NOT NULL GENERATED ALWAYS AS (price * quantity) STORED,`)
+	ac, err = Dump(ctx, dialect.MySQL, "8", []*Table{items("VIRTUAL")})
+	require.NoError(t, err)
+	require.Contains(t, ac, "  `total` bigint AS (price * quantity) VIRTUAL NOT NULL,")
+	ac, err = Dump(ctx, dialect.SQLite, "", []*Table{items("STORED")})
+	require.NoError(t, err)
+	require.Contains(t, ac, "  `total` integer NOT NULL AS (price * quantity) STORED\n")
+
+	_, err = Dump(ctx, dialect.Postgres, "15", []*Table{items("VIRTUAL")})
+	require.EqualError(t, err, `generated column "total": PostgreSQL supports only STORED generated columns`)
+	tb := items("STORED")
+	tb.Columns[3].Default = 1
+	_, err = Dump(ctx, dialect.MySQL, "8", []*Table{tb})
+	require.EqualError(t, err, `generated column "total" cannot have a default value`)
+}
+
+func TestDump_IndexExprs(t *testing.T) {
+	users := func(unique bool, ant *entsql.IndexAnnotation, columns ...string) *Table {
+		t := NewTable("users").
+			AddPrimary(&Column{Name: "id", Type: field.TypeInt, Increment: true}).
+			AddColumn(&Column{Name: "email", Type: field.TypeString, Size: 100}).
+			AddColumn(&Column{Name: "created_at", Type: field.TypeTime, SchemaType: map[string]string{dialect.MySQL: "datetime"}}).
+			AddIndex(strings.Join(append([]string{"users"}, columns...), "_"), unique, columns)
+		t.Indexes[0].Annotation = ant
+		return t
+	}
+	ctx := context.Background()
+	ac, err := Dump(ctx, dialect.Postgres, "15", []*Table{users(true, entsql.IndexExprs("lower(email)"), "lower_email")})
+	require.NoError(t, err)
+	require.Contains(t, ac, `CREATE UNIQUE INDEX "users_lower_email" ON "users" ((lower(email)));`)
+	ac, err = Dump(ctx, dialect.MySQL, "8", []*Table{users(true, entsql.IndexExprs("lower(email)"), "lower_email")})
+	require.NoError(t, err)
+	require.Contains(t, ac, "  UNIQUE INDEX `users_lower_email` ((lower(email)))\n")
+	ac, err = Dump(ctx, dialect.SQLite, "", []*Table{users(true, entsql.IndexExprs("lower(email)"), "lower_email")})
+	require.NoError(t, err)
+	require.Contains(t, ac, "CREATE UNIQUE INDEX `users_lower_email` ON `users` ((lower(email)));")
+
+	ant := &entsql.IndexAnnotation{
+		DescColumns: map[string]bool{"created_at": true},
+		ExprColumns: map[string]string{"created_at": "date(created_at)"},
+		Exprs:       []string{"length(email)"},
+	}
+	ac, err = Dump(ctx, dialect.Postgres, "15", []*Table{users(false, ant, "email", "created_at")})
+	require.NoError(t, err)
+	require.Contains(t, ac, `CREATE INDEX "users_email_created_at" ON "users" ("email", (date(created_at)) DESC, (length(email)));`)
+
+	_, err = Dump(ctx, dialect.Postgres, "15", []*Table{users(false, entsql.IndexExprColumn("unknown", "lower(unknown)"), "email")})
+	require.EqualError(t, err, `unexpected index "users_email" expression column: "unknown"`)
+}
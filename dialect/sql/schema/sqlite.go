@@ -127,6 +127,10 @@ func (d *SQLite) atTypeC(c1 *Column, c2 *schema.Column) error {
 		t = &schema.StringType{T: sqlite.TypeText}
 	case field.TypeFloat32, field.TypeFloat64:
 		t = &schema.FloatType{T: sqlite.TypeReal}
+	case field.TypeDecimal:
+		t = &schema.DecimalType{T: "decimal", Precision: int(c1.Precision), Scale: int(c1.Scale)}
+	case field.TypeDuration:
+		t = &schema.IntegerType{T: sqlite.TypeInteger}
 	case field.TypeTime:
 		t = &schema.TimeType{T: "datetime"}
 	case field.TypeJSON:
@@ -183,6 +187,11 @@ func (d *SQLite) atIncrementT(t *schema.Table, v int64) {
 	t.AddAttrs(&sqlite.AutoIncrement{Seq: v})
 }
 
+func (d *SQLite) atPartition(*Table, *schema.Table) error {
+	// SQLite does not support table partitioning.
+	return nil
+}
+
 func (d *SQLite) atIndex(idx1 *Index, t2 *schema.Table, idx2 *schema.Index) error {
 	for _, c1 := range idx1.Columns {
 		c2, ok := t2.Column(c1.Name)
@@ -0,0 +1,402 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+	"slices"
+	"strings"
+
+	"ariga.io/atlas/sql/migrate"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/dialect/sql"
+)
+
+// routine is a stored function, a trigger, a row-level security policy or the row-level security
+// state of a table, that were defined using the entsql.Function, entsql.Trigger and entsql.RowSecurity
+// annotations. Since the Atlas version bundled with Ent does not support managing them, their statements
+// are planned by Ent and appended to the migration plan.
+type routine struct {
+	kind    string // function, trigger, policy or rls.
+	name    string // name of the routine.
+	table   string // table of the trigger or the policy.
+	def     string // CREATE statement.
+	drop    string // DROP statement.
+	comment string // COMMENT statement that marks the routine as managed by Ent (PostgreSQL only).
+	mark    string // the mark of managed routines. In PostgreSQL, holds the checksum of their definition.
+}
+
+// key identifies the routine in its schema.
+func (r *routine) key() string {
+	return r.kind + ":" + r.table + ":" + r.name
+}
+
+// markPrefix prefixes the comments of the PostgreSQL routines that are managed by Ent.
+const markPrefix = "ent:"
+
+// sqliteMark marks the SQLite triggers that are managed by Ent. Since SQLite does not support commenting
+// objects, the mark is embedded in the definition of the trigger, which is stored verbatim by SQLite.
+const sqliteMark = "/* " + markPrefix + " */"
+
+// describe returns the comment of the change that applies the given operation on the
+// routine. Like the comments that are planned by Atlas, it starts with a capital letter.
+func (r *routine) describe(op string) string {
+	if r.kind == "rls" {
+		if op == "Create" {
+			op = "Enable"
+		}
+		return fmt.Sprintf("%s row-level security on %q", op, r.table)
+	}
+	return fmt.Sprintf("%s %q %s", op, r.name, r.kind)
+}
+
+// routines returns the functions, the triggers and the row-level security policies that are defined by
+// the annotations of the given tables for the migration dialect, ordered by their definition. Policies
+// are returned last, as their expressions might call the functions.
+func (a *Atlas) routines(tables []*Table) ([]*routine, error) {
+	var (
+		rs   []*routine
+		seen = make(map[string]*routine)
+	)
+	add := func(r *routine) error {
+		if r2, ok := seen[r.key()]; ok {
+			if r2.def != r.def {
+				return fmt.Errorf("%s %q is defined with different definitions", r.kind, r.name)
+			}
+			return nil
+		}
+		seen[r.key()] = r
+		rs = append(rs, r)
+		return nil
+	}
+	for _, t := range tables {
+		if t.Annotation == nil {
+			continue
+		}
+		if a.dialect == dialect.Postgres {
+			for _, f := range t.Annotation.Functions {
+				r, err := pgFunction(f)
+				if err != nil {
+					return nil, err
+				}
+				if err := add(r); err != nil {
+					return nil, err
+				}
+			}
+		}
+		for _, tr := range t.Annotation.Triggers {
+			if tr.Dialect != "" && tr.Dialect != a.dialect {
+				continue
+			}
+			if err := checkTrigger(tr); err != nil {
+				return nil, fmt.Errorf("trigger %q of table %q: %w", tr.Name, t.Name, err)
+			}
+			var r *routine
+			switch a.dialect {
+			case dialect.Postgres:
+				r = pgTrigger(t, tr)
+			case dialect.SQLite:
+				r = sqliteTrigger(t, tr)
+			default:
+				return nil, fmt.Errorf("triggers are not supported by dialect %q", a.dialect)
+			}
+			if err := add(r); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if a.dialect != dialect.Postgres {
+		return rs, nil
+	}
+	for _, t := range tables {
+		if t.Annotation == nil || t.Annotation.RowSecurity == nil {
+			continue
+		}
+		if err := add(pgRowSecurity(t, t.Annotation.RowSecurity)); err != nil {
+			return nil, err
+		}
+		for _, p := range t.Annotation.RowSecurity.Policies {
+			r, err := pgPolicy(t, p)
+			if err != nil {
+				return nil, fmt.Errorf("policy %q of table %q: %w", p.Name, t.Name, err)
+			}
+			if err := add(r); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return rs, nil
+}
+
+// checkTrigger validates the trigger options.
+func checkTrigger(t *entsql.TriggerOptions) error {
+	switch {
+	case t.Name == "":
+		return fmt.Errorf("missing trigger name")
+	case t.Event == "":
+		return fmt.Errorf("missing trigger event")
+	case t.Body == "":
+		return fmt.Errorf("missing trigger body")
+	}
+	switch t.Timing {
+	case entsql.TriggerBefore, entsql.TriggerAfter, entsql.TriggerInsteadOf:
+		return nil
+	default:
+		return fmt.Errorf("unknown trigger timing: %q", t.Timing)
+	}
+}
+
+func pgFunction(f *entsql.FunctionOptions) (*routine, error) {
+	switch {
+	case f.Name == "":
+		return nil, fmt.Errorf("missing function name")
+	case f.Returns == "":
+		return nil, fmt.Errorf("function %q: missing return type", f.Name)
+	case f.Body == "":
+		return nil, fmt.Errorf("function %q: missing body", f.Name)
+	case strings.Contains(f.Body, "$$"):
+		return nil, fmt.Errorf("function %q: body must not contain $$", f.Name)
+	}
+	lang := f.Lang
+	if lang == "" {
+		lang = "plpgsql"
+	}
+	b := sql.Dialect(dialect.Postgres)
+	r := &routine{kind: "function", name: f.Name}
+	r.def = b.String(func(b *sql.Builder) {
+		b.WriteString("CREATE OR REPLACE FUNCTION ").Ident(f.Name).WriteString("(" + f.Args + ")").
+			WriteString(" RETURNS " + f.Returns + " LANGUAGE " + lang + " AS $$" + f.Body + "$$")
+	})
+	r.drop = b.String(func(b *sql.Builder) {
+		b.WriteString("DROP FUNCTION IF EXISTS ").Ident(f.Name).WriteString("(" + f.Args + ")")
+	})
+	r.mark = fmt.Sprintf("%s%x", markPrefix, md5.Sum([]byte(r.def)))
+	r.comment = b.String(func(b *sql.Builder) {
+		b.WriteString("COMMENT ON FUNCTION ").Ident(f.Name).WriteString("(" + f.Args + ") IS '" + r.mark + "'")
+	})
+	return r, nil
+}
+
+func pgTrigger(t *Table, tr *entsql.TriggerOptions) *routine {
+	b := sql.Dialect(dialect.Postgres)
+	r := &routine{kind: "trigger", name: tr.Name, table: t.Name}
+	r.def = b.String(func(b *sql.Builder) {
+		b.WriteString("CREATE TRIGGER ").Ident(tr.Name).Pad().WriteString(string(tr.Timing)).Pad().WriteString(tr.Event).
+			WriteString(" ON ").Ident(t.Name).WriteString(" FOR EACH ROW ").WriteString(tr.Body)
+	})
+	r.drop = b.String(func(b *sql.Builder) {
+		b.WriteString("DROP TRIGGER IF EXISTS ").Ident(tr.Name).WriteString(" ON ").Ident(t.Name)
+	})
+	r.mark = fmt.Sprintf("%s%x", markPrefix, md5.Sum([]byte(r.def)))
+	r.comment = b.String(func(b *sql.Builder) {
+		b.WriteString("COMMENT ON TRIGGER ").Ident(tr.Name).WriteString(" ON ").Ident(t.Name).WriteString(" IS '" + r.mark + "'")
+	})
+	return r
+}
+
+// pgRowSecurity returns the routine that enables the row-level security of the table. Its mark
+// holds the FORCE state, as row-level security is a property of the table and cannot be commented.
+func pgRowSecurity(t *Table, rs *entsql.RowSecurityOptions) *routine {
+	b := sql.Dialect(dialect.Postgres)
+	r := &routine{kind: "rls", name: t.Name, table: t.Name, mark: "noforce"}
+	if rs.Force {
+		r.mark = "force"
+	}
+	r.def = b.String(func(b *sql.Builder) {
+		b.WriteString("ALTER TABLE ").Ident(t.Name).WriteString(" ENABLE ROW LEVEL SECURITY, ")
+		if !rs.Force {
+			b.WriteString("NO ")
+		}
+		b.WriteString("FORCE ROW LEVEL SECURITY")
+	})
+	r.drop = b.String(func(b *sql.Builder) {
+		b.WriteString("ALTER TABLE ").Ident(t.Name).WriteString(" DISABLE ROW LEVEL SECURITY")
+	})
+	return r
+}
+
+func pgPolicy(t *Table, p *entsql.PolicyOptions) (*routine, error) {
+	switch {
+	case p.Name == "":
+		return nil, fmt.Errorf("missing policy name")
+	case p.Using == "" && p.WithCheck == "":
+		return nil, fmt.Errorf("missing policy expression")
+	}
+	switch strings.ToUpper(p.Command) {
+	case "", "ALL", "SELECT", "INSERT", "UPDATE", "DELETE":
+	default:
+		return nil, fmt.Errorf("unknown policy command: %q", p.Command)
+	}
+	b := sql.Dialect(dialect.Postgres)
+	r := &routine{kind: "policy", name: p.Name, table: t.Name}
+	r.def = b.String(func(b *sql.Builder) {
+		b.WriteString("CREATE POLICY ").Ident(p.Name).WriteString(" ON ").Ident(t.Name)
+		if p.Command != "" {
+			b.WriteString(" FOR " + strings.ToUpper(p.Command))
+		}
+		if len(p.Roles) > 0 {
+			b.WriteString(" TO " + strings.Join(p.Roles, ", "))
+		}
+		if p.Using != "" {
+			b.WriteString(" USING (" + p.Using + ")")
+		}
+		if p.WithCheck != "" {
+			b.WriteString(" WITH CHECK (" + p.WithCheck + ")")
+		}
+	})
+	r.drop = b.String(func(b *sql.Builder) {
+		b.WriteString("DROP POLICY IF EXISTS ").Ident(p.Name).WriteString(" ON ").Ident(t.Name)
+	})
+	r.mark = fmt.Sprintf("%s%x", markPrefix, md5.Sum([]byte(r.def)))
+	r.comment = b.String(func(b *sql.Builder) {
+		b.WriteString("COMMENT ON POLICY ").Ident(p.Name).WriteString(" ON ").Ident(t.Name).WriteString(" IS '" + r.mark + "'")
+	})
+	return r, nil
+}
+
+func sqliteTrigger(t *Table, tr *entsql.TriggerOptions) *routine {
+	body := strings.TrimSpace(tr.Body)
+	if !strings.HasSuffix(body, ";") {
+		body += ";"
+	}
+	b := sql.Dialect(dialect.SQLite)
+	return &routine{
+		kind:  "trigger",
+		name:  tr.Name,
+		table: t.Name,
+		def: b.String(func(b *sql.Builder) {
+			b.WriteString("CREATE TRIGGER ").Ident(tr.Name).Pad().WriteString(string(tr.Timing)).Pad().WriteString(tr.Event).
+				WriteString(" ON ").Ident(t.Name).WriteString(" FOR EACH ROW " + sqliteMark + " BEGIN " + body + " END")
+		}),
+		drop: b.String(func(b *sql.Builder) {
+			b.WriteString("DROP TRIGGER IF EXISTS ").Ident(tr.Name)
+		}),
+	}
+}
+
+// inspectRoutines returns the routines that exist in the connected schema, keyed by their routine key.
+// For PostgreSQL, the mark field holds the comment of the routine, or the FORCE state of tables with
+// row-level security, and for SQLite, the def field holds the CREATE statement of the trigger, as it is
+// stored verbatim in the schema table, and the mark field is set if the trigger is managed by Ent.
+func (a *Atlas) inspectRoutines(ctx context.Context, conn dialect.ExecQuerier) (map[string]*routine, error) {
+	var (
+		query string
+		args  []any
+	)
+	switch a.dialect {
+	case dialect.Postgres:
+		query, args = `SELECT 'function', p.proname, '', pg_get_function_identity_arguments(p.oid), '', COALESCE(obj_description(p.oid, 'pg_proc'), '') FROM pg_proc p JOIN pg_namespace n ON n.oid = p.pronamespace WHERE n.nspname = COALESCE(NULLIF($1, ''), CURRENT_SCHEMA())
+UNION ALL
+SELECT 'trigger', t.tgname, c.relname, '', '', COALESCE(obj_description(t.oid, 'pg_trigger'), '') FROM pg_trigger t JOIN pg_class c ON c.oid = t.tgrelid JOIN pg_namespace n ON n.oid = c.relnamespace WHERE NOT t.tgisinternal AND n.nspname = COALESCE(NULLIF($1, ''), CURRENT_SCHEMA())
+UNION ALL
+SELECT 'policy', p.polname, c.relname, '', '', COALESCE(obj_description(p.oid, 'pg_policy'), '') FROM pg_policy p JOIN pg_class c ON c.oid = p.polrelid JOIN pg_namespace n ON n.oid = c.relnamespace WHERE n.nspname = COALESCE(NULLIF($1, ''), CURRENT_SCHEMA())
+UNION ALL
+SELECT 'rls', c.relname, c.relname, '', '', CASE WHEN c.relforcerowsecurity THEN 'force' ELSE 'noforce' END FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace WHERE c.relrowsecurity AND n.nspname = COALESCE(NULLIF($1, ''), CURRENT_SCHEMA())`, []any{a.schema}
+	case dialect.SQLite:
+		query = "SELECT 'trigger', `name`, `tbl_name`, '', `sql`, '' FROM `sqlite_master` WHERE `type` = 'trigger'"
+	default:
+		return nil, nil
+	}
+	rows := &sql.Rows{}
+	if err := conn.Query(ctx, query, args, rows); err != nil {
+		return nil, fmt.Errorf("query routines: %w", err)
+	}
+	defer rows.Close()
+	rs := make(map[string]*routine)
+	for rows.Next() {
+		var (
+			r     routine
+			fargs string
+		)
+		if err := rows.Scan(&r.kind, &r.name, &r.table, &fargs, &r.def, &r.mark); err != nil {
+			return nil, fmt.Errorf("scan routine: %w", err)
+		}
+		if a.dialect == dialect.SQLite {
+			if strings.Contains(r.def, sqliteMark) {
+				r.mark = markPrefix
+			}
+			r.drop = sql.Dialect(dialect.SQLite).String(func(b *sql.Builder) {
+				b.WriteString("DROP TRIGGER IF EXISTS ").Ident(r.name)
+			})
+		}
+		if a.dialect == dialect.Postgres {
+			r.drop = sql.Dialect(dialect.Postgres).String(func(b *sql.Builder) {
+				switch r.kind {
+				case "function":
+					b.WriteString("DROP FUNCTION IF EXISTS ").Ident(r.name).WriteString("(" + fargs + ")")
+				case "trigger":
+					b.WriteString("DROP TRIGGER IF EXISTS ").Ident(r.name).WriteString(" ON ").Ident(r.table)
+				case "policy":
+					b.WriteString("DROP POLICY IF EXISTS ").Ident(r.name).WriteString(" ON ").Ident(r.table)
+				}
+			})
+		}
+		rs[r.key()] = &r
+	}
+	return rs, rows.Err()
+}
+
+// planRoutines appends to the plan the changes for creating the desired routines that do not exist in the
+// current routines, and replacing the ones whose definitions were changed. Routines that were created by
+// previous migrations and are no longer defined by the schema are dropped, even if the schema no longer
+// defines any. Note that row-level security is never disabled by the migration, as it cannot be marked as
+// managed by Ent. If no current routines are given (e.g. a dump), all routines are created.
+func (a *Atlas) planRoutines(plan *migrate.Plan, tables []*Table, current map[string]*routine) (*migrate.Plan, error) {
+	desired, err := a.routines(tables)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		creates []*migrate.Change
+		keys    = make(map[string]bool, len(desired))
+	)
+	for _, r := range desired {
+		keys[r.key()] = true
+		switch cur, ok := current[r.key()]; {
+		case !ok:
+			creates = append(creates, r.changes(r.describe("Create"), false)...)
+		case a.dialect == dialect.Postgres && cur.mark != r.mark, a.dialect == dialect.SQLite && cur.def != r.def:
+			creates = append(creates, r.changes(r.describe("Replace"), true)...)
+		}
+	}
+	// Routines that are not marked as managed by Ent are never dropped. Triggers and
+	// policies are dropped before the functions, as they might depend on them.
+	var drops []*routine
+	for k, r := range current {
+		if !keys[k] && strings.HasPrefix(r.mark, markPrefix) {
+			drops = append(drops, r)
+		}
+	}
+	slices.SortFunc(drops, func(r1, r2 *routine) int {
+		if r1.kind != r2.kind {
+			return -strings.Compare(r1.kind, r2.kind)
+		}
+		return strings.Compare(r1.key(), r2.key())
+	})
+	plan.Changes = append(plan.Changes, creates...)
+	for _, r := range drops {
+		plan.Changes = append(plan.Changes, &migrate.Change{Cmd: r.drop, Comment: r.describe("Drop")})
+	}
+	return plan, nil
+}
+
+// changes returns the changes for creating the routine, or replacing it.
+func (r *routine) changes(comment string, replace bool) []*migrate.Change {
+	var cs []*migrate.Change
+	// Functions are replaced using CREATE OR REPLACE, and the
+	// row-level security of tables is altered in place.
+	if replace && (r.kind == "trigger" || r.kind == "policy") {
+		cs = append(cs, &migrate.Change{Cmd: r.drop})
+	}
+	cs = append(cs, &migrate.Change{Cmd: r.def, Reverse: r.drop})
+	if r.comment != "" {
+		cs = append(cs, &migrate.Change{Cmd: r.comment})
+	}
+	cs[0].Comment = comment
+	return cs
+}
@@ -0,0 +1,276 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"text/template"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/field"
+
+	"ariga.io/atlas/sql/migrate"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func postsTable(ants ...*entsql.Annotation) *Table {
+	t := NewTable("posts").
+		AddPrimary(&Column{Name: "id", Type: field.TypeInt, Increment: true}).
+		AddColumn(&Column{Name: "title", Type: field.TypeString}).
+		AddColumn(&Column{Name: "updated_at", Type: field.TypeTime, Nullable: true})
+	ant := &entsql.Annotation{}
+	for _, a := range ants {
+		*ant = ant.Merge(a).(entsql.Annotation)
+	}
+	return t.SetAnnotation(ant)
+}
+
+func TestDump_Routines(t *testing.T) {
+	ctx := context.Background()
+	posts := postsTable(
+		entsql.Function("set_updated_at", "trigger", "BEGIN NEW.updated_at = now(); RETURN NEW; END"),
+		entsql.TriggerFor(dialect.Postgres, "posts_updated_at", entsql.TriggerBefore, "UPDATE", "EXECUTE FUNCTION set_updated_at()"),
+		entsql.TriggerFor(dialect.SQLite, "posts_updated_at", entsql.TriggerAfter, "UPDATE OF title", "UPDATE posts SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id"),
+	)
+	ac, err := Dump(ctx, dialect.Postgres, "15", []*Table{posts})
+	require.NoError(t, err)
+	require.Contains(t, ac, `-- Create "set_updated_at" function
+CREATE OR REPLACE FUNCTION "set_updated_at"() RETURNS trigger LANGUAGE plpgsql AS $$BEGIN NEW.updated_at = now(); RETURN NEW; END$$;
+COMMENT ON FUNCTION "set_updated_at"() IS 'ent:`)
+	require.Contains(t, ac, `-- Create "posts_updated_at" trigger
+CREATE TRIGGER "posts_updated_at" BEFORE UPDATE ON "posts" FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+COMMENT ON TRIGGER "posts_updated_at" ON "posts" IS 'ent:`)
+
+	ac, err = Dump(ctx, dialect.SQLite, "", []*Table{posts})
+	require.NoError(t, err)
+	require.NotContains(t, ac, "FUNCTION")
+	require.Contains(t, ac, "-- Create \"posts_updated_at\" trigger\nCREATE TRIGGER `posts_updated_at` AFTER UPDATE OF title ON `posts` FOR EACH ROW /* ent: */ BEGIN UPDATE posts SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id; END;\n")
+
+	_, err = Dump(ctx, dialect.MySQL, "8", []*Table{postsTable(entsql.Trigger("t", entsql.TriggerAfter, "INSERT", "SET @x = 1"))})
+	require.EqualError(t, err, `triggers are not supported by dialect "mysql"`)
+	_, err = Dump(ctx, dialect.SQLite, "", []*Table{postsTable(entsql.Trigger("t", "DURING", "INSERT", "SELECT 1"))})
+	require.EqualError(t, err, `trigger "t" of table "posts": unknown trigger timing: "DURING"`)
+	_, err = Dump(ctx, dialect.Postgres, "15", []*Table{postsTable(
+		entsql.Function("f", "trigger", "BEGIN RETURN NEW; END"),
+	), func() *Table {
+		t := postsTable(entsql.Function("f", "trigger", "BEGIN RETURN OLD; END"))
+		t.Name = "comments"
+		return t
+	}()})
+	require.EqualError(t, err, `function "f" is defined with different definitions`)
+}
+
+func TestAtlas_RoutinesSQLite(t *testing.T) {
+	ctx := context.Background()
+	drv, err := sql.Open(dialect.SQLite, "file:routines?mode=memory&_fk=1")
+	require.NoError(t, err)
+	defer drv.Close()
+	m, err := NewMigrate(drv)
+	require.NoError(t, err)
+	posts := postsTable(entsql.Trigger("posts_updated_at", entsql.TriggerAfter, "UPDATE OF title", "UPDATE posts SET updated_at = '2025-01-01' WHERE id = NEW.id"))
+	require.NoError(t, m.Create(ctx, posts))
+	_, err = drv.ExecContext(ctx, "INSERT INTO posts (title) VALUES ('a'); UPDATE posts SET title = 'b'")
+	require.NoError(t, err)
+	var v string
+	require.NoError(t, drv.DB().QueryRowContext(ctx, "SELECT CAST(updated_at AS TEXT) FROM posts").Scan(&v))
+	require.Equal(t, "2025-01-01", v)
+
+	// No changes.
+	p := t.TempDir()
+	d, err := migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	m, err = NewMigrate(drv, WithDir(d), WithErrNoPlan(true))
+	require.NoError(t, err)
+	require.ErrorIs(t, m.NamedDiff(ctx, "no_changes", posts), migrate.ErrNoPlan)
+
+	// Replace the trigger.
+	posts = postsTable(entsql.Trigger("posts_updated_at", entsql.TriggerAfter, "UPDATE OF title", "UPDATE posts SET updated_at = '2025-02-01' WHERE id = NEW.id"))
+	require.NoError(t, m.NamedDiff(ctx, "replace", posts))
+	v1 := time.Now().UTC().Format("20060102150405")
+	requireFileEqual(t, filepath.Join(p, v1+"_replace.up.sql"), "-- Replace \"posts_updated_at\" trigger\nDROP TRIGGER IF EXISTS `posts_updated_at`;\nCREATE TRIGGER `posts_updated_at` AFTER UPDATE OF title ON `posts` FOR EACH ROW /* ent: */ BEGIN UPDATE posts SET updated_at = '2025-02-01' WHERE id = NEW.id; END;\n")
+	require.NoError(t, m.Create(ctx, posts))
+	_, err = drv.ExecContext(ctx, "UPDATE posts SET title = 'c'")
+	require.NoError(t, err)
+	require.NoError(t, drv.DB().QueryRowContext(ctx, "SELECT CAST(updated_at AS TEXT) FROM posts").Scan(&v))
+	require.Equal(t, "2025-02-01", v)
+}
+
+func TestAtlas_RoutinesPostgres(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	a := &Atlas{dialect: dialect.Postgres}
+	posts := postsTable(
+		entsql.Function("set_updated_at", "trigger", "BEGIN NEW.updated_at = now(); RETURN NEW; END"),
+		entsql.Trigger("posts_updated_at", entsql.TriggerBefore, "UPDATE", "EXECUTE FUNCTION set_updated_at()"),
+	)
+	desired, err := a.routines([]*Table{posts})
+	require.NoError(t, err)
+	require.Len(t, desired, 2)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT 'function', p.proname")).
+		WithArgs("").
+		WillReturnRows(sqlmock.NewRows([]string{"kind", "name", "table", "args", "def", "comment"}).
+			// Unchanged function.
+			AddRow("function", "set_updated_at", "", "", "", desired[0].mark).
+			// Changed trigger.
+			AddRow("trigger", "posts_updated_at", "posts", "", "", "ent:1").
+			// Deleted function and trigger that were managed by Ent.
+			AddRow("function", "old", "", "a integer", "", "ent:2").
+			AddRow("trigger", "posts_old", "posts", "", "", "ent:3").
+			// Unmanaged function.
+			AddRow("function", "unmanaged", "", "", "", ""))
+	current, err := a.inspectRoutines(ctx, sql.OpenDB(dialect.Postgres, db))
+	require.NoError(t, err)
+	plan, err := a.planRoutines(&migrate.Plan{}, []*Table{posts}, current)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	cmds := make([]string, len(plan.Changes))
+	for i, c := range plan.Changes {
+		cmds[i] = c.Cmd
+	}
+	require.Equal(t, []string{
+		`DROP TRIGGER IF EXISTS "posts_updated_at" ON "posts"`,
+		`CREATE TRIGGER "posts_updated_at" BEFORE UPDATE ON "posts" FOR EACH ROW EXECUTE FUNCTION set_updated_at()`,
+		desired[1].comment,
+		`DROP TRIGGER IF EXISTS "posts_old" ON "posts"`,
+		`DROP FUNCTION IF EXISTS "old"(a integer)`,
+	}, cmds)
+	require.Equal(t, `Replace "posts_updated_at" trigger`, plan.Changes[0].Comment)
+
+	// Routines are dropped even if the schema no longer defines any.
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT 'function', p.proname")).
+		WithArgs("").
+		WillReturnRows(sqlmock.NewRows([]string{"kind", "name", "table", "args", "def", "comment"}).
+			AddRow("function", "set_updated_at", "", "", "", desired[0].mark).
+			AddRow("function", "unmanaged", "", "", "", ""))
+	current, err = a.inspectRoutines(ctx, sql.OpenDB(dialect.Postgres, db))
+	require.NoError(t, err)
+	plan, err = a.planRoutines(&migrate.Plan{}, []*Table{postsTable()}, current)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `DROP FUNCTION IF EXISTS "set_updated_at"()`, plan.Changes[0].Cmd)
+	require.Equal(t, `Drop "set_updated_at" function`, plan.Changes[0].Comment)
+}
+
+func TestDump_RowSecurity(t *testing.T) {
+	ctx := context.Background()
+	posts := postsTable(
+		entsql.Function("current_author", "integer", "BEGIN RETURN current_setting('app.author')::integer; END"),
+		entsql.RowSecurity(
+			entsql.Policy("posts_read", "true"),
+			&entsql.PolicyOptions{Name: "posts_write", Command: "update", Roles: []string{"app"}, Using: "author_id = current_author()", WithCheck: "author_id = current_author()"},
+		),
+	)
+	ac, err := Dump(ctx, dialect.Postgres, "15", []*Table{posts})
+	require.NoError(t, err)
+	require.Contains(t, ac, `-- Create "current_author" function
+CREATE OR REPLACE FUNCTION "current_author"() RETURNS integer LANGUAGE plpgsql AS $$BEGIN RETURN current_setting('app.author')::integer; END$$;
+COMMENT ON FUNCTION "current_author"() IS 'ent:`)
+	require.Contains(t, ac, `-- Enable row-level security on "posts"
+ALTER TABLE "posts" ENABLE ROW LEVEL SECURITY, NO FORCE ROW LEVEL SECURITY;
+-- Create "posts_read" policy
+CREATE POLICY "posts_read" ON "posts" USING (true);
+COMMENT ON POLICY "posts_read" ON "posts" IS 'ent:`)
+	require.Contains(t, ac, `-- Create "posts_write" policy
+CREATE POLICY "posts_write" ON "posts" FOR UPDATE TO app USING (author_id = current_author()) WITH CHECK (author_id = current_author());
+COMMENT ON POLICY "posts_write" ON "posts" IS 'ent:`)
+
+	// Row-level security is ignored by other dialects.
+	ac, err = Dump(ctx, dialect.SQLite, "", []*Table{posts})
+	require.NoError(t, err)
+	require.NotContains(t, ac, "POLICY")
+
+	_, err = Dump(ctx, dialect.Postgres, "15", []*Table{postsTable(entsql.RowSecurity(&entsql.PolicyOptions{Name: "p", Command: "MERGE", Using: "true"}))})
+	require.EqualError(t, err, `policy "p" of table "posts": unknown policy command: "MERGE"`)
+	_, err = Dump(ctx, dialect.Postgres, "15", []*Table{postsTable(entsql.RowSecurity(&entsql.PolicyOptions{Name: "p"}))})
+	require.EqualError(t, err, `policy "p" of table "posts": missing policy expression`)
+}
+
+func TestAtlas_RowSecurityPostgres(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	a := &Atlas{dialect: dialect.Postgres}
+	posts := postsTable(
+		&entsql.Annotation{RowSecurity: &entsql.RowSecurityOptions{Force: true}},
+		entsql.RowSecurity(
+			entsql.Policy("posts_read", "true"),
+			entsql.Policy("posts_write", "author_id = current_setting('app.author')::integer"),
+		),
+	)
+	desired, err := a.routines([]*Table{posts})
+	require.NoError(t, err)
+	require.Len(t, desired, 3)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT 'function', p.proname")).
+		WithArgs("").
+		WillReturnRows(sqlmock.NewRows([]string{"kind", "name", "table", "args", "def", "comment"}).
+			// Row-level security is enabled, but not forced.
+			AddRow("rls", "posts", "posts", "", "", "noforce").
+			// Unchanged policy.
+			AddRow("policy", "posts_read", "posts", "", "", desired[1].mark).
+			// Deleted policy that was managed by Ent, and an unmanaged one.
+			AddRow("policy", "posts_old", "posts", "", "", "ent:1").
+			AddRow("policy", "unmanaged", "posts", "", "", ""))
+	current, err := a.inspectRoutines(ctx, sql.OpenDB(dialect.Postgres, db))
+	require.NoError(t, err)
+	plan, err := a.planRoutines(&migrate.Plan{}, []*Table{posts}, current)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	cmds := make([]string, len(plan.Changes))
+	for i, c := range plan.Changes {
+		cmds[i] = c.Cmd
+	}
+	require.Equal(t, []string{
+		`ALTER TABLE "posts" ENABLE ROW LEVEL SECURITY, FORCE ROW LEVEL SECURITY`,
+		`CREATE POLICY "posts_write" ON "posts" USING (author_id = current_setting('app.author')::integer)`,
+		desired[2].comment,
+		`DROP POLICY IF EXISTS "posts_old" ON "posts"`,
+	}, cmds)
+	require.Equal(t, `Replace row-level security on "posts"`, plan.Changes[0].Comment)
+	require.Equal(t, `ALTER TABLE "posts" DISABLE ROW LEVEL SECURITY`, plan.Changes[0].Reverse)
+	require.Equal(t, `Drop "posts_old" policy`, plan.Changes[3].Comment)
+}
+
+func TestAtlas_RoutinesReplay(t *testing.T) {
+	ctx := context.Background()
+	drv, err := sql.Open(dialect.SQLite, "file:routines_replay?mode=memory&_fk=1")
+	require.NoError(t, err)
+	defer drv.Close()
+	p := t.TempDir()
+	d, err := migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	f, err := migrate.NewTemplateFormatter(
+		template.Must(template.New("").Parse("{{ .Name }}.sql")),
+		template.Must(template.New("").Parse(`{{ range .Changes }}{{ printf "%s;\n" .Cmd }}{{ end }}`)),
+	)
+	require.NoError(t, err)
+	m, err := NewMigrate(drv, WithDir(d), WithFormatter(f), WithMigrationMode(ModeReplay), WithErrNoPlan(true))
+	require.NoError(t, err)
+	posts := postsTable(entsql.Trigger("posts_updated_at", entsql.TriggerAfter, "UPDATE OF title", "UPDATE posts SET updated_at = '2025-01-01' WHERE id = NEW.id"))
+	require.NoError(t, m.NamedDiff(ctx, "1_create", posts))
+	requireFileEqual(t, filepath.Join(p, "1_create.sql"), "CREATE TABLE `posts` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `title` text NOT NULL, `updated_at` datetime NULL);\nCREATE TRIGGER `posts_updated_at` AFTER UPDATE OF title ON `posts` FOR EACH ROW /* ent: */ BEGIN UPDATE posts SET updated_at = '2025-01-01' WHERE id = NEW.id; END;\n")
+
+	// The triggers that were created by the migration directory are not planned again.
+	require.ErrorIs(t, m.NamedDiff(ctx, "2_no_changes", posts), migrate.ErrNoPlan)
+
+	// Triggers that were removed from the schema are dropped,
+	// and triggers that are not managed by Ent are kept.
+	require.NoError(t, os.WriteFile(filepath.Join(p, "2_unmanaged.sql"), []byte("CREATE TRIGGER `posts_unmanaged` AFTER INSERT ON `posts` BEGIN SELECT 1; END;\n"), 0644))
+	sum, err := d.Checksum()
+	require.NoError(t, err)
+	require.NoError(t, migrate.WriteSumFile(d, sum))
+	require.NoError(t, m.NamedDiff(ctx, "3_drop", postsTable()))
+	requireFileEqual(t, filepath.Join(p, "3_drop.sql"), "DROP TRIGGER IF EXISTS `posts_updated_at`;\n")
+	require.ErrorIs(t, m.NamedDiff(ctx, "4_no_changes", postsTable()), migrate.ErrNoPlan)
+}
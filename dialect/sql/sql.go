@@ -410,16 +410,11 @@ func OrderByRand() func(*Selector) {
 // This is used by the generated code.
 func (f *OrderFieldTerm) ToFunc() func(*Selector) {
 	return func(s *Selector) {
-		s.OrderExprFunc(func(b *Builder) {
-			b.WriteString(s.C(f.Field))
-			if f.Desc {
-				b.WriteString(" DESC")
-			}
-			if f.NullsFirst {
-				b.WriteString(" NULLS FIRST")
-			} else if f.NullsLast {
-				b.WriteString(" NULLS LAST")
-			}
+		s.OrderByKey(&OrderKey{
+			Expr:       Expr(s.C(f.Field)),
+			Desc:       f.Desc,
+			NullsFirst: f.NullsFirst,
+			NullsLast:  f.NullsLast,
 		})
 	}
 }
@@ -0,0 +1,229 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlgraph
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+)
+
+// CursorColumn returns the name of the column that holds the value of
+// the i-th ORDER BY term in queries that were passed to KeysetAfter.
+func CursorColumn(i int) string {
+	return "ent_cursor_" + strconv.Itoa(i)
+}
+
+// KeysetAfter appends the terms of the ORDER BY clause of the selector to its selection
+// (named using CursorColumn), and if values are provided, limits the result to the rows
+// that are sorted after them. The values are usually decoded from a cursor that was
+// created from the selected terms of the last row of the previous page. Hence, it should
+// be called after the ordering was applied, and the ordering should be total. All terms
+// must be added using Selector.OrderByKey, as the generated ordering options do.
+//
+// The comparison of each term respects its direction and the position of NULL values,
+// which are sorted first on ascending order in MySQL and SQLite, and last in PostgreSQL,
+// unless NULLS FIRST or NULLS LAST were set explicitly.
+func KeysetAfter(s *sql.Selector, values []any) {
+	keys, err := s.OrderKeys()
+	switch {
+	case err != nil:
+		s.AddError(fmt.Errorf("sqlgraph: keyset pagination: %w", err))
+		return
+	case len(keys) == 0:
+		s.AddError(errors.New("sqlgraph: keyset pagination requires the query to be ordered"))
+		return
+	}
+	for i, k := range keys {
+		s.AppendSelectExprAs(k.Expr, CursorColumn(i))
+	}
+	if values == nil {
+		return
+	}
+	if len(values) != len(keys) {
+		s.AddError(fmt.Errorf("sqlgraph: cursor has %d values, but the query is ordered by %d terms", len(values), len(keys)))
+		return
+	}
+	ors := make([]*sql.Predicate, 0, len(keys))
+	for i, k := range keys {
+		after := keyAfter(s.Dialect(), k, values[i])
+		if after == nil {
+			continue
+		}
+		ands := make([]*sql.Predicate, 0, i+1)
+		for j := 0; j < i; j++ {
+			ands = append(ands, keyEQ(keys[j], values[j]))
+		}
+		ors = append(ors, sql.And(append(ands, after)...))
+	}
+	if len(ors) == 0 {
+		s.Where(sql.False())
+	} else {
+		s.Where(sql.Or(ors...))
+	}
+}
+
+// keyEQ returns a predicate that matches the rows with the same value of the key.
+func keyEQ(k *sql.OrderKey, v any) *sql.Predicate {
+	return sql.P(func(b *sql.Builder) {
+		b.Join(k.Expr)
+		if v == nil {
+			b.WriteOp(sql.OpIsNull)
+		} else {
+			b.WriteOp(sql.OpEQ).Arg(v)
+		}
+	})
+}
+
+// keyAfter returns a predicate that matches the rows that are sorted after
+// the given value of the key, or nil if no such rows can exist.
+func keyAfter(d string, k *sql.OrderKey, v any) *sql.Predicate {
+	// NULL values are sorted as larger than any other
+	// value in PostgreSQL, and as smaller in the rest.
+	nullsLast := k.NullsLast || !k.NullsFirst && (d == dialect.Postgres) != k.Desc
+	switch {
+	case v == nil && nullsLast:
+		return nil
+	case v == nil:
+		return sql.P(func(b *sql.Builder) {
+			b.Join(k.Expr).WriteOp(sql.OpNotNull)
+		})
+	}
+	op := sql.OpGT
+	if k.Desc {
+		op = sql.OpLT
+	}
+	p := sql.P(func(b *sql.Builder) {
+		b.Join(k.Expr).WriteOp(op).Arg(v)
+	})
+	if nullsLast {
+		p = sql.Or(p, sql.P(func(b *sql.Builder) {
+			b.Join(k.Expr).WriteOp(sql.OpIsNull)
+		}))
+	}
+	return p
+}
+
+// cursorValue is the encoded representation of a single value in a cursor.
+type cursorValue struct {
+	T string          `json:"t"`
+	V json.RawMessage `json:"v,omitempty"`
+}
+
+// EncodeCursor encodes the given values into an opaque cursor. The types of the
+// values are preserved, so they can be used as query arguments after decoding.
+func EncodeCursor(values []any) (string, error) {
+	cv := make([]cursorValue, len(values))
+	for i, v := range values {
+		var (
+			err error
+			c   = &cv[i]
+		)
+		switch v := v.(type) {
+		case nil:
+			c.T = "n"
+		case bool:
+			c.T, c.V = "b", strconv.AppendBool(nil, v)
+		case int:
+			c.T, c.V = "i", strconv.AppendInt(nil, int64(v), 10)
+		case int8:
+			c.T, c.V = "i", strconv.AppendInt(nil, int64(v), 10)
+		case int16:
+			c.T, c.V = "i", strconv.AppendInt(nil, int64(v), 10)
+		case int32:
+			c.T, c.V = "i", strconv.AppendInt(nil, int64(v), 10)
+		case int64:
+			c.T, c.V = "i", strconv.AppendInt(nil, v, 10)
+		case uint:
+			c.T, c.V = "u", strconv.AppendUint(nil, uint64(v), 10)
+		case uint8:
+			c.T, c.V = "u", strconv.AppendUint(nil, uint64(v), 10)
+		case uint16:
+			c.T, c.V = "u", strconv.AppendUint(nil, uint64(v), 10)
+		case uint32:
+			c.T, c.V = "u", strconv.AppendUint(nil, uint64(v), 10)
+		case uint64:
+			c.T, c.V = "u", strconv.AppendUint(nil, v, 10)
+		case float32:
+			c.T = "f"
+			c.V, err = json.Marshal(float64(v))
+		case float64:
+			c.T = "f"
+			c.V, err = json.Marshal(v)
+		case string:
+			c.T = "s"
+			c.V, err = json.Marshal(v)
+		case []byte:
+			c.T = "x"
+			c.V, err = json.Marshal(v)
+		case time.Time:
+			c.T = "t"
+			c.V, err = json.Marshal(v.Format(time.RFC3339Nano))
+		default:
+			err = fmt.Errorf("unsupported cursor value type %T", v)
+		}
+		if err != nil {
+			return "", fmt.Errorf("sqlgraph: encoding cursor: %w", err)
+		}
+	}
+	buf, err := json.Marshal(cv)
+	if err != nil {
+		return "", fmt.Errorf("sqlgraph: encoding cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// DecodeCursor decodes the values of the given cursor that was created by EncodeCursor.
+func DecodeCursor(s string) ([]any, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("sqlgraph: decoding cursor: %w", err)
+	}
+	var cv []cursorValue
+	if err := json.Unmarshal(buf, &cv); err != nil {
+		return nil, fmt.Errorf("sqlgraph: decoding cursor: %w", err)
+	}
+	values := make([]any, len(cv))
+	for i, c := range cv {
+		switch c.T {
+		case "n":
+		case "b":
+			values[i], err = strconv.ParseBool(string(c.V))
+		case "i":
+			values[i], err = strconv.ParseInt(string(c.V), 10, 64)
+		case "u":
+			values[i], err = strconv.ParseUint(string(c.V), 10, 64)
+		case "f":
+			var f float64
+			err = json.Unmarshal(c.V, &f)
+			values[i] = f
+		case "s":
+			var s string
+			err = json.Unmarshal(c.V, &s)
+			values[i] = s
+		case "x":
+			var b []byte
+			err = json.Unmarshal(c.V, &b)
+			values[i] = b
+		case "t":
+			var s string
+			if err = json.Unmarshal(c.V, &s); err == nil {
+				values[i], err = time.Parse(time.RFC3339Nano, s)
+			}
+		default:
+			err = fmt.Errorf("unknown cursor value type %q", c.T)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("sqlgraph: decoding cursor: %w", err)
+		}
+	}
+	return values, nil
+}
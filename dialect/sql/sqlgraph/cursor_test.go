@@ -0,0 +1,70 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlgraph
+
+import (
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeysetAfter(t *testing.T) {
+	t.Run("MySQL", func(t *testing.T) {
+		s := sql.Dialect(dialect.MySQL).Select("name").From(sql.Table("users"))
+		sql.OrderByField("name", sql.OrderDesc()).ToFunc()(s)
+		sql.OrderByField("id").ToFunc()(s)
+		KeysetAfter(s, []any{"a8m", 1})
+		query, args := s.Query()
+		require.Equal(t, "SELECT `name`, (`users`.`name`) AS `ent_cursor_0`, (`users`.`id`) AS `ent_cursor_1` FROM `users` WHERE `users`.`name` < ? OR `users`.`name` IS NULL OR (`users`.`name` = ? AND `users`.`id` > ?) ORDER BY `users`.`name` DESC, `users`.`id`", query)
+		require.Equal(t, []any{"a8m", "a8m", 1}, args)
+	})
+	t.Run("Postgres", func(t *testing.T) {
+		s := sql.Dialect(dialect.Postgres).Select("name").From(sql.Table("users"))
+		sql.OrderByField("age").ToFunc()(s)
+		sql.OrderByField("name", sql.OrderNullsFirst()).ToFunc()(s)
+		sql.OrderByField("id").ToFunc()(s)
+		KeysetAfter(s, []any{nil, nil, 1})
+		query, args := s.Query()
+		require.Equal(t, `SELECT "name", ("users"."age") AS "ent_cursor_0", ("users"."name") AS "ent_cursor_1", ("users"."id") AS "ent_cursor_2" FROM "users" WHERE ("users"."age" IS NULL AND "users"."name" IS NOT NULL) OR ("users"."age" IS NULL AND "users"."name" IS NULL AND ("users"."id" > $1 OR "users"."id" IS NULL)) ORDER BY "users"."age", "users"."name" NULLS FIRST, "users"."id"`, query)
+		require.Equal(t, []any{1}, args)
+	})
+	t.Run("NoOrder", func(t *testing.T) {
+		s := sql.Select("name").From(sql.Table("users"))
+		KeysetAfter(s, nil)
+		require.Error(t, s.Err())
+	})
+	t.Run("RawOrder", func(t *testing.T) {
+		s := sql.Select("name").From(sql.Table("users")).OrderBy(sql.Desc("name"))
+		KeysetAfter(s, nil)
+		require.Error(t, s.Err())
+	})
+	t.Run("Mismatch", func(t *testing.T) {
+		s := sql.Select("name").From(sql.Table("users"))
+		sql.OrderByField("id").ToFunc()(s)
+		KeysetAfter(s, []any{1, 2})
+		require.Error(t, s.Err())
+	})
+}
+
+func TestCursor(t *testing.T) {
+	now := time.Now().Round(0)
+	values := []any{nil, true, int64(-1), uint64(1 << 63), 1.5, "a8m", []byte("ent"), now}
+	c, err := EncodeCursor(values)
+	require.NoError(t, err)
+	decoded, err := DecodeCursor(c)
+	require.NoError(t, err)
+	require.Len(t, decoded, len(values))
+	require.Equal(t, values[:7], decoded[:7])
+	require.True(t, now.Equal(decoded[7].(time.Time)))
+
+	_, err = EncodeCursor([]any{struct{}{}})
+	require.Error(t, err)
+	_, err = DecodeCursor("invalid")
+	require.Error(t, err)
+}
@@ -6,9 +6,11 @@ package sqlgraph
 
 import (
 	"fmt"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/entql"
+	"entgo.io/ent/schema/field"
 )
 
 type (
@@ -228,10 +230,10 @@ func (e *state) evalBinary(expr *entql.BinaryExpr) *sql.Predicate {
 		case *entql.Field:
 			return sql.ColumnsOp(e.field(field), e.field(x), binary[expr.Op])
 		case *entql.Value:
-			c := e.field(field)
+			c, v := e.field(field), e.value(field, x)
 			return sql.P(func(b *sql.Builder) {
 				b.Ident(c).WriteOp(binary[expr.Op])
-				args(b, x)
+				args(b, v)
 			})
 		default:
 			panic("unreachable")
@@ -299,6 +301,30 @@ func (e *state) field(f *entql.Field) string {
 	return e.selector.C(f.Name)
 }
 
+// value returns the value to be compared with the given field. Durations
+// are wrapped with sql.Duration, as they are not stored as integers in all
+// dialects (e.g. interval in PostgreSQL).
+func (e *state) value(f *entql.Field, v *entql.Value) *entql.Value {
+	if fs, ok := e.context.Fields[f.Name]; !ok || fs.Type != field.TypeDuration {
+		return v
+	}
+	wrap := func(v any) any {
+		if n, ok := v.(int64); ok {
+			return sql.Duration(time.Duration(n))
+		}
+		return v
+	}
+	vs, ok := v.V.([]any)
+	if !ok {
+		return &entql.Value{V: wrap(v.V)}
+	}
+	ws := make([]any, len(vs))
+	for i := range vs {
+		ws[i] = wrap(vs[i])
+	}
+	return &entql.Value{V: ws}
+}
+
 func args(b *sql.Builder, v *entql.Value) {
 	vs, ok := v.V.([]any)
 	if !ok {
@@ -7,6 +7,7 @@ package sqlgraph
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
@@ -39,8 +40,9 @@ func TestGraph_EvalP(t *testing.T) {
 					ID:    &FieldSpec{Column: "uid"},
 				},
 				Fields: map[string]*FieldSpec{
-					"name": {Column: "name", Type: field.TypeString},
-					"last": {Column: "last", Type: field.TypeString},
+					"name":    {Column: "name", Type: field.TypeString},
+					"last":    {Column: "last", Type: field.TypeString},
+					"timeout": {Column: "timeout", Type: field.TypeDuration},
 				},
 			},
 			{
@@ -123,6 +125,18 @@ func TestGraph_EvalP(t *testing.T) {
 			wantQuery: `SELECT * FROM "users" WHERE "foo" = $1 AND ("users"."name" = $2 OR "users"."name" = $3)`,
 			wantArgs:  []any{"bar", "foo", "baz"},
 		},
+		{
+			s:         sql.Dialect(dialect.Postgres).Select().From(sql.Table("users")),
+			p:         entql.Int64GT(int64(time.Minute)).Field("timeout"),
+			wantQuery: `SELECT * FROM "users" WHERE "users"."timeout" > $1`,
+			wantArgs:  []any{"00:01:00"},
+		},
+		{
+			s:         sql.Dialect(dialect.SQLite).Select().From(sql.Table("users")),
+			p:         entql.Int64GT(int64(time.Minute)).Field("timeout"),
+			wantQuery: "SELECT * FROM `users` WHERE `users`.`timeout` > ?",
+			wantArgs:  []any{int64(time.Minute)},
+		},
 		{
 			s:         sql.Dialect(dialect.Postgres).Select().From(sql.Table("users")),
 			p:         entql.HasEdge("pets"),
@@ -51,3 +51,64 @@ func IsForeignKeyConstraintError(err error) bool {
 	}
 	return false
 }
+
+// IsSerializationError reports if the error resulted from a serialization failure, and the
+// transaction that caused it can be retried. e.g. concurrent update in a serializable transaction.
+func IsSerializationError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if hasSQLState(err, "40001") {
+		return true
+	}
+	for _, s := range []string{
+		"could not serialize access", // Postgres
+		"(SQLSTATE 40001)",           // Postgres (pgx)
+		"database is locked",         // SQLite (SQLITE_BUSY)
+	} {
+		if strings.Contains(err.Error(), s) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDeadlockError reports if the error resulted from a deadlock that was detected by the database,
+// and the transaction that was chosen as the victim can be retried.
+func IsDeadlockError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if hasSQLState(err, "40P01") {
+		return true
+	}
+	for _, s := range []string{
+		"Error 1213",        // MySQL (Deadlock found when trying to get lock).
+		"deadlock detected", // Postgres
+		"(SQLSTATE 40P01)",  // Postgres (pgx)
+	} {
+		if strings.Contains(err.Error(), s) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRetryableError reports if the error resulted from a transient transaction failure,
+// and the transaction that caused it can be safely retried from the beginning.
+func IsRetryableError(err error) bool {
+	return IsSerializationError(err) || IsDeadlockError(err)
+}
+
+// hasSQLState reports if one of the errors in the chain of err exposes the given SQLSTATE code.
+func hasSQLState(err error, code string) bool {
+	var e interface{ SQLState() string }
+	return errors.As(err, &e) && e.SQLState() == code
+}
+
+// IsStaleObjectError reports if the error resulted from updating an entity
+// with optimistic locking, and its version was changed concurrently.
+func IsStaleObjectError(err error) bool {
+	var e *StaleObjectError
+	return errors.As(err, &e)
+}
@@ -0,0 +1,45 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlgraph
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type stateError string
+
+func (e stateError) Error() string    { return "state error" }
+func (e stateError) SQLState() string { return string(e) }
+
+func TestIsRetryableError(t *testing.T) {
+	for _, err := range []error{
+		errors.New("pq: could not serialize access due to concurrent update"),
+		errors.New("ERROR: could not serialize access due to read/write dependencies among transactions (SQLSTATE 40001)"),
+		fmt.Errorf("wrapped: %w", stateError("40001")),
+	} {
+		require.True(t, IsSerializationError(err), err)
+		require.False(t, IsDeadlockError(err), err)
+		require.True(t, IsRetryableError(err), err)
+	}
+	for _, err := range []error{
+		errors.New("Error 1213 (40001): Deadlock found when trying to get lock; try restarting transaction"),
+		errors.New("pq: deadlock detected"),
+		fmt.Errorf("wrapped: %w", stateError("40P01")),
+	} {
+		require.True(t, IsDeadlockError(err), err)
+		require.True(t, IsRetryableError(err), err)
+	}
+	for _, err := range []error{
+		nil,
+		errors.New("Error 1062: Duplicate entry"),
+		stateError("23505"),
+	} {
+		require.False(t, IsRetryableError(err), err)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
 	"math"
 	"sort"
 
@@ -395,7 +396,7 @@ func OrderByNeighborsCount(q *sql.Selector, s *Step, opts ...sql.OrderTermOption
 				b.WriteOp(sql.OpIsNull)
 			}
 		}
-		q.OrderExpr(build.Expr(x))
+		q.OrderByKey(&sql.OrderKey{Expr: build.Expr(x)})
 	case s.ThroughEdgeTable():
 		countAs := countAlias(q, s, opt)
 		terms := []sql.OrderTerm{
@@ -473,30 +474,20 @@ func orderTerms(q, join *sql.Selector, ts []sql.OrderTerm) {
 		default:
 			continue
 		}
-		q.OrderExprFunc(func(b *sql.Builder) {
-			// Write the ORDER BY term.
-			switch {
-			case orderC != "":
-				b.WriteString(orderC)
-			case orderX != nil:
-				b.Join(orderX(join))
-			}
-			// Unlike MySQL and SQLite, NULL values sort as if larger than any other value. Therefore,
-			// we need to explicitly order NULLs first on ASC and last on DESC unless specified otherwise.
-			switch normalizePG := b.Dialect() == dialect.Postgres && !nullsfirst && !nullslast; {
-			case normalizePG && desc:
-				b.WriteString(" DESC NULLS LAST")
-			case normalizePG:
-				b.WriteString(" NULLS FIRST")
-			case desc:
-				b.WriteString(" DESC")
-			}
-			if nullsfirst {
-				b.WriteString(" NULLS FIRST")
-			} else if nullslast {
-				b.WriteString(" NULLS LAST")
-			}
-		})
+		k := &sql.OrderKey{Desc: desc, NullsFirst: nullsfirst, NullsLast: nullslast}
+		// Write the ORDER BY term.
+		switch {
+		case orderC != "":
+			k.Expr = sql.Expr(orderC)
+		case orderX != nil:
+			k.Expr = orderX(join)
+		}
+		// Unlike MySQL and SQLite, NULL values sort as if larger than any other value. Therefore,
+		// we need to explicitly order NULLs first on ASC and last on DESC unless specified otherwise.
+		if q.Dialect() == dialect.Postgres && !nullsfirst && !nullslast {
+			k.NullsFirst, k.NullsLast = !desc, desc
+		}
+		q.OrderByKey(k)
 	}
 }
 
@@ -662,10 +653,53 @@ type (
 		Schema      string
 		Columns     []string
 		ID          *FieldSpec   // primary key.
-		CompositeID []*FieldSpec // composite id (edge schema).
+		CompositeID []*FieldSpec // composite id (edge schema or multi-column primary key).
 	}
 )
 
+// setID sets the identifier of the node. A single field is set as
+// the node primary key, and multiple fields as its composite id.
+func (n *NodeSpec) setID(id []*FieldSpec) {
+	switch {
+	case len(id) == 1:
+		n.ID = id[0]
+	case len(id) > 1:
+		n.CompositeID = id
+	}
+}
+
+// idPredicate returns the predicate for matching the node by its
+// primary key, or by all columns of its composite id.
+func (n *NodeSpec) idPredicate() (*sql.Predicate, error) {
+	switch {
+	case n.ID != nil:
+		return sql.EQ(n.ID.Column, n.ID.Value), nil
+	case len(n.CompositeID) > 1:
+		ps := make([]*sql.Predicate, len(n.CompositeID))
+		for i, c := range n.CompositeID {
+			ps[i] = sql.EQ(c.Column, c.Value)
+		}
+		return sql.And(ps...), nil
+	case len(n.CompositeID) == 1:
+		return nil, fmt.Errorf("sql/sqlgraph: invalid composite id for table %q", n.Table)
+	default:
+		return nil, fmt.Errorf("sql/sqlgraph: missing node id for table %q", n.Table)
+	}
+}
+
+// idValue returns the value of the node identifier. Composite
+// ids are returned as a list of their column values.
+func (n *NodeSpec) idValue() driver.Value {
+	if n.ID != nil {
+		return n.ID.Value
+	}
+	vs := make([]driver.Value, len(n.CompositeID))
+	for i, c := range n.CompositeID {
+		vs[i] = c.Value
+	}
+	return vs
+}
+
 // NewFieldSpec creates a new FieldSpec with its required fields.
 func NewFieldSpec(column string, typ field.Type) *FieldSpec {
 	return &FieldSpec{Column: column, Type: typ}
@@ -711,6 +745,13 @@ type (
 		//	}
 		//
 		OnConflict []sql.ConflictOption
+
+		// Generated holds the columns that are computed by the database
+		// (e.g. generated columns). These columns are read back after the
+		// node was created, and assigned using the ScanValues and Assign.
+		Generated  []string
+		ScanValues func(columns []string) ([]any, error)
+		Assign     func(columns []string, values []any) error
 	}
 
 	// BatchCreateSpec holds the information for creating
@@ -782,6 +823,9 @@ type (
 		Fields    FieldMut
 		Predicate func(*sql.Selector)
 		Modifiers []func(*sql.UpdateBuilder)
+		// Version holds the column and the expected value of the version
+		// field, in case the node is updated with optimistic locking.
+		Version *FieldSpec
 
 		ScanValues func(columns []string) ([]any, error)
 		Assign     func(columns []string, values []any) error
@@ -793,12 +837,7 @@ func NewUpdateSpec(table string, columns []string, id ...*FieldSpec) *UpdateSpec
 	spec := &UpdateSpec{
 		Node: &NodeSpec{Table: table, Columns: columns},
 	}
-	switch {
-	case len(id) == 1:
-		spec.Node.ID = id[0]
-	case len(id) > 1:
-		spec.Node.CompositeID = id
-	}
+	spec.Node.setID(id)
 	return spec
 }
 
@@ -870,6 +909,18 @@ func (e *NotFoundError) Error() string {
 	return fmt.Sprintf("record with id %v not found in table %s", e.id, e.table)
 }
 
+// StaleObjectError returns when trying to update an entity with optimistic
+// locking, and its version was changed since it was loaded from the database.
+type StaleObjectError struct {
+	Table   string       // Table of the entity.
+	ID      driver.Value // ID of the entity.
+	Version driver.Value // Expected version.
+}
+
+func (e *StaleObjectError) Error() string {
+	return fmt.Sprintf("record with id %v in table %s was modified concurrently (expected version %v)", e.ID, e.Table, e.Version)
+}
+
 // DeleteSpec holds the information for delete one
 // or more nodes in the graph.
 type DeleteSpec struct {
@@ -877,9 +928,12 @@ type DeleteSpec struct {
 	Predicate func(*sql.Selector)
 }
 
-// NewDeleteSpec creates a new node deletion spec.
-func NewDeleteSpec(table string, id *FieldSpec) *DeleteSpec {
-	return &DeleteSpec{Node: &NodeSpec{Table: table, ID: id}}
+// NewDeleteSpec creates a new node deletion spec. Multiple
+// id fields define a composite (multi-column) identifier.
+func NewDeleteSpec(table string, id ...*FieldSpec) *DeleteSpec {
+	spec := &DeleteSpec{Node: &NodeSpec{Table: table}}
+	spec.Node.setID(id)
+	return spec
 }
 
 // DeleteNodes applies the DeleteSpec on the graph.
@@ -922,15 +976,17 @@ type QuerySpec struct {
 	Assign     func(columns []string, values []any) error
 }
 
-// NewQuerySpec creates a new node query spec.
-func NewQuerySpec(table string, columns []string, id *FieldSpec) *QuerySpec {
-	return &QuerySpec{
+// NewQuerySpec creates a new node query spec. Multiple
+// id fields define a composite (multi-column) identifier.
+func NewQuerySpec(table string, columns []string, id ...*FieldSpec) *QuerySpec {
+	spec := &QuerySpec{
 		Node: &NodeSpec{
-			ID:      id,
 			Table:   table,
 			Columns: columns,
 		},
 	}
+	spec.Node.setID(id)
+	return spec
 }
 
 // QueryNodes queries the nodes in the graph query and scans them to the given values.
@@ -940,6 +996,25 @@ func QueryNodes(ctx context.Context, drv dialect.Driver, spec *QuerySpec) error
 	return qr.nodes(ctx, drv)
 }
 
+// QueryNodesIter is like QueryNodes, but returns an iterator that scans the rows lazily.
+// The Assign function of the spec is called for each row, and a nil error is yielded
+// after it. The iteration stops when yield returns false or on the first error, which
+// is yielded last. The underlying rows are closed when the iteration ends.
+func QueryNodesIter(ctx context.Context, drv dialect.Driver, spec *QuerySpec) iter.Seq[error] {
+	return func(yield func(error) bool) {
+		builder := sql.Dialect(drv.Dialect())
+		qr := &query{graph: graph{builder: builder}, QuerySpec: spec}
+		stopped := false
+		err := qr.scan(ctx, drv, func() bool {
+			stopped = !yield(nil)
+			return !stopped
+		})
+		if err != nil && !stopped {
+			yield(err)
+		}
+	}
+}
+
 // CountNodes counts the nodes in the given graph query.
 func CountNodes(ctx context.Context, drv dialect.Driver, spec *QuerySpec) (int, error) {
 	builder := sql.Dialect(drv.Dialect())
@@ -995,6 +1070,12 @@ type query struct {
 }
 
 func (q *query) nodes(ctx context.Context, drv dialect.Driver) error {
+	return q.scan(ctx, drv, nil)
+}
+
+// scan executes the query and assigns its rows. If next is not nil, it is
+// called after each row is assigned, and the scan stops if it returns false.
+func (q *query) scan(ctx context.Context, drv dialect.Driver, next func() bool) error {
 	rows := &sql.Rows{}
 	selector, err := q.selector(ctx)
 	if err != nil {
@@ -1025,6 +1106,9 @@ func (q *query) nodes(ctx context.Context, drv dialect.Driver) error {
 		if err := q.Assign(columns, values); err != nil {
 			return err
 		}
+		if next != nil && !next() {
+			return nil
+		}
 	}
 	return rows.Err()
 }
@@ -1106,27 +1190,22 @@ type updater struct {
 func (u *updater) node(ctx context.Context, tx dialect.ExecQuerier) error {
 	var (
 		id         driver.Value
-		idp        *sql.Predicate
 		addEdges   = EdgeSpecs(u.Edges.Add).GroupRel()
 		clearEdges = EdgeSpecs(u.Edges.Clear).GroupRel()
 	)
-	switch {
-	// In case it is not an edge schema, the id holds the PK
-	// of the node used for linking it with the other nodes.
-	case u.Node.ID != nil:
+	idp, err := u.Node.idPredicate()
+	if err != nil {
+		return err
+	}
+	// In case the node does not have a composite id, the id holds
+	// the PK of the node used for linking it with the other nodes.
+	if u.Node.ID != nil {
 		id = u.Node.ID.Value
-		idp = sql.EQ(u.Node.ID.Column, id)
-	case len(u.Node.CompositeID) == 2:
-		idp = sql.And(
-			sql.EQ(u.Node.CompositeID[0].Column, u.Node.CompositeID[0].Value),
-			sql.EQ(u.Node.CompositeID[1].Column, u.Node.CompositeID[1].Value),
-		)
-	case len(u.Node.CompositeID) != 2:
-		return fmt.Errorf("sql/sqlgraph: invalid composite id for update table %q", u.Node.Table)
-	default:
-		return fmt.Errorf("sql/sqlgraph: missing node id for update table %q", u.Node.Table)
 	}
 	update := u.builder.Update(u.Node.Table).Schema(u.Node.Schema).Where(idp)
+	if v := u.Version; v != nil {
+		update.Where(sql.EQ(v.Column, v.Value))
+	}
 	if pred := u.Predicate; pred != nil {
 		selector := u.builder.Select().From(u.builder.Table(u.Node.Table).Schema(u.Node.Schema))
 		pred(selector)
@@ -1153,7 +1232,7 @@ func (u *updater) node(ctx context.Context, tx dialect.ExecQuerier) error {
 		}
 		// In case there are zero affected rows by this statement, we need to distinguish
 		// between the case of "record was not found" and "record was not changed".
-		if affected == 0 && u.Predicate != nil {
+		if affected == 0 && (u.Predicate != nil || u.Version != nil) {
 			if err := u.ensureExists(ctx); err != nil {
 				return err
 			}
@@ -1199,14 +1278,14 @@ func (u *updater) nodes(ctx context.Context, drv dialect.Driver) (int, error) {
 	// the returned nodes are used for updating external tables.
 	case u.Node.ID != nil:
 		selector.Select(u.Node.ID.Column)
-	case len(u.Node.CompositeID) == 2:
+	case len(u.Node.CompositeID) > 1:
 		// Other edge-schemas (M2M tables) cannot be updated by this operation.
 		// Also, in case there is a need to update an external foreign-key, it must
 		// be a single value and the user should use the "update by id" API instead.
 		if multiple {
-			return 0, fmt.Errorf("sql/sqlgraph: update edge schema table %q cannot update external tables", u.Node.Table)
+			return 0, fmt.Errorf("sql/sqlgraph: update composite id table %q cannot update external tables", u.Node.Table)
 		}
-	case len(u.Node.CompositeID) != 2:
+	case len(u.Node.CompositeID) == 1:
 		return 0, fmt.Errorf("sql/sqlgraph: invalid composite id for update table %q", u.Node.Table)
 	default:
 		return 0, fmt.Errorf("sql/sqlgraph: missing node id for update table %q", u.Node.Table)
@@ -1352,10 +1431,7 @@ func (u *updater) scan(rows *sql.Rows) error {
 		if err := rows.Err(); err != nil {
 			return err
 		}
-		if len(u.Node.CompositeID) == 2 {
-			return &NotFoundError{table: u.Node.Table, id: []driver.Value{u.Node.CompositeID[0].Value, u.Node.CompositeID[1].Value}}
-		}
-		return &NotFoundError{table: u.Node.Table, id: u.Node.ID.Value}
+		return &NotFoundError{table: u.Node.Table, id: u.Node.idValue()}
 	}
 	values, err := u.ScanValues(columns)
 	if err != nil {
@@ -1376,22 +1452,44 @@ func (u *updater) scan(rows *sql.Rows) error {
 }
 
 func (u *updater) ensureExists(ctx context.Context) error {
-	exists := u.builder.Select().From(u.builder.Table(u.Node.Table).Schema(u.Node.Schema)).Where(sql.EQ(u.Node.ID.Column, u.Node.ID.Value))
-	u.Predicate(exists)
-	query, args := u.builder.SelectExpr(sql.Exists(exists)).Query()
-	rows := &sql.Rows{}
-	if err := u.tx.Query(ctx, query, args, rows); err != nil {
+	found, err := u.exists(ctx, u.Version, u.Predicate)
+	if err != nil || found {
 		return err
 	}
-	defer rows.Close()
-	found, err := sql.ScanBool(rows)
+	// A versioned record that exists, but was not matched by the update
+	// (e.g. by its expected version), is considered as a conflict.
+	if u.Version != nil {
+		if found, err = u.exists(ctx, nil, nil); err != nil {
+			return err
+		}
+		if found {
+			return &StaleObjectError{Table: u.Node.Table, ID: u.Node.idValue(), Version: u.Version.Value}
+		}
+	}
+	return &NotFoundError{table: u.Node.Table, id: u.Node.idValue()}
+}
+
+// exists reports if the updated record exists, and matches
+// the given version and predicate.
+func (u *updater) exists(ctx context.Context, version *FieldSpec, pred func(*sql.Selector)) (bool, error) {
+	idp, err := u.Node.idPredicate()
 	if err != nil {
-		return err
+		return false, err
 	}
-	if !found {
-		return &NotFoundError{table: u.Node.Table, id: u.Node.ID.Value}
+	exists := u.builder.Select().From(u.builder.Table(u.Node.Table).Schema(u.Node.Schema)).Where(idp)
+	if version != nil {
+		exists.Where(sql.EQ(version.Column, version.Value))
 	}
-	return nil
+	if pred != nil {
+		pred(exists)
+	}
+	query, args := u.builder.SelectExpr(sql.Exists(exists)).Query()
+	rows := &sql.Rows{}
+	if err := u.tx.Query(ctx, query, args, rows); err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	return sql.ScanBool(rows)
 }
 
 type creator struct {
@@ -1428,7 +1526,10 @@ func (c *creator) node(ctx context.Context, drv dialect.Driver) error {
 		if err := c.graph.addM2MEdges(ctx, []driver.Value{c.ID.Value}, edges[M2M]); err != nil {
 			return err
 		}
-		return c.graph.addFKEdges(ctx, []driver.Value{c.ID.Value}, append(edges[O2M], edges[O2O]...))
+		if err := c.graph.addFKEdges(ctx, []driver.Value{c.ID.Value}, append(edges[O2M], edges[O2O]...)); err != nil {
+			return err
+		}
+		return c.graph.loadGenerated(ctx, c.CreateSpec)
 	}(); err != nil {
 		return rollback(tx, err)
 	}
@@ -1578,6 +1679,11 @@ func (c *batchCreator) nodes(ctx context.Context, drv dialect.Driver) error {
 				return err
 			}
 		}
+		// Generated columns are returned by the INSERT statement in
+		// dialects that support the "RETURNING" clause.
+		if insert.Dialect() == dialect.MySQL {
+			return c.graph.loadGeneratedBatch(ctx, c.Nodes)
+		}
 		return nil
 	}(); err != nil {
 		return rollback(tx, err)
@@ -1600,7 +1706,15 @@ func (c *batchCreator) mayTx(ctx context.Context, drv dialect.Driver) (dialect.T
 // batchInsert inserts a batch of nodes to their table and sets their ID if it was not provided by the user.
 func (c *batchCreator) batchInsert(ctx context.Context, tx dialect.ExecQuerier, insert *sql.InsertBuilder) error {
 	c.ensureConflict(insert)
-	return c.insertLastIDs(ctx, tx, insert.Returning(c.Nodes[0].ID.Column))
+	return c.insertLastIDs(ctx, tx, insert.Returning(append([]string{c.Nodes[0].ID.Column}, c.generated()...)...))
+}
+
+// generated returns the generated columns of the nodes that are scanned and assigned after their creation.
+func (c *batchCreator) generated() []string {
+	if n := c.Nodes[0]; n.ScanValues != nil {
+		return n.Generated
+	}
+	return nil
 }
 
 // ensureConflict ensures the ON CONFLICT is added to the insert statement.
@@ -1651,6 +1765,104 @@ type graph struct {
 	builder *sql.DialectBuilder
 }
 
+// loadGenerated reads back the columns that were computed by
+// the database (e.g. generated columns) for the created node.
+func (g *graph) loadGenerated(ctx context.Context, n *CreateSpec) error {
+	// The ID is unknown in case it was not returned by
+	// the database (e.g. non-numeric IDs in MySQL).
+	if len(n.Generated) == 0 || n.ScanValues == nil || n.ID == nil || n.ID.Value == nil {
+		return nil
+	}
+	selector := g.builder.Select(n.Generated...).
+		From(g.builder.Table(n.Table).Schema(n.Schema)).
+		Where(sql.EQ(n.ID.Column, n.ID.Value))
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := g.tx.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return &NotFoundError{table: n.Table, id: n.ID.Value}
+	}
+	values, err := n.ScanValues(n.Generated)
+	if err != nil {
+		return err
+	}
+	if err := rows.Scan(values...); err != nil {
+		return fmt.Errorf("failed scanning rows: %w", err)
+	}
+	return n.Assign(n.Generated, values)
+}
+
+// loadGeneratedBatch reads back the columns that were computed by the database
+// for the created nodes using one query, and assigns them to the nodes by their IDs.
+func (g *graph) loadGeneratedBatch(ctx context.Context, nodes []*CreateSpec) error {
+	var (
+		ids   []any
+		byKey = make(map[string]*CreateSpec, len(nodes))
+	)
+	for _, n := range nodes {
+		// See loadGenerated for the nodes that are skipped.
+		if len(n.Generated) == 0 || n.ScanValues == nil || n.ID == nil || n.ID.Value == nil {
+			continue
+		}
+		k, err := idKey(n.ID.Value)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, n.ID.Value)
+		byKey[k] = n
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	n := nodes[0]
+	selector := g.builder.Select(append([]string{n.ID.Column}, n.Generated...)...).
+		From(g.builder.Table(n.Table).Schema(n.Schema)).
+		Where(sql.In(n.ID.Column, ids...))
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := g.tx.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id any
+		// ScanValues allocates new destinations for the given columns,
+		// and therefore, it can be used for scanning the rows of all nodes.
+		values, err := n.ScanValues(n.Generated)
+		if err != nil {
+			return err
+		}
+		if err := rows.Scan(append([]any{&id}, values...)...); err != nil {
+			return fmt.Errorf("failed scanning rows: %w", err)
+		}
+		k, err := idKey(id)
+		if err != nil {
+			return err
+		}
+		node, ok := byKey[k]
+		if !ok {
+			return fmt.Errorf("sqlgraph: unexpected id %v in table %q", id, n.Table)
+		}
+		if err := node.Assign(n.Generated, values); err != nil {
+			return err
+		}
+		delete(byKey, k)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, node := range byKey {
+		return &NotFoundError{table: node.Table, id: node.ID.Value}
+	}
+	return nil
+}
+
 func (g *graph) clearM2MEdges(ctx context.Context, ids []driver.Value, edges EdgeSpecs) error {
 	// Remove all M2M edges from the same type at once.
 	// The EdgeSpec is the same for all members in a group.
@@ -1877,7 +2089,8 @@ func isExternalEdge(e *EdgeSpec) bool {
 func setTableColumns(fields []*FieldSpec, edges map[Rel][]*EdgeSpec, set func(string, driver.Value)) (err error) {
 	for _, fi := range fields {
 		value := fi.Value
-		if fi.Type == field.TypeJSON {
+		// Array values are encoded by the builder, based on its dialect.
+		if _, ok := value.(*sql.ArrayValue); !ok && fi.Type == field.TypeJSON {
 			buf, err := json.Marshal(value)
 			if err != nil {
 				return fmt.Errorf("marshal value for column %s: %w", fi.Column, err)
@@ -1960,25 +2173,39 @@ func (c *batchCreator) insertLastIDs(ctx context.Context, tx dialect.ExecQuerier
 			return err
 		}
 		defer rows.Close()
+		generated := c.generated()
 		for i := 0; rows.Next(); i++ {
-			node := c.Nodes[i]
-			switch _, ok := node.ID.Value.(field.ValueScanner); {
+			var (
+				id    int64
+				node  = c.Nodes[i]
+				dest  = []any{&node.ID.Value}
+				_, ok = node.ID.Value.(field.ValueScanner)
+			)
+			switch {
 			case ok:
 				// If the ID implements the sql.Scanner
 				// interface it should be a pointer type.
-				if err := rows.Scan(node.ID.Value); err != nil {
-					return err
-				}
+				dest[0] = node.ID.Value
 			case node.ID.Type.Numeric():
 				// Normalize the type to int64 to make it looks
 				// like LastInsertId.
-				var id int64
-				if err := rows.Scan(&id); err != nil {
+				dest[0] = &id
+			}
+			if len(generated) > 0 {
+				values, err := node.ScanValues(generated)
+				if err != nil {
 					return err
 				}
+				dest = append(dest, values...)
+			}
+			if err := rows.Scan(dest...); err != nil {
+				return err
+			}
+			if !ok && node.ID.Type.Numeric() {
 				node.ID.Value = id
-			default:
-				if err := rows.Scan(&node.ID.Value); err != nil {
+			}
+			if len(generated) > 0 {
+				if err := node.Assign(generated, dest[1:]); err != nil {
 					return err
 				}
 			}
@@ -2010,6 +2237,21 @@ func (c *batchCreator) insertLastIDs(ctx context.Context, tx dialect.ExecQuerier
 	return nil
 }
 
+// idKey returns a key that identifies the given ID value, whether it was set on
+// the create spec or scanned from the database (e.g. int64 or []byte values).
+func idKey(v any) (string, error) {
+	if vr, ok := v.(driver.Valuer); ok {
+		var err error
+		if v, err = vr.Value(); err != nil {
+			return "", err
+		}
+	}
+	if b, ok := v.([]byte); ok {
+		return string(b), nil
+	}
+	return fmt.Sprint(v), nil
+}
+
 // rollback calls to tx.Rollback and wraps the given error with the rollback error if occurred.
 func rollback(tx dialect.Tx, err error) error {
 	if rerr := tx.Rollback(); rerr != nil {
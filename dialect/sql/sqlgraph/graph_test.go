@@ -1468,6 +1468,84 @@ func TestCreateNode(t *testing.T) {
 	}
 }
 
+func TestCreateNode_Generated(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectExec(escape("INSERT INTO `users` (`name`) VALUES (?)")).
+		WithArgs("a8m").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(escape("SELECT `age` FROM `users` WHERE `id` = ?")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"age"}).AddRow(30))
+	usr := &user{}
+	err = CreateNode(context.Background(), sql.OpenDB(dialect.MySQL, db), &CreateSpec{
+		Table: "users",
+		ID:    &FieldSpec{Column: "id", Type: field.TypeInt},
+		Fields: []*FieldSpec{
+			{Column: "name", Type: field.TypeString, Value: "a8m"},
+		},
+		Generated:  []string{"age"},
+		ScanValues: usr.values,
+		Assign:     usr.assign,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 30, usr.age)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	db, mock, err = sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectExec(escape("INSERT INTO `users` (`name`) VALUES (?), (?)")).
+		WithArgs("a8m", "nati").
+		WillReturnResult(sqlmock.NewResult(10, 2))
+	mock.ExpectQuery(escape("SELECT `id`, `age` FROM `users` WHERE `id` IN (?, ?)")).
+		WithArgs(10, 11).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "age"}).AddRow(11, 32).AddRow(10, 30))
+	usr1, usr2 := &user{}, &user{}
+	batch := func() *BatchCreateSpec {
+		return &BatchCreateSpec{
+			Nodes: []*CreateSpec{
+				{
+					Table:      "users",
+					ID:         &FieldSpec{Column: "id", Type: field.TypeInt},
+					Fields:     []*FieldSpec{{Column: "name", Type: field.TypeString, Value: "a8m"}},
+					Generated:  []string{"age"},
+					ScanValues: usr1.values,
+					Assign:     usr1.assign,
+				},
+				{
+					Table:      "users",
+					ID:         &FieldSpec{Column: "id", Type: field.TypeInt},
+					Fields:     []*FieldSpec{{Column: "name", Type: field.TypeString, Value: "nati"}},
+					Generated:  []string{"age"},
+					ScanValues: usr2.values,
+					Assign:     usr2.assign,
+				},
+			},
+		}
+	}
+	err = BatchCreate(context.Background(), sql.OpenDB(dialect.MySQL, db), batch())
+	require.NoError(t, err)
+	require.Equal(t, 30, usr1.age)
+	require.Equal(t, 32, usr2.age)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// Generated columns are returned by the INSERT statement.
+	db, mock, err = sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectQuery(escape(`INSERT INTO "users" ("name") VALUES ($1), ($2) RETURNING "id", "age"`)).
+		WithArgs("a8m", "nati").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "age"}).AddRow(10, 31).AddRow(11, 33))
+	usr1, usr2 = &user{}, &user{}
+	spec := batch()
+	err = BatchCreate(context.Background(), sql.OpenDB(dialect.Postgres, db), spec)
+	require.NoError(t, err)
+	require.Equal(t, 31, usr1.age)
+	require.Equal(t, 33, usr2.age)
+	require.EqualValues(t, 10, spec.Nodes[0].ID.Value)
+	require.EqualValues(t, 11, spec.Nodes[1].ID.Value)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestBatchCreate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -2064,6 +2142,154 @@ func TestExecUpdateNode(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestUpdateNode_Version(t *testing.T) {
+	spec := func() *UpdateSpec {
+		return &UpdateSpec{
+			Node: &NodeSpec{
+				Table:   "users",
+				Columns: []string{"id", "name", "age"},
+				ID:      &FieldSpec{Column: "id", Type: field.TypeInt, Value: 1},
+			},
+			Fields: FieldMut{
+				Set: []*FieldSpec{
+					{Column: "name", Type: field.TypeString, Value: "Ariel"},
+				},
+				Add: []*FieldSpec{
+					{Column: "version", Type: field.TypeInt, Value: 1},
+				},
+			},
+			Version: &FieldSpec{Column: "version", Type: field.TypeInt, Value: 2},
+		}
+	}
+	t.Run("Updated", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectExec(escape("UPDATE `users` SET `name` = ?, `version` = COALESCE(`users`.`version`, 0) + ? WHERE `id` = ? AND `version` = ?")).
+			WithArgs("Ariel", 1, 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		err = UpdateNode(context.Background(), sql.OpenDB("", db), spec())
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("Stale", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectExec(escape("UPDATE `users` SET `name` = ?, `version` = COALESCE(`users`.`version`, 0) + ? WHERE `id` = ? AND `version` = ?")).
+			WithArgs("Ariel", 1, 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(escape("SELECT EXISTS (SELECT * FROM `users` WHERE `id` = ? AND `version` = ?)")).
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectQuery(escape("SELECT EXISTS (SELECT * FROM `users` WHERE `id` = ?)")).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectRollback()
+		err = UpdateNode(context.Background(), sql.OpenDB("", db), spec())
+		var se *StaleObjectError
+		require.ErrorAs(t, err, &se)
+		require.Equal(t, &StaleObjectError{Table: "users", ID: 1, Version: 2}, se)
+		require.True(t, IsStaleObjectError(err))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("StalePredicate", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectExec(escape("UPDATE `users` SET `name` = ?, `version` = COALESCE(`users`.`version`, 0) + ? WHERE (`id` = ? AND `version` = ?) AND `users`.`version` = ?")).
+			WithArgs("Ariel", 1, 1, 2, 1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(escape("SELECT EXISTS (SELECT * FROM `users` WHERE (`id` = ? AND `version` = ?) AND `users`.`version` = ?)")).
+			WithArgs(1, 2, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectQuery(escape("SELECT EXISTS (SELECT * FROM `users` WHERE `id` = ?)")).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectRollback()
+		spec := spec()
+		spec.Predicate = func(s *sql.Selector) {
+			s.Where(sql.EQ(s.C("version"), 1))
+		}
+		err = UpdateNode(context.Background(), sql.OpenDB("", db), spec)
+		require.True(t, IsStaleObjectError(err), "records that were not matched by the predicates of a versioned update are stale")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectExec(escape("UPDATE `users` SET `name` = ?, `version` = COALESCE(`users`.`version`, 0) + ? WHERE `id` = ? AND `version` = ?")).
+			WithArgs("Ariel", 1, 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(escape("SELECT EXISTS (SELECT * FROM `users` WHERE `id` = ? AND `version` = ?)")).
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectQuery(escape("SELECT EXISTS (SELECT * FROM `users` WHERE `id` = ?)")).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectRollback()
+		err = UpdateNode(context.Background(), sql.OpenDB("", db), spec())
+		var nf *NotFoundError
+		require.ErrorAs(t, err, &nf)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateNode_CompositeID(t *testing.T) {
+	spec := func() *UpdateSpec {
+		spec := NewUpdateSpec("orders", []string{"tenant_id", "region", "number", "note"},
+			&FieldSpec{Column: "tenant_id", Type: field.TypeInt, Value: 1},
+			&FieldSpec{Column: "region", Type: field.TypeString, Value: "eu"},
+			&FieldSpec{Column: "number", Type: field.TypeInt, Value: 42},
+		)
+		spec.SetField("note", field.TypeString, "shipped")
+		return spec
+	}
+	t.Run("Update", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectExec(escape("UPDATE `orders` SET `note` = ? WHERE `tenant_id` = ? AND `region` = ? AND `number` = ?")).
+			WithArgs("shipped", 1, "eu", 42).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		require.NoError(t, UpdateNode(context.Background(), sql.OpenDB("", db), spec()))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectExec(escape("UPDATE `orders` SET `note` = ? WHERE (`tenant_id` = ? AND `region` = ? AND `number` = ?) AND `orders`.`note` IS NULL")).
+			WithArgs("shipped", 1, "eu", 42).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(escape("SELECT EXISTS (SELECT * FROM `orders` WHERE (`tenant_id` = ? AND `region` = ? AND `number` = ?) AND `orders`.`note` IS NULL)")).
+			WithArgs(1, "eu", 42).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectRollback()
+		s := spec()
+		s.Predicate = sql.FieldIsNull("note")
+		err = UpdateNode(context.Background(), sql.OpenDB("", db), s)
+		var nf *NotFoundError
+		require.ErrorAs(t, err, &nf)
+		require.EqualError(t, err, "record with id [1 eu 42] not found in table orders")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+		s := spec()
+		s.Node.CompositeID = s.Node.CompositeID[:1]
+		err = UpdateNode(context.Background(), sql.OpenDB("", db), s)
+		require.EqualError(t, err, `sql/sqlgraph: invalid composite id for table "orders"`)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestUpdateNodes(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -2441,6 +2667,68 @@ func TestQueryNodes(t *testing.T) {
 	require.Equal(t, 3, n)
 }
 
+func TestQueryNodesIter(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	rows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "age", "name"}).
+			AddRow(1, 10, "a").
+			AddRow(2, 20, "b").
+			AddRow(3, 30, "c")
+	}
+	mock.ExpectQuery(escape("SELECT `users`.`id`, `users`.`age`, `users`.`name` FROM `users`")).
+		WillReturnRows(rows()).
+		RowsWillBeClosed()
+	mock.ExpectQuery(escape("SELECT `users`.`id`, `users`.`age`, `users`.`name` FROM `users`")).
+		WillReturnRows(rows()).
+		RowsWillBeClosed()
+	mock.ExpectQuery(escape("SELECT `users`.`id`, `users`.`age`, `users`.`name` FROM `users`")).
+		WillReturnError(errors.New("boom"))
+	var (
+		current *user
+		spec    = &QuerySpec{
+			Node: &NodeSpec{
+				Table:   "users",
+				Columns: []string{"id", "age", "name"},
+				ID:      &FieldSpec{Column: "id", Type: field.TypeInt},
+			},
+			ScanValues: func(columns []string) ([]any, error) {
+				current = &user{}
+				return current.values(columns)
+			},
+			Assign: func(columns []string, values []any) error {
+				return current.assign(columns, values)
+			},
+		}
+		drv = sql.OpenDB("", db)
+	)
+	var names []string
+	for err := range QueryNodesIter(context.Background(), drv, spec) {
+		require.NoError(t, err)
+		names = append(names, current.name)
+	}
+	require.Equal(t, []string{"a", "b", "c"}, names)
+
+	// Stop in the middle of the iteration.
+	names = names[:0]
+	for err := range QueryNodesIter(context.Background(), drv, spec) {
+		require.NoError(t, err)
+		if names = append(names, current.name); len(names) == 2 {
+			break
+		}
+	}
+	require.Equal(t, []string{"a", "b"}, names)
+
+	// Errors are yielded last.
+	var errs []error
+	for err := range QueryNodesIter(context.Background(), drv, spec) {
+		errs = append(errs, err)
+	}
+	require.Len(t, errs, 1)
+	require.EqualError(t, errs[0], "boom")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestQueryNodesSchema(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
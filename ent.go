@@ -510,6 +510,7 @@ const (
 	OpQueryExist   = "Exist"
 	OpQueryGroupBy = "GroupBy"
 	OpQuerySelect  = "Select"
+	OpQueryIter    = "Iter"
 )
 
 type (
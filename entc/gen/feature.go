@@ -137,6 +137,65 @@ var (
 		Description: "Allows users to configure the `ON CONFLICT`/`ON DUPLICATE KEY` clause for `INSERT` statements",
 	}
 
+	// FeatureRetryTx provides a feature-flag for running functions in transactions that are
+	// retried on transient failures, like serialization failures and deadlocks.
+	FeatureRetryTx = Feature{
+		Name:        "sql/retrytx",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows users to run functions in transactions that are retried on serialization failures and deadlocks",
+	}
+
+	// FeatureSavepoint provides a feature-flag for nesting transactions using savepoints.
+	FeatureSavepoint = Feature{
+		Name:        "sql/savepoint",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows users to create savepoints in transactions, and to start nested transactions from transactional clients",
+	}
+
+	// FeatureIter provides a feature-flag for iterating over query results lazily.
+	FeatureIter = Feature{
+		Name:        "sql/iter",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows users to iterate over query results lazily using the Go 1.23 iterators",
+	}
+
+	// FeaturePaginate provides a feature-flag for keyset (cursor) pagination.
+	FeaturePaginate = Feature{
+		Name:        "sql/paginate",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows users to paginate query results using opaque cursors (keyset pagination)",
+	}
+
+	// FeatureOutbox provides a feature-flag for writing the changes of the mutations to an outbox table.
+	FeatureOutbox = Feature{
+		Name:        "sql/outbox",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Writes a change record of each mutation to an outbox table in the same transaction, and allows relaying them",
+		GraphTemplates: []GraphTemplate{
+			{
+				Name:   "dialect/sql/outbox",
+				Format: "outbox.go",
+			},
+		},
+		cleanup: func(c *Config) error {
+			return os.RemoveAll(filepath.Join(c.Target, "outbox.go"))
+		},
+	}
+
+	// FeatureSchemaDrift provides a feature-flag for mapping the tables and columns of the schema
+	// drift reports back to the names of the Ent types and fields.
+	FeatureSchemaDrift = Feature{
+		Name:        "sql/drift",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Maps the tables and columns of schema drift reports back to the names of the Ent types and fields",
+	}
+
 	FeatureVersionedMigration = Feature{
 		Name:        "sql/versioned-migration",
 		Stage:       Experimental,
@@ -172,6 +231,12 @@ var (
 		FeatureModifier,
 		FeatureExecQuery,
 		FeatureUpsert,
+		FeatureRetryTx,
+		FeatureSavepoint,
+		FeatureIter,
+		FeaturePaginate,
+		FeatureOutbox,
+		FeatureSchemaDrift,
 		FeatureVersionedMigration,
 		FeatureGlobalID,
 	}
@@ -12,16 +12,22 @@ import (
 	"go/parser"
 	"go/token"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"runtime/debug"
+	"slices"
 	"strconv"
 	"strings"
 	"text/template/parse"
 
+	"entgo.io/ent/dialect/sql/history"
+	"entgo.io/ent/dialect/sql/outbox"
 	"entgo.io/ent/dialect/sql/schema"
 	"entgo.io/ent/entc/load"
+	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 
 	"golang.org/x/sync/errgroup"
@@ -176,6 +182,11 @@ func NewGraph(c *Config, schemas ...*load.Schema) (g *Graph, err error) {
 		g.addIndexes(schemas[i])
 	}
 	check(g.edgeSchemas(), "resolving edges")
+	check(g.compositeIDs(), "resolving composite identifiers")
+	check(g.deleteActions(), "resolving delete actions")
+	check(g.histories(), "resolving history tables")
+	check(g.partitions(), "resolving table partitions")
+	check(g.rowSecurity(), "resolving row-level security policies")
 	aliases(g)
 	g.defaults()
 	if c.Storage != nil && c.Storage.Init != nil {
@@ -322,6 +333,9 @@ func (g *Graph) addEdges(schema *load.Schema) {
 		expect(ok, "type %q does not exist for edge", e.Type)
 		_, ok = t.fields[e.Name]
 		expect(!ok, "%s schema cannot contain field and edge with the same name %q", schema.Name, e.Name)
+		// Fields of embedded structs were added to the fields map above,
+		// and their names are checked here as regular fields.
+		expect(t.embed(e.Name) == nil, "%s schema cannot contain embedded field and edge with the same name %q", schema.Name, e.Name)
 		_, ok = seen[e.Name]
 		expect(!ok, "%s schema contains multiple %q edges", schema.Name, e.Name)
 		seen[e.Name] = struct{}{}
@@ -490,6 +504,234 @@ func (g *Graph) resolve(t *Type) error {
 	return nil
 }
 
+// compositeIDs resolves the identifiers of types that are not edge schemas, but were
+// annotated with field.ID. Since foreign keys are single-column, edges of these types
+// are limited to the ones that their foreign key resides in the type table.
+func (g *Graph) compositeIDs() error {
+	for _, n := range g.Nodes {
+		ant := fieldAnnotate(n.Annotations)
+		if ant == nil || len(ant.ID) == 0 || n.IsEdgeSchema() {
+			continue
+		}
+		switch {
+		case n.IsView():
+			return fmt.Errorf("composite identifier is not supported on view %s", n.Name)
+		case g.Storage != nil && g.Storage.Name != "sql":
+			return fmt.Errorf("composite identifier of type %s is not supported by storage driver %q", n.Name, g.Storage.Name)
+		case len(ant.ID) < 2:
+			return fmt.Errorf("composite identifier of type %s must contain at least 2 fields", n.Name)
+		}
+		if t, ok := g.typ(n.KeyName()); ok {
+			return fmt.Errorf("composite identifier of type %s conflicts with type %s", n.Name, t.Name)
+		}
+		ids := make([]*Field, 0, len(ant.ID))
+		for _, name := range ant.ID {
+			f, ok := n.fields[name]
+			switch {
+			case !ok:
+				return fmt.Errorf("composite identifier of type %s contains unknown field %q", n.Name, name)
+			case slices.Contains(ids, f):
+				return fmt.Errorf("composite identifier of type %s contains field %q more than once", n.Name, name)
+			case f.Optional || f.Nillable:
+				return fmt.Errorf("optional field %s.%s cannot be part of a composite identifier", n.Name, name)
+			case f.IsJSON() || f.IsArray() || f.IsOther():
+				return fmt.Errorf("field %s.%s of type %s cannot be part of a composite identifier", n.Name, name, f.Type)
+			// Identifier columns are not updatable. Note that edge-fields
+			// and their edges are already required to be both immutable.
+			case !f.Immutable:
+				return fmt.Errorf("field %s.%s must be immutable to be part of a composite identifier", n.Name, name)
+			}
+			ids = append(ids, f)
+		}
+		n.ID, n.compositeID = nil, ids
+	}
+	for _, n := range g.Nodes {
+		for _, e := range n.Edges {
+			switch {
+			case n.IsEdgeSchema() || e.Type.IsEdgeSchema():
+			case n.HasCompositeID() && (e.M2M() || !e.OwnFK()):
+				return fmt.Errorf("edge %s.%s is not supported, because its foreign key references type %s with a composite identifier", n.Name, e.Name, n.Name)
+			case e.Type.HasCompositeID() && (e.M2M() || e.OwnFK()):
+				return fmt.Errorf("edge %s.%s is not supported, because its foreign key references type %s with a composite identifier", n.Name, e.Name, e.Type.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// deleteActions validates the delete actions that were configured on
+// the edges using the edge.OnDelete annotation.
+func (g *Graph) deleteActions() error {
+	for _, n := range g.Nodes {
+		for _, e := range n.Edges {
+			action := e.OnDelete()
+			switch {
+			case action == "":
+			case action != edge.Cascade && action != edge.Restrict && action != edge.SetNull:
+				return fmt.Errorf("edge %s.%s has an unknown delete action: %q", n.Name, e.Name, action)
+			case g.Storage != nil && g.Storage.Name != "sql":
+				return fmt.Errorf("delete action %q of edge %s.%s is not supported by storage driver %q", action, n.Name, e.Name, g.Storage.Name)
+			case e.M2O():
+				return fmt.Errorf("delete action %q is not allowed on M2O edge %s.%s", action, n.Name, e.Name)
+			case !n.HasOneFieldID() || !e.Type.HasOneFieldID():
+				return fmt.Errorf("delete action %q is not supported on edge %s.%s between types with composite identifiers", action, n.Name, e.Name)
+			case action == edge.Restrict:
+			case e.Immutable && (action == edge.SetNull || e.M2M()):
+				return fmt.Errorf("delete action %q is not allowed on immutable edge %s.%s", action, n.Name, e.Name)
+			case action == edge.SetNull && e.OwnFK():
+				return fmt.Errorf("delete action %q is not allowed on edge %s.%s, because its foreign-key is held by type %s", action, n.Name, e.Name, n.Name)
+			case action == edge.SetNull && !e.M2M() && e.Ref != nil && (!e.Ref.Optional || e.Ref.Field() != nil && !e.Ref.Field().Optional):
+				return fmt.Errorf("delete action %q is not allowed on edge %s.%s, because its inverse edge %s.%s is required", action, n.Name, e.Name, e.Type.Name, e.Ref.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// histories validates the types that were annotated with entsql.History.
+func (g *Graph) histories() error {
+	for _, n := range g.Nodes {
+		switch {
+		case !n.HasHistory():
+		case n.IsView():
+			return fmt.Errorf("history is not supported on view %s", n.Name)
+		case g.Storage != nil && g.Storage.Name != "sql":
+			return fmt.Errorf("history of type %s is not supported by storage driver %q", n.Name, g.Storage.Name)
+		case !n.HasOneFieldID():
+			return fmt.Errorf("history is not supported on type %s with a composite identifier", n.Name)
+		default:
+			if _, ok := g.typ(n.HistoryName()); ok {
+				return fmt.Errorf("history of type %s conflicts with type %s", n.Name, n.HistoryName())
+			}
+		}
+	}
+	return nil
+}
+
+// partitions validates the partition keys of the types that were annotated with entsql.Partition.
+func (g *Graph) partitions() error {
+	for _, n := range g.Nodes {
+		ant := n.EntSQL()
+		switch {
+		case ant == nil || ant.Partition == nil:
+		case n.IsView():
+			return fmt.Errorf("partitioning is not supported on view %s", n.Name)
+		case len(ant.Partition.Columns) == 0:
+			return fmt.Errorf("missing partition columns for type %s", n.Name)
+		default:
+			columns := make(map[string]bool)
+			if n.HasOneFieldID() {
+				columns[n.ID.StorageKey()] = true
+			}
+			for _, f := range n.Fields {
+				columns[f.StorageKey()] = true
+			}
+			for _, fk := range n.ForeignKeys {
+				columns[fk.Field.StorageKey()] = true
+			}
+			for _, c := range ant.Partition.Columns {
+				if !columns[c] {
+					return fmt.Errorf("partition column %q was not found in type %s", c, n.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// rowSecurity validates the policies of the types that were annotated with entsql.RowSecurity.
+func (g *Graph) rowSecurity() error {
+	fields := make(map[string]string)
+	for _, n := range g.Nodes {
+		ant := n.EntSQL()
+		switch {
+		case ant == nil || ant.RowSecurity == nil:
+		case n.IsView():
+			return fmt.Errorf("row-level security is not supported on view %s", n.Name)
+		case g.Storage != nil && g.Storage.Name != "sql":
+			return fmt.Errorf("row-level security of type %s is not supported by storage driver %q", n.Name, g.Storage.Name)
+		default:
+			names := make(map[string]bool)
+			for _, p := range ant.RowSecurity.Policies {
+				switch {
+				case p.Name == "":
+					return fmt.Errorf("missing policy name for type %s", n.Name)
+				case names[p.Name]:
+					return fmt.Errorf("duplicate policy %q for type %s", p.Name, n.Name)
+				case p.Using == "" && p.WithCheck == "":
+					return fmt.Errorf("policy %q of type %s must define a USING or a WITH CHECK expression", p.Name, n.Name)
+				}
+				names[p.Name] = true
+			}
+		}
+	}
+	for _, v := range g.SessionVars() {
+		if name, ok := fields[v.Field]; ok {
+			return fmt.Errorf("session variables %q and %q have the same Go name %s", name, v.Name, v.Field)
+		}
+		fields[v.Field] = v.Name
+	}
+	return nil
+}
+
+// SessionVar is a session variable that is read by the row-level security policies of the schema.
+type SessionVar struct {
+	// Name of the variable. For example, "app.current_tenant".
+	Name string
+	// Field is the Go name of the variable. For example, "AppCurrentTenant".
+	Field string
+	// Tables holds the names of the tables whose policies read the variable.
+	Tables []string
+}
+
+// Constant returns the name of the constant that holds the variable name.
+func (v *SessionVar) Constant() string {
+	return "Var" + v.Field
+}
+
+// sessionVarRe matches the session variables that are read using current_setting.
+var sessionVarRe = regexp.MustCompile(`(?i)current_setting\(\s*'([^']+)'`)
+
+// SessionVars returns the session variables that are read by the row-level security
+// policies of the schema using current_setting, ordered by their names.
+func (g *Graph) SessionVars() []*SessionVar {
+	vars := make(map[string]*SessionVar)
+	for _, n := range g.Nodes {
+		ant := n.EntSQL()
+		if ant == nil || ant.RowSecurity == nil {
+			continue
+		}
+		for _, p := range ant.RowSecurity.Policies {
+			for _, m := range sessionVarRe.FindAllStringSubmatch(p.Using+" "+p.WithCheck, -1) {
+				v, ok := vars[m[1]]
+				if !ok {
+					v = &SessionVar{Name: m[1], Field: pascal(strings.ReplaceAll(m[1], ".", "_"))}
+					vars[m[1]] = v
+				}
+				if !slices.Contains(v.Tables, n.Table()) {
+					v.Tables = append(v.Tables, n.Table())
+				}
+			}
+		}
+	}
+	sorted := make([]*SessionVar, 0, len(vars))
+	for _, name := range slices.Sorted(maps.Keys(vars)) {
+		sorted = append(sorted, vars[name])
+	}
+	return sorted
+}
+
+// HistoryNodes returns the types whose row history is recorded in history tables.
+func (g *Graph) HistoryNodes() []*Type {
+	var nodes []*Type
+	for _, n := range g.Nodes {
+		if n.HasHistory() {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
 // edgeSchemas visits all edges in the graph and detects which schemas are used as "edge schemas".
 // Note, edge schemas cannot be used by more than one association (edge.To), must define two required
 // edges (+ edge-fields) to the types that go through them, and allow adding additional fields with
@@ -639,8 +881,11 @@ func (g *Graph) Tables() (all []*schema.Table, err error) {
 	for _, n := range g.MutableNodes() {
 		table := schema.NewTable(n.Table()).
 			SetComment(n.sqlComment())
+		table.TypeName = n.Name
 		if n.HasOneFieldID() {
-			table.AddPrimary(n.ID.PK())
+			pk := n.ID.PK()
+			pk.FieldName = n.ID.Name
+			table.AddPrimary(pk)
 		}
 		switch ant := n.EntSQL(); {
 		case ant == nil:
@@ -654,7 +899,9 @@ func (g *Graph) Tables() (all []*schema.Table, err error) {
 				continue
 			}
 			if !f.IsEdgeField() {
-				table.AddColumn(f.Column())
+				c := f.Column()
+				c.FieldName = f.Name
+				table.AddColumn(c)
 			}
 		}
 		switch {
@@ -780,6 +1027,21 @@ func (g *Graph) Tables() (all []*schema.Table, err error) {
 	if err := ensureUniqueFKs(tables); err != nil {
 		return nil, err
 	}
+	for _, n := range g.HistoryNodes() {
+		t := history.NewTable(tables[n.Table()])
+		if tables[t.Name] != nil {
+			return nil, fmt.Errorf("table name %q is reserved for the history table of type %s", t.Name, n.Name)
+		}
+		tables[t.Name] = t
+		all = append(all, t)
+	}
+	if g.featureEnabled(FeatureOutbox) {
+		t := outbox.NewTable()
+		if tables[t.Name] != nil {
+			return nil, fmt.Errorf("table name %q is reserved for the outbox table", t.Name)
+		}
+		all = append(all, t)
+	}
 	return
 }
 
@@ -791,6 +1053,7 @@ func (g *Graph) Views() (views []*schema.Table, err error) {
 		}
 		view := schema.NewView(n.Table()).
 			SetComment(n.sqlComment())
+		view.TypeName = n.Name
 		switch ant := n.EntSQL(); {
 		case ant == nil:
 		case ant.Skip:
@@ -802,7 +1065,9 @@ func (g *Graph) Views() (views []*schema.Table, err error) {
 			if a := f.EntSQL(); a != nil && a.Skip {
 				continue
 			}
-			view.AddColumn(f.Column())
+			c := f.Column()
+			c.FieldName = f.Name
+			view.AddColumn(c)
 		}
 		views = append(views, view)
 	}
@@ -827,23 +1092,21 @@ func fkColumn(e *Edge, owner *schema.Table, refPK *schema.Column) *schema.Column
 	if e.Rel.fk != nil && e.Rel.fk.Field != nil {
 		fc := e.Rel.fk.Field.Column()
 		column.Comment, column.Default = fc.Comment, fc.Default
+		if e.Rel.fk.UserDefined {
+			column.FieldName = e.Rel.fk.Field.Name
+		}
 	}
 	return column
 }
 
 func addCompositePK(t *schema.Table, n *Type) error {
-	columns := make([]*schema.Column, 0, len(n.EdgeSchema.ID))
-	for _, id := range n.EdgeSchema.ID {
-		for _, f := range n.Fields {
-			if !f.IsEdgeField() || id != f {
-				continue
-			}
-			c, ok := t.Column(f.StorageKey())
-			if !ok {
-				return fmt.Errorf("missing column %q for edge field %q.%q", f.StorageKey(), n.Name, f.Name)
-			}
-			columns = append(columns, c)
+	columns := make([]*schema.Column, 0, len(n.CompositeID()))
+	for _, f := range n.CompositeID() {
+		c, ok := t.Column(f.StorageKey())
+		if !ok {
+			return fmt.Errorf("missing column %q for field %q.%q", f.StorageKey(), n.Name, f.Name)
 		}
+		columns = append(columns, c)
 	}
 	t.PrimaryKey = columns
 	return nil
@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/dialect/sql/outbox"
 	"entgo.io/ent/entc/load"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
@@ -217,6 +218,28 @@ func TestNewGraphDuplicateEdgeField(t *testing.T) {
 	require.EqualError(t, err, `entc/gen: User schema cannot contain field and edge with the same name "parent"`)
 }
 
+func TestNewGraphDuplicateEdgeEmbed(t *testing.T) {
+	type Address struct {
+		Street string
+	}
+	embed, err := load.NewField(field.Embed("address", Address{}).Descriptor())
+	require.NoError(t, err)
+	for name, wantErr := range map[string]string{
+		"address":        `entc/gen: User schema cannot contain embedded field and edge with the same name "address"`,
+		"address_street": `entc/gen: User schema cannot contain field and edge with the same name "address_street"`,
+	} {
+		_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]},
+			&load.Schema{
+				Name:   "User",
+				Fields: []*load.Field{embed},
+				Edges: []*load.Edge{
+					{Name: name, Type: "User", Unique: true},
+				},
+			})
+		require.EqualError(t, err, wantErr)
+	}
+}
+
 func TestNewGraphThroughUndefinedType(t *testing.T) {
 	_, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, &load.Schema{
 		Name: "T1",
@@ -259,6 +282,88 @@ func TestNewGraphThroughDuplicates(t *testing.T) {
 	require.EqualError(t, err, `entc/gen: resolving edges: edge User.groups defined with Through("group_edges", T1.Type), but schema User already has an edge named group_edges`)
 }
 
+func TestNewGraphDeleteActions(t *testing.T) {
+	onDelete := func(action edge.DeleteAction) map[string]any {
+		return map[string]any{edge.Annotation{}.Name(): edge.OnDelete(action)}
+	}
+	graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]},
+		&load.Schema{
+			Name: "User",
+			Edges: []*load.Edge{
+				{Name: "pets", Type: "Pet", Annotations: onDelete(edge.Cascade)},
+				{Name: "groups", Type: "Group", Annotations: onDelete(edge.SetNull)},
+			},
+		},
+		&load.Schema{
+			Name: "Pet",
+			Edges: []*load.Edge{
+				{Name: "owner", Type: "User", Unique: true, Inverse: true, RefName: "pets"},
+			},
+		},
+		&load.Schema{
+			Name: "Group",
+		},
+	)
+	require.NoError(t, err)
+	edges := graph.Nodes[0].DeleteActionEdges()
+	require.Len(t, edges, 2)
+	require.Equal(t, edge.Cascade, edges[0].OnDelete())
+	require.Equal(t, edge.SetNull, edges[1].OnDelete())
+	require.Empty(t, graph.Nodes[1].DeleteActionEdges())
+
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]},
+		&load.Schema{
+			Name: "User",
+			Edges: []*load.Edge{
+				{Name: "pets", Type: "Pet"},
+			},
+		},
+		&load.Schema{
+			Name: "Pet",
+			Edges: []*load.Edge{
+				{Name: "owner", Type: "User", Unique: true, Inverse: true, RefName: "pets", Annotations: onDelete(edge.Cascade)},
+			},
+		},
+	)
+	require.EqualError(t, err, `entc/gen: resolving delete actions: delete action "CASCADE" is not allowed on M2O edge Pet.owner`)
+
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]},
+		&load.Schema{
+			Name: "User",
+			Edges: []*load.Edge{
+				{Name: "pets", Type: "Pet", Annotations: onDelete(edge.SetNull)},
+			},
+		},
+		&load.Schema{
+			Name: "Pet",
+			Edges: []*load.Edge{
+				{Name: "owner", Type: "User", Unique: true, Required: true, Inverse: true, RefName: "pets"},
+			},
+		},
+	)
+	require.EqualError(t, err, `entc/gen: resolving delete actions: delete action "SET NULL" is not allowed on edge User.pets, because its inverse edge Pet.owner is required`)
+
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]},
+		&load.Schema{
+			Name: "User",
+			Edges: []*load.Edge{
+				{Name: "pets", Type: "User", Annotations: onDelete("NO ACTION")},
+			},
+		},
+	)
+	require.EqualError(t, err, `entc/gen: resolving delete actions: edge User.pets has an unknown delete action: "NO ACTION"`)
+
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[1]},
+		&load.Schema{
+			Name: "User",
+			Edges: []*load.Edge{
+				{Name: "pets", Type: "User", Annotations: onDelete(edge.Cascade)},
+			},
+		},
+	)
+	require.EqualError(t, err, `entc/gen: resolving delete actions: delete action "CASCADE" of edge User.pets is not supported by storage driver "gremlin"`)
+}
+
 func TestRelation(t *testing.T) {
 	require := require.New(t)
 	_, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, T1)
@@ -419,6 +524,217 @@ func TestMultiSchemaAnnotation(t *testing.T) {
 	require.Equal(t, "two", ts[4].Schema) // user<>cars edge has annotation and lives in specified schema
 }
 
+func TestOutboxTable(t *testing.T) {
+	user := &load.Schema{Name: "User"}
+	g, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0], Features: []Feature{FeatureOutbox}}, user)
+	require.NoError(t, err)
+	ts, err := g.Tables()
+	require.NoError(t, err)
+	require.Len(t, ts, 2)
+	require.Equal(t, "users", ts[0].Name)
+	require.Equal(t, outbox.Table, ts[1].Name)
+
+	g, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0], Features: []Feature{FeatureOutbox}}, &load.Schema{
+		Name:        "Outbox",
+		Annotations: map[string]any{entsql.Annotation{}.Name(): map[string]string{"table": outbox.Table}},
+	})
+	require.NoError(t, err)
+	_, err = g.Tables()
+	require.EqualError(t, err, `table name "ent_outbox" is reserved for the outbox table`)
+}
+
+func TestHistoryTable(t *testing.T) {
+	var (
+		history = map[string]any{entsql.Annotation{}.Name(): map[string]any{"history": true}}
+		user    = &load.Schema{
+			Name:        "User",
+			Annotations: history,
+			Fields: []*load.Field{
+				{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}, Unique: true},
+			},
+		}
+		pet = &load.Schema{
+			Name: "Pet",
+			Edges: []*load.Edge{
+				{Name: "owner", Type: "User", Unique: true},
+			},
+		}
+	)
+	g, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, user, pet)
+	require.NoError(t, err)
+	require.Equal(t, []*Type{g.Nodes[0]}, g.HistoryNodes())
+	require.Equal(t, "users_history", g.Nodes[0].HistoryTable())
+	require.False(t, g.Nodes[1].HasHistory())
+	ts, err := g.Tables()
+	require.NoError(t, err)
+	require.Len(t, ts, 3)
+	require.Equal(t, "User", ts[0].TypeName)
+	require.Equal(t, "id", ts[0].Columns[0].FieldName)
+	require.Equal(t, "name", ts[0].Columns[1].FieldName)
+	require.Equal(t, "Pet", ts[1].TypeName)
+	require.Empty(t, ts[1].Columns[1].FieldName, "foreign-key without an edge field")
+	require.Equal(t, "users_history", ts[2].Name)
+	require.Empty(t, ts[2].TypeName)
+	require.Len(t, ts[2].Columns, 6)
+	require.Equal(t, "name", ts[2].Columns[2].Name)
+	require.False(t, ts[2].Columns[2].Unique)
+	require.Empty(t, ts[2].ForeignKeys)
+
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, user, &load.Schema{Name: "UserHistory"})
+	require.EqualError(t, err, "entc/gen: resolving history tables: history of type User conflicts with type UserHistory")
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, &load.Schema{Name: "V", View: true, Annotations: history})
+	require.EqualError(t, err, "entc/gen: resolving history tables: history is not supported on view V")
+
+	g, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, user, &load.Schema{
+		Name:        "Audit",
+		Annotations: map[string]any{entsql.Annotation{}.Name(): map[string]string{"table": "users_history"}},
+	})
+	require.NoError(t, err)
+	_, err = g.Tables()
+	require.EqualError(t, err, `table name "users_history" is reserved for the history table of type User`)
+}
+
+func TestCompositeID(t *testing.T) {
+	id := func(fields ...string) map[string]any {
+		return map[string]any{field.Annotation{}.Name(): field.Annotation{ID: fields}}
+	}
+	var (
+		order = &load.Schema{
+			Name:        "Order",
+			Annotations: id("tenant_id", "number"),
+			Fields: []*load.Field{
+				{Name: "tenant_id", Info: &field.TypeInfo{Type: field.TypeInt}, Immutable: true},
+				{Name: "number", Info: &field.TypeInfo{Type: field.TypeString}, Immutable: true},
+				{Name: "customer_id", Info: &field.TypeInfo{Type: field.TypeInt}, Optional: true},
+				{Name: "note", Info: &field.TypeInfo{Type: field.TypeString}},
+			},
+			Edges: []*load.Edge{
+				{Name: "customer", Type: "Customer", RefName: "orders", Unique: true, Inverse: true, Field: "customer_id"},
+			},
+		}
+		customer = &load.Schema{
+			Name: "Customer",
+			Edges: []*load.Edge{
+				{Name: "orders", Type: "Order"},
+			},
+		}
+	)
+	g, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, order, customer)
+	require.NoError(t, err)
+	n := g.Nodes[0]
+	require.True(t, n.HasCompositeID())
+	require.False(t, n.HasOneFieldID())
+	require.False(t, n.IsEdgeSchema())
+	require.Nil(t, n.ID)
+	require.Equal(t, "OrderKey", n.KeyName())
+	require.Equal(t, []*Field{n.Fields[0], n.Fields[1]}, n.CompositeID())
+	require.Len(t, n.MutableFields(), 2)
+	require.Empty(t, g.Nodes[1].EdgesWithID())
+	ts, err := g.Tables()
+	require.NoError(t, err)
+	require.Len(t, ts[0].PrimaryKey, 2)
+	require.Equal(t, "tenant_id", ts[0].PrimaryKey[0].Name)
+	require.Equal(t, "number", ts[0].PrimaryKey[1].Name)
+	require.Equal(t, "customer_id", ts[0].ForeignKeys[0].Columns[0].Name)
+
+	for _, tt := range []struct {
+		ids     []string
+		wantErr string
+	}{
+		{ids: []string{"tenant_id"}, wantErr: "composite identifier of type Order must contain at least 2 fields"},
+		{ids: []string{"tenant_id", "name"}, wantErr: `composite identifier of type Order contains unknown field "name"`},
+		{ids: []string{"tenant_id", "tenant_id"}, wantErr: `composite identifier of type Order contains field "tenant_id" more than once`},
+		{ids: []string{"tenant_id", "customer_id"}, wantErr: "optional field Order.customer_id cannot be part of a composite identifier"},
+		{ids: []string{"tenant_id", "note"}, wantErr: "field Order.note must be immutable to be part of a composite identifier"},
+	} {
+		order.Annotations = id(tt.ids...)
+		_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, order, customer)
+		require.EqualError(t, err, "entc/gen: resolving composite identifiers: "+tt.wantErr)
+	}
+	order.Annotations = id("tenant_id", "number")
+	order.Edges = append(order.Edges, &load.Edge{Name: "items", Type: "Customer"})
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, order, customer)
+	require.EqualError(t, err, "entc/gen: resolving composite identifiers: edge Order.items is not supported, because its foreign key references type Order with a composite identifier")
+	order.Edges = order.Edges[:1]
+	customer.Edges = append(customer.Edges, &load.Edge{Name: "last", Type: "Order", Unique: true})
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, order, customer)
+	require.EqualError(t, err, "entc/gen: resolving composite identifiers: edge Customer.last is not supported, because its foreign key references type Order with a composite identifier")
+	customer.Edges = customer.Edges[:1]
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, order, customer, &load.Schema{Name: "OrderKey"})
+	require.EqualError(t, err, "entc/gen: resolving composite identifiers: composite identifier of type Order conflicts with type OrderKey")
+}
+
+func TestPartition(t *testing.T) {
+	partition := func(columns ...string) map[string]any {
+		return map[string]any{entsql.Annotation{}.Name(): map[string]any{"partition": map[string]any{"type": "RANGE", "columns": columns}}}
+	}
+	pet := &load.Schema{
+		Name:        "Pet",
+		Annotations: partition("created_at", "user_pets"),
+		Fields: []*load.Field{
+			{Name: "created_at", Info: &field.TypeInfo{Type: field.TypeTime}},
+		},
+	}
+	user := &load.Schema{
+		Name: "User",
+		Edges: []*load.Edge{
+			{Name: "pets", Type: "Pet"},
+		},
+	}
+	g, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, user, pet)
+	require.NoError(t, err)
+	ts, err := g.Tables()
+	require.NoError(t, err)
+	require.Equal(t, &entsql.PartitionOptions{Type: entsql.PartitionRange, Columns: []string{"created_at", "user_pets"}}, ts[1].Annotation.Partition)
+
+	pet.Annotations = partition("name")
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, user, pet)
+	require.EqualError(t, err, `entc/gen: resolving table partitions: partition column "name" was not found in type Pet`)
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, &load.Schema{Name: "V", View: true, Annotations: partition("id")})
+	require.EqualError(t, err, "entc/gen: resolving table partitions: partitioning is not supported on view V")
+}
+
+func TestRowSecurity(t *testing.T) {
+	rls := func(policies ...map[string]any) map[string]any {
+		return map[string]any{entsql.Annotation{}.Name(): map[string]any{"row_security": map[string]any{"policies": policies}}}
+	}
+	var (
+		user = &load.Schema{
+			Name: "User",
+			Annotations: rls(
+				map[string]any{"name": "tenant_isolation", "using": "tenant_id = current_setting('app.current_tenant')::integer"},
+				map[string]any{"name": "owner", "command": "UPDATE", "using": "current_user = 'admin'", "with_check": "owner_id = current_setting( 'app.user_id')::integer"},
+			),
+		}
+		pet = &load.Schema{
+			Name:        "Pet",
+			Annotations: rls(map[string]any{"name": "tenant_isolation", "using": "tenant_id = current_setting('app.current_tenant')::integer"}),
+		}
+	)
+	g, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, user, pet)
+	require.NoError(t, err)
+	require.Equal(t, []*SessionVar{
+		{Name: "app.current_tenant", Field: "AppCurrentTenant", Tables: []string{"users", "pets"}},
+		{Name: "app.user_id", Field: "AppUserID", Tables: []string{"users"}},
+	}, g.SessionVars())
+	require.Equal(t, "VarAppUserID", g.SessionVars()[1].Constant())
+	ts, err := g.Tables()
+	require.NoError(t, err)
+	require.Len(t, ts[0].Annotation.RowSecurity.Policies, 2)
+
+	pet.Annotations = rls(map[string]any{"name": "p", "using": "true"}, map[string]any{"name": "p", "using": "false"})
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, pet)
+	require.EqualError(t, err, `entc/gen: resolving row-level security policies: duplicate policy "p" for type Pet`)
+	pet.Annotations = rls(map[string]any{"name": "p"})
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, pet)
+	require.EqualError(t, err, `entc/gen: resolving row-level security policies: policy "p" of type Pet must define a USING or a WITH CHECK expression`)
+	pet.Annotations = rls(map[string]any{"name": "p", "using": "a = current_setting('app.user_id') AND b = current_setting('app.user-id')"})
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, pet)
+	require.EqualError(t, err, `entc/gen: resolving row-level security policies: session variables "app.user-id" and "app.user_id" have the same Go name AppUserID`)
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, &load.Schema{Name: "V", View: true, Annotations: rls()})
+	require.EqualError(t, err, "entc/gen: resolving row-level security policies: row-level security is not supported on view V")
+}
+
 func TestEnsureCorrectFK(t *testing.T) {
 	var (
 		user = &load.Schema{
@@ -65,6 +65,11 @@ var (
 			Cond:   notView,
 			Format: pkgf("%s_delete.go"),
 		},
+		{
+			Name:   "history",
+			Cond:   func(t *Type) bool { return t.HasHistory() },
+			Format: pkgf("%s_history.go"),
+		},
 		{
 			Name:   "query",
 			Format: pkgf("%s_query.go"),
@@ -154,6 +159,13 @@ var (
 				return !g.featureEnabled(FeatureEntQL)
 			},
 		},
+		{
+			Name:   "rowsecurity",
+			Format: "rowsecurity.go",
+			Skip: func(g *Graph) bool {
+				return len(g.SessionVars()) == 0
+			},
+		},
 		{
 			Name:   "runtime/ent",
 			Format: "runtime.go",
@@ -13,6 +13,7 @@ import (
 	"go/types"
 	"path"
 	"reflect"
+	"slices"
 	"sort"
 	"strings"
 	"unicode"
@@ -21,6 +22,8 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/entsql"
+	dialectsql "entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/history"
 	"entgo.io/ent/dialect/sql/schema"
 	"entgo.io/ent/entc/load"
 	entschema "entgo.io/ent/schema"
@@ -62,6 +65,30 @@ type (
 			ID       []*Field
 			To, From *Edge
 		}
+		// compositeID holds the fields of the multi-column identifier of
+		// types that are not edge schemas and were annotated with field.ID.
+		compositeID []*Field
+		// Embeds holds the embedded struct fields (value objects) of this type.
+		// Their struct members are expanded into Fields, one column per member.
+		Embeds []*Embed
+	}
+
+	// Embed holds the information of an embedded struct field that is stored
+	// in multiple columns. For example, field.Embed("address", Address{}).
+	Embed struct {
+		def *load.Field
+		// Name is the name of the embedded field in the schema.
+		Name string
+		// Type holds the type information of the embedded struct.
+		Type *field.TypeInfo
+		// Optional indicates that the embedded field is optional on create,
+		// and that its columns are nullable.
+		Optional bool
+		// Immutable indicates is this embedded field cannot be updated.
+		Immutable bool
+		// Fields holds the fields that were expanded from the struct members,
+		// ordered as they are defined in the struct.
+		Fields []*Field
 	}
 
 	// Field holds the information of a type field used for the templates.
@@ -102,6 +129,10 @@ type (
 		Annotations Annotations
 		// referenced foreign-key.
 		fk *ForeignKey
+		// embedded field that holds this field, and the
+		// name of the struct member the field represents.
+		embed  *Embed
+		member string
 	}
 
 	// Edge of a graph between two types.
@@ -237,24 +268,13 @@ func NewType(c *Config, schema *load.Schema) (*Type, error) {
 		return nil, err
 	}
 	for _, f := range schema.Fields {
-		tf := &Field{
-			cfg:           c,
-			def:           f,
-			typ:           typ,
-			Name:          f.Name,
-			Type:          f.Info,
-			Unique:        f.Unique,
-			Position:      f.Position,
-			Nillable:      f.Nillable,
-			Optional:      f.Optional,
-			Default:       f.Default,
-			UpdateDefault: f.UpdateDefault,
-			Immutable:     f.Immutable,
-			StructTag:     structTag(f.Name, f.Tag),
-			Validators:    f.Validators,
-			UserDefined:   true,
-			Annotations:   f.Annotations,
+		if len(f.Embedded) > 0 {
+			if err := typ.addEmbed(f); err != nil {
+				return nil, err
+			}
+			continue
 		}
+		tf := typ.newField(f)
 		if err := typ.checkField(tf, f); err != nil {
 			return nil, err
 		}
@@ -275,6 +295,82 @@ func NewType(c *Config, schema *load.Schema) (*Type, error) {
 	return typ, nil
 }
 
+// newField creates a new type field from the given schema field.
+func (t *Type) newField(f *load.Field) *Field {
+	return &Field{
+		cfg:           t.Config,
+		def:           f,
+		typ:           t,
+		Name:          f.Name,
+		Type:          f.Info,
+		Unique:        f.Unique,
+		Position:      f.Position,
+		Nillable:      f.Nillable,
+		Optional:      f.Optional,
+		Default:       f.Default,
+		UpdateDefault: f.UpdateDefault,
+		Immutable:     f.Immutable,
+		StructTag:     structTag(f.Name, f.Tag),
+		Validators:    f.Validators,
+		UserDefined:   true,
+		Annotations:   f.Annotations,
+	}
+}
+
+// entityMethods holds the names of the methods that are defined on the
+// generated entities, and therefore cannot be used by embedded fields.
+var entityMethods = map[string]bool{"Edges": true, "Update": true, "Unwrap": true, "String": true, "Value": true}
+
+// addEmbed expands the given embedded struct field into type fields. Each
+// struct member is stored in its own column that is prefixed with the name
+// of the embedded field. e.g. "address_street" for Address.Street.
+func (t *Type) addEmbed(f *load.Field) error {
+	e := &Embed{
+		def:       f,
+		Name:      f.Name,
+		Type:      f.Info,
+		Optional:  f.Optional,
+		Immutable: f.Immutable,
+	}
+	switch {
+	case f.Name == "":
+		return fmt.Errorf("field name cannot be empty")
+	case f.Info == nil || f.Info.RType == nil || f.Info.RType.Kind != reflect.Struct:
+		return fmt.Errorf("invalid type for embedded field %s", f.Name)
+	case t.fields[f.Name] != nil || t.embed(f.Name) != nil || t.ID != nil && t.ID.Name == f.Name:
+		return fmt.Errorf("field %q redeclared for type %q", f.Name, t.Name)
+	case entityMethods[e.StructField()]:
+		return fmt.Errorf("embedded field %q conflicts with the %s method of type %q", f.Name, e.StructField(), t.Name)
+	}
+	for _, m := range f.Embedded {
+		sf := *m
+		sf.Name = fmt.Sprintf("%s_%s", f.Name, snake(m.Name))
+		sf.Position = f.Position
+		sf.Optional, sf.Nillable = f.Optional, f.Optional
+		sf.Immutable = f.Immutable
+		tf := t.newField(&sf)
+		tf.embed, tf.member = e, m.Name
+		if err := t.checkField(tf, &sf); err != nil {
+			return err
+		}
+		e.Fields = append(e.Fields, tf)
+		t.Fields = append(t.Fields, tf)
+		t.fields[sf.Name] = tf
+	}
+	t.Embeds = append(t.Embeds, e)
+	return nil
+}
+
+// embed returns the embedded field with the given name, or nil if it does not exist.
+func (t Type) embed(name string) *Embed {
+	for _, e := range t.Embeds {
+		if e.Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
 // IsView indicates if the type (schema) is a view.
 func (t Type) IsView() bool {
 	return t.schema != nil && t.schema.View
@@ -288,7 +384,22 @@ func (t Type) IsEdgeSchema() bool {
 
 // HasCompositeID indicates if the type has a composite ID field.
 func (t Type) HasCompositeID() bool {
-	return t.IsEdgeSchema() && len(t.EdgeSchema.ID) > 1
+	return len(t.CompositeID()) > 1
+}
+
+// CompositeID returns the fields that compose the identifier of the
+// type. i.e. the edge fields of an edge schema, or the fields that were
+// configured using the field.ID annotation on other types.
+func (t Type) CompositeID() []*Field {
+	if t.IsEdgeSchema() {
+		return t.EdgeSchema.ID
+	}
+	return t.compositeID
+}
+
+// KeyName returns the struct name of the composite identifier of the type.
+func (t Type) KeyName() string {
+	return t.Name + "Key"
 }
 
 // HasOneFieldID indicates if the type has an ID with one field (not composite).
@@ -399,6 +510,63 @@ func (t Type) HasUpdateDefault() bool {
 	return false
 }
 
+// VersionField returns the field that is used for optimistic locking, if exists.
+// See entsql.OptimisticLock for more info.
+func (t Type) VersionField() *Field {
+	for _, f := range t.Fields {
+		if ant := f.EntSQL(); ant != nil && ant.OptimisticLock != nil {
+			return f
+		}
+	}
+	return nil
+}
+
+// SoftDeleteField returns the field that marks the entities of this type
+// as deleted, if exists. See entsql.SoftDelete for more info.
+func (t Type) SoftDeleteField() *Field {
+	for _, f := range t.Fields {
+		if ant := f.EntSQL(); ant != nil && ant.SoftDelete {
+			return f
+		}
+	}
+	return nil
+}
+
+// HasHistory reports if the row history of the type is recorded in
+// a history table. See entsql.History for more info.
+func (t Type) HasHistory() bool {
+	ant := t.EntSQL()
+	return ant != nil && ant.History && !ant.Skip
+}
+
+// HistoryTable returns the SQL table name of the type history.
+func (t Type) HistoryTable() string {
+	return history.TableName(t.Table())
+}
+
+// HistoryName returns the struct name of the type revisions.
+func (t Type) HistoryName() string {
+	return t.Name + "History"
+}
+
+// HistoryQueryName returns the struct name of the query-builder of the type revisions.
+func (t Type) HistoryQueryName() string {
+	return t.Name + "HistoryQuery"
+}
+
+// DeleteActionEdges returns the edges that were configured with a delete action
+// using the edge.OnDelete annotation. The actions are applied by the generated
+// Delete builders, in the order of the edges, before deleting the entities.
+func (t Type) DeleteActionEdges() []*Edge {
+	var edges []*Edge
+	for _, e := range t.Edges {
+		if e.OnDelete() != "" {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
 // HasOptional reports if this type has an optional field.
 func (t Type) HasOptional() bool {
 	for _, f := range t.Fields {
@@ -549,7 +717,7 @@ func (t Type) NumConstraint() int {
 func (t Type) MutableFields() []*Field {
 	fields := make([]*Field, 0, len(t.Fields))
 	for _, f := range t.Fields {
-		if f.Immutable {
+		if f.Immutable || f.IsGenerated() {
 			continue
 		}
 		if e, err := f.Edge(); err == nil && e.Immutable {
@@ -644,11 +812,14 @@ func (t Type) TagTypes() []string {
 // It fails if the schema index is invalid.
 func (t *Type) AddIndex(idx *load.Index) error {
 	index := &Index{Name: idx.StorageKey, Unique: idx.Unique, Annotations: idx.Annotations}
-	if len(idx.Fields) == 0 && len(idx.Edges) == 0 {
+	ant := sqlIndexAnnotate(idx.Annotations)
+	if len(idx.Fields) == 0 && len(idx.Edges) == 0 && (ant == nil || len(ant.Exprs) == 0) {
 		return errors.New("missing fields or edges")
 	}
-	switch ant := sqlIndexAnnotate(idx.Annotations); {
+	switch {
 	case ant == nil:
+	case len(idx.Fields) == 0 && len(idx.Edges) == 0 && idx.StorageKey == "":
+		return errors.New("expression index must define its name using the StorageKey option")
 	case len(ant.PrefixColumns) != 0 && ant.Prefix != 0:
 		return fmt.Errorf("index %q cannot contain both entsql.Prefix and entsql.PrefixColumn in annotation", index.Name)
 	case ant.Prefix != 0 && len(idx.Fields)+len(idx.Edges) != 1:
@@ -691,6 +862,13 @@ func (t *Type) AddIndex(idx *load.Index) error {
 		parts := append([]string{strings.ToLower(t.Name)}, index.Columns...)
 		index.Name = strings.Join(parts, "_")
 	}
+	if ant != nil {
+		for column := range ant.ExprColumns {
+			if !slices.Contains(index.Columns, column) {
+				return fmt.Errorf("index %q has entsql.IndexExprColumn for unknown column %q", index.Name, column)
+			}
+		}
+	}
 	t.Indexes = append(t.Indexes, index)
 	return nil
 }
@@ -1014,10 +1192,26 @@ func (t *Type) checkField(tf *Field, f *load.Field) (err error) {
 		err = fmt.Errorf("invalid type for field %s", f.Name)
 	case f.Unique && f.Default && f.DefaultKind != reflect.Func:
 		err = fmt.Errorf("unique field %q cannot have default value", f.Name)
-	case t.fields[f.Name] != nil:
+	case t.fields[f.Name] != nil || t.embed(f.Name) != nil:
 		err = fmt.Errorf("field %q redeclared for type %q", f.Name, t.Name)
 	case f.Sensitive && f.Tag != "":
 		err = fmt.Errorf("sensitive field %q cannot have struct tags", f.Name)
+	case ant != nil && ant.EnumType != "" && f.Info.Type != field.TypeEnum:
+		err = fmt.Errorf("native enum type %q cannot be defined on non-enum field %q", ant.EnumType, f.Name)
+	case ant != nil && ant.Array && (f.Info.Type != field.TypeJSON || pgArrayTypes[f.Info.Ident] == ""):
+		err = fmt.Errorf("array field %q must be a slice of strings, integers, floats or booleans", f.Name)
+	case ant != nil && ant.Array && t.Config != nil && t.Config.Storage != nil && t.Config.Storage.Name != "sql":
+		err = fmt.Errorf("array field %q is not supported by storage driver %q", f.Name, t.Config.Storage.Name)
+	case ant != nil && ant.DurationFormat != "" && f.Info.Type != field.TypeDuration:
+		err = fmt.Errorf("duration format %q cannot be defined on non-duration field %q", ant.DurationFormat, f.Name)
+	case ant != nil && ant.DurationFormat != "" && ant.DurationFormat != dialectsql.DurationNanoseconds && ant.DurationFormat != dialectsql.DurationTime:
+		err = fmt.Errorf("invalid duration format %q for field %q", ant.DurationFormat, f.Name)
+	case ant != nil && ant.Generated != nil && ant.Generated.Expr == "":
+		err = fmt.Errorf("generated field %q must have an expression", f.Name)
+	case ant != nil && ant.Generated != nil && (f.Default || f.UpdateDefault || ant.Default != "" || ant.DefaultExpr != "" || len(ant.DefaultExprs) > 0):
+		err = fmt.Errorf("generated field %q cannot have default values", f.Name)
+	case ant != nil && ant.Generated != nil && t.Config != nil && t.Config.Storage != nil && t.Config.Storage.Name != "sql":
+		err = fmt.Errorf("generated field %q is not supported by storage driver %q", f.Name, t.Config.Storage.Name)
 	case f.Info.Type == field.TypeEnum:
 		if tf.Enums, err = tf.enums(f); err == nil && !tf.HasGoType() {
 			// Enum types should be named as follows: typepkg.Field.
@@ -1029,10 +1223,52 @@ func (t *Type) checkField(tf *Field, f *load.Field) (err error) {
 		err = fmt.Errorf("field %q cannot have both default value and default expression annotations", f.Name)
 	case tf.HasValueScanner() && tf.IsJSON():
 		err = fmt.Errorf("json field %q cannot have an external ValueScanner", f.Name)
+	case ant != nil && ant.OptimisticLock != nil:
+		err = t.checkVersionField(tf, f)
+	case ant != nil && ant.SoftDelete:
+		err = t.checkSoftDeleteField(tf, f)
 	}
 	return err
 }
 
+// checkSoftDeleteField checks the field that marks the entities as deleted.
+func (t *Type) checkSoftDeleteField(tf *Field, f *load.Field) error {
+	switch {
+	case f.Info.Type != field.TypeTime:
+		return fmt.Errorf("soft delete field %q must be a time field", f.Name)
+	case !f.Optional || f.Immutable:
+		return fmt.Errorf("soft delete field %q must be optional and mutable", f.Name)
+	case t.IsView():
+		return fmt.Errorf("soft delete field %q cannot be defined on view %q", f.Name, t.Name)
+	case t.Config != nil && t.Storage != nil && t.Storage.Name != "sql":
+		return fmt.Errorf("soft delete field %q is supported only by the sql storage", f.Name)
+	}
+	for _, f2 := range t.fields {
+		if ant := f2.EntSQL(); ant != nil && ant.SoftDelete {
+			return fmt.Errorf("type %q cannot have more than one soft delete field: %q, %q", t.Name, f2.Name, tf.Name)
+		}
+	}
+	return nil
+}
+
+// checkVersionField checks the field that is used for optimistic locking.
+func (t *Type) checkVersionField(tf *Field, f *load.Field) error {
+	switch {
+	case !tf.SupportsMutationAdd() || !f.Info.Type.Integer():
+		return fmt.Errorf("optimistic lock field %q must be an integer", f.Name)
+	case f.Nillable || f.Immutable:
+		return fmt.Errorf("optimistic lock field %q cannot be nillable or immutable", f.Name)
+	case t.IsView():
+		return fmt.Errorf("optimistic lock field %q cannot be defined on view %q", f.Name, t.Name)
+	}
+	for _, f2 := range t.fields {
+		if ant := f2.EntSQL(); ant != nil && ant.OptimisticLock != nil {
+			return fmt.Errorf("type %q cannot have more than one optimistic lock field: %q, %q", t.Name, f2.Name, tf.Name)
+		}
+	}
+	return nil
+}
+
 // UnexportedForeignKeys returns all foreign-keys that belong to the type
 // but are not exported (not defined with field). i.e. generated by ent.
 func (t Type) UnexportedForeignKeys() []*ForeignKey {
@@ -1286,6 +1522,10 @@ func (f Field) MutationAppended() string {
 // A field can be required in one database, but optional in the other. e.g.,
 // in case a SchemaType was defined as "serial" for PostgreSQL, but "int" for SQLite.
 func (f Field) RequiredFor() (dialects []string) {
+	// Generated columns are computed by the database.
+	if f.IsGenerated() {
+		return nil
+	}
 	seen := make(map[string]struct{})
 	switch f.def.SchemaType[dialect.Postgres] {
 	case postgres.TypeSerial, postgres.TypeBigSerial, postgres.TypeSmallSerial:
@@ -1323,6 +1563,47 @@ func (f Field) IsTime() bool { return f.Type != nil && f.Type.Type == field.Type
 // IsJSON returns true if the field is a JSON field.
 func (f Field) IsJSON() bool { return f.Type != nil && f.Type.Type == field.TypeJSON }
 
+// IsArray returns true if the field is stored as a native array in PostgreSQL (see entsql.Array).
+func (f Field) IsArray() bool {
+	ant := f.EntSQL()
+	return f.IsJSON() && ant != nil && ant.Array
+}
+
+// IsDuration returns true if the field is a duration field.
+func (f Field) IsDuration() bool { return f.Type != nil && f.Type.Type == field.TypeDuration }
+
+// DurationValue returns the expression for wrapping the given duration identifier
+// with sql.Duration (or sql.Durations for slices), using the format of the field.
+func (f Field) DurationValue(ident string, slice ...bool) string {
+	fn := "Duration"
+	if len(slice) > 0 && slice[0] {
+		fn = "Durations"
+	}
+	switch ant := f.EntSQL(); {
+	case ant == nil || ant.DurationFormat == "":
+		return fmt.Sprintf("sql.%s(%s)", fn, ident)
+	case ant.DurationFormat == dialectsql.DurationTime:
+		return fmt.Sprintf("sql.%s(%s, sql.DurationTime)", fn, ident)
+	default:
+		return fmt.Sprintf("sql.%s(%s, sql.DurationNanoseconds)", fn, ident)
+	}
+}
+
+// ArrayElem returns the element type of an array field.
+func (f Field) ArrayElem() string { return strings.TrimPrefix(f.Type.String(), "[]") }
+
+// pgArrayTypes maps the types of array fields to their PostgreSQL column types.
+var pgArrayTypes = map[string]string{
+	"[]string":  "text[]",
+	"[]int":     "bigint[]",
+	"[]int64":   "bigint[]",
+	"[]int32":   "integer[]",
+	"[]int16":   "smallint[]",
+	"[]float64": "double precision[]",
+	"[]float32": "real[]",
+	"[]bool":    "boolean[]",
+}
+
 // IsOther returns true if the field is an Other field.
 func (f Field) IsOther() bool { return f.Type != nil && f.Type.Type == field.TypeOther }
 
@@ -1345,6 +1626,13 @@ func (f Field) IsEnum() bool { return f.Type != nil && f.Type.Type == field.Type
 // that was referenced by one of the edges.
 func (f Field) IsEdgeField() bool { return f.fk != nil }
 
+// IsGenerated reports if the field is a generated column, whose value
+// is computed by the database. See entsql.Generated for more info.
+func (f Field) IsGenerated() bool {
+	ant := f.EntSQL()
+	return ant != nil && ant.Generated != nil
+}
+
 // IsDeprecated returns true if the field is deprecated.
 func (f Field) IsDeprecated() bool { return f.def != nil && f.def.Deprecated }
 
@@ -1400,12 +1688,14 @@ func (f Field) ScanType() string {
 	switch f.Type.Type {
 	case field.TypeJSON, field.TypeBytes:
 		return "[]byte"
-	case field.TypeString, field.TypeEnum:
+	case field.TypeString, field.TypeEnum, field.TypeDecimal:
 		return "sql.NullString"
 	case field.TypeBool:
 		return "sql.NullBool"
 	case field.TypeTime:
 		return "sql.NullTime"
+	case field.TypeDuration:
+		return "sql.NullDuration"
 	case field.TypeInt, field.TypeInt8, field.TypeInt16, field.TypeInt32, field.TypeInt64,
 		field.TypeUint, field.TypeUint8, field.TypeUint16, field.TypeUint32, field.TypeUint64:
 		return "sql.NullInt64"
@@ -1436,6 +1726,17 @@ func (t Type) DeprecatedFields() []*Field {
 	return fs
 }
 
+// GeneratedFields returns all generated fields of the type.
+func (t Type) GeneratedFields() []*Field {
+	fs := make([]*Field, 0, len(t.Fields))
+	for _, f := range t.Fields {
+		if f.IsGenerated() {
+			fs = append(fs, f)
+		}
+	}
+	return fs
+}
+
 // HasValueScanner indicates if the field has (an external) ValueScanner.
 func (f Field) HasValueScanner() bool {
 	return f.def != nil && f.def.ValueScanner
@@ -1485,12 +1786,14 @@ func (f Field) NewScanType() string {
 	switch f.Type.Type {
 	case field.TypeJSON, field.TypeBytes:
 		expr = "[]byte"
-	case field.TypeString, field.TypeEnum:
+	case field.TypeString, field.TypeEnum, field.TypeDecimal:
 		expr = "sql.NullString"
 	case field.TypeBool:
 		expr = "sql.NullBool"
 	case field.TypeTime:
 		expr = "sql.NullTime"
+	case field.TypeDuration:
+		expr = "sql.NullDuration"
 	case field.TypeInt, field.TypeInt8, field.TypeInt16, field.TypeInt32, field.TypeInt64,
 		field.TypeUint, field.TypeUint8, field.TypeUint16, field.TypeUint32, field.TypeUint64:
 		expr = "sql.NullInt64"
@@ -1521,10 +1824,12 @@ func (f Field) ScanTypeField(rec string) string {
 	switch f.Type.Type {
 	case field.TypeEnum:
 		expr = fmt.Sprintf("%s(%s.String)", f.Type, rec)
-	case field.TypeString, field.TypeBool, field.TypeInt64, field.TypeFloat64:
+	case field.TypeString, field.TypeBool, field.TypeInt64, field.TypeFloat64, field.TypeDecimal:
 		expr = f.goType(fmt.Sprintf("%s.%s", rec, strings.Title(f.Type.Type.String())))
 	case field.TypeTime:
 		expr = fmt.Sprintf("%s.Time", rec)
+	case field.TypeDuration:
+		expr = fmt.Sprintf("%s.Duration", rec)
 	case field.TypeFloat32:
 		expr = fmt.Sprintf("%s(%s.Float64)", f.Type, rec)
 	case field.TypeInt, field.TypeInt8, field.TypeInt16, field.TypeInt32,
@@ -1569,6 +1874,9 @@ func (f Field) Column() *schema.Column {
 		Enums:    f.EnumValues(),
 		Comment:  f.sqlComment(),
 	}
+	if f.def != nil {
+		c.Precision, c.Scale = int64(f.def.Precision), int64(f.def.Scale)
+	}
 	switch {
 	case f.Default && (f.Type.Numeric() || f.Type.Type == field.TypeBool):
 		c.Default = f.DefaultValue()
@@ -1597,9 +1905,39 @@ func (f Field) Column() *schema.Column {
 	if ant := f.EntSQL(); ant != nil && ant.Collation != "" {
 		c.Collation = ant.Collation
 	}
+	if ant := f.EntSQL(); ant != nil && ant.EnumType != "" {
+		c.EnumType = ant.EnumType
+	}
+	if ant := f.EntSQL(); ant != nil && ant.Generated != nil {
+		c.Generated = &schema.GeneratedExpr{Expr: ant.Generated.Expr, Type: string(ant.Generated.Type)}
+	}
 	if f.def != nil {
 		c.SchemaType = f.def.SchemaType
 	}
+	// Array fields are stored as native arrays in PostgreSQL,
+	// unless their type was defined explicitly by the schema.
+	if t := pgArrayTypes[f.Type.String()]; f.IsArray() && c.SchemaType[dialect.Postgres] == "" {
+		st := map[string]string{dialect.Postgres: t}
+		for k, v := range c.SchemaType {
+			st[k] = v
+		}
+		c.SchemaType = st
+	}
+	// Duration fields are stored as interval in PostgreSQL and as bigint in other
+	// dialects, unless their format or type was defined explicitly by the schema.
+	if ant := f.EntSQL(); f.IsDuration() && ant != nil && ant.DurationFormat != "" {
+		d, t := dialect.Postgres, "bigint"
+		if ant.DurationFormat == dialectsql.DurationTime {
+			d, t = dialect.MySQL, "time(6)"
+		}
+		if c.SchemaType[d] == "" {
+			st := map[string]string{d: t}
+			for k, v := range c.SchemaType {
+				st[k] = v
+			}
+			c.SchemaType = st
+		}
+	}
 	return c
 }
 
@@ -1725,8 +2063,13 @@ func (f Field) SignedType() (*field.TypeInfo, error) {
 
 // SupportsMutationAdd reports if the field supports the mutation "Add(T) T" interface.
 func (f Field) SupportsMutationAdd() bool {
-	if !f.Type.Numeric() || f.IsEdgeField() {
+	switch {
+	case !f.Type.Numeric() || f.IsEdgeField() || f.IsGenerated():
 		return false
+	case f.Type.Type == field.TypeDecimal:
+		// Decimal values are represented as strings by default,
+		// and therefore, can be summed only by their Go type.
+		return f.implementsAdder()
 	}
 	return f.ConvertedToBasic() || f.implementsAdder()
 }
@@ -1764,7 +2107,7 @@ func rtypeEqual(t1, t2 *field.RType) bool {
 
 // SupportsMutationAppend reports if the field supports the mutation append operation.
 func (f Field) SupportsMutationAppend() bool {
-	return f.IsJSON() && f.Type.RType != nil && f.Type.RType.Kind == reflect.Slice
+	return !f.IsGenerated() && f.IsJSON() && f.Type.RType != nil && f.Type.RType.Kind == reflect.Slice
 }
 
 var (
@@ -1816,7 +2159,7 @@ func (f Field) BasicType(ident string) (expr string) {
 		case rt.Kind == reflect.Struct:
 			expr = fmt.Sprintf("time.Time(%s)", ident)
 		}
-	case field.TypeString:
+	case field.TypeString, field.TypeDecimal:
 		switch {
 		case rt.Kind == reflect.String:
 			expr = fmt.Sprintf("string(%s)", ident)
@@ -1883,6 +2226,52 @@ func (f *Field) Ops() []Op {
 	return ops
 }
 
+// Embed returns the embedded field that holds this field,
+// or nil if the field was not expanded from a struct member.
+func (f Field) Embed() *Embed { return f.embed }
+
+// EmbedMember returns the name of the struct member that this field
+// represents in its embedded field. e.g. "Street" for Address.Street.
+func (f Field) EmbedMember() string { return f.member }
+
+// StructField returns the name of the getter method of the embedded field in the model.
+func (e Embed) StructField() string { return pascal(e.Name) }
+
+// Comment returns the comment of the embedded field.
+func (e Embed) Comment() string { return e.def.Comment }
+
+// MutationGet returns the method name for getting the embedded value in the mutation.
+func (e Embed) MutationGet() string {
+	name := e.StructField()
+	if mutMethods[name] {
+		name = "Get" + name
+	}
+	return name
+}
+
+// MutationSet returns the method name for setting the embedded value in the mutation.
+func (e Embed) MutationSet() string {
+	name := "Set" + e.StructField()
+	if mutMethods[name] {
+		name += "Field"
+	}
+	return name
+}
+
+// MutationReset returns the method name for resetting the embedded value in the mutation.
+func (e Embed) MutationReset() string {
+	name := "Reset" + e.StructField()
+	if mutMethods[name] {
+		name += "Field"
+	}
+	return name
+}
+
+// MutationClear returns the method name for clearing the embedded value in the mutation.
+func (e Embed) MutationClear() string {
+	return "Clear" + e.StructField()
+}
+
 // Label returns the Gremlin label name of the edge.
 // If the edge is inverse
 func (e Edge) Label() string {
@@ -2146,6 +2535,19 @@ func (e Edge) EntSQL() *entsql.Annotation {
 	return sqlAnnotate(e.Annotations)
 }
 
+// OnDelete returns the action that is applied by the generated Delete builders
+// on the neighbors of the edge, or an empty string if it was not configured.
+func (e Edge) OnDelete() edge.DeleteAction {
+	ant := &edge.Annotation{}
+	if e.Annotations == nil || e.Annotations[ant.Name()] == nil {
+		return ""
+	}
+	if buf, err := json.Marshal(e.Annotations[ant.Name()]); err == nil {
+		_ = json.Unmarshal(buf, ant)
+	}
+	return ant.OnDelete
+}
+
 // Index returns the index of the edge in the schema.
 // Used mainly to extract its position in the "loadedTypes" array.
 func (e Edge) Index() (int, error) {
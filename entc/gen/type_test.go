@@ -6,7 +6,12 @@ package gen
 
 import (
 	"testing"
+	"time"
 
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/schema"
 	"entgo.io/ent/entc/load"
 	"entgo.io/ent/schema/field"
 
@@ -86,6 +91,319 @@ func TestType(t *testing.T) {
 	require.EqualError(err, "schema name conflicts with ent predeclared identifier \"Value\"")
 }
 
+func TestType_VersionField(t *testing.T) {
+	lock := map[string]any{
+		entsql.Annotation{}.Name(): entsql.OptimisticLock(),
+	}
+	typ, err := NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}},
+			{Name: "version", Info: &field.TypeInfo{Type: field.TypeInt64}, Annotations: lock},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "version", typ.VersionField().Name)
+
+	typ, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}},
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, typ.VersionField())
+
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "version", Info: &field.TypeInfo{Type: field.TypeString}, Annotations: lock},
+		},
+	})
+	require.EqualError(t, err, `optimistic lock field "version" must be an integer`)
+
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "version", Info: &field.TypeInfo{Type: field.TypeInt}, Nillable: true, Annotations: lock},
+		},
+	})
+	require.EqualError(t, err, `optimistic lock field "version" cannot be nillable or immutable`)
+
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "v1", Info: &field.TypeInfo{Type: field.TypeInt}, Annotations: lock},
+			{Name: "v2", Info: &field.TypeInfo{Type: field.TypeInt}, Annotations: lock},
+		},
+	})
+	require.EqualError(t, err, `type "T" cannot have more than one optimistic lock field: "v1", "v2"`)
+}
+
+func TestType_SoftDeleteField(t *testing.T) {
+	soft := map[string]any{
+		entsql.Annotation{}.Name(): entsql.SoftDelete(),
+	}
+	typ, err := NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}},
+			{Name: "deleted_at", Info: &field.TypeInfo{Type: field.TypeTime}, Optional: true, Nillable: true, Annotations: soft},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "deleted_at", typ.SoftDeleteField().Name)
+
+	typ, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}},
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, typ.SoftDeleteField())
+
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "deleted", Info: &field.TypeInfo{Type: field.TypeBool}, Optional: true, Annotations: soft},
+		},
+	})
+	require.EqualError(t, err, `soft delete field "deleted" must be a time field`)
+
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "deleted_at", Info: &field.TypeInfo{Type: field.TypeTime}, Annotations: soft},
+		},
+	})
+	require.EqualError(t, err, `soft delete field "deleted_at" must be optional and mutable`)
+
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "d1", Info: &field.TypeInfo{Type: field.TypeTime}, Optional: true, Annotations: soft},
+			{Name: "d2", Info: &field.TypeInfo{Type: field.TypeTime}, Optional: true, Annotations: soft},
+		},
+	})
+	require.EqualError(t, err, `type "T" cannot have more than one soft delete field: "d1", "d2"`)
+}
+
+func TestType_GeneratedField(t *testing.T) {
+	generated := map[string]any{
+		entsql.Annotation{}.Name(): entsql.Generated("price * quantity", entsql.Stored),
+	}
+	typ, err := NewType(&Config{Package: "entc/gen", Storage: drivers[0]}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "price", Info: &field.TypeInfo{Type: field.TypeInt}},
+			{Name: "total", Info: &field.TypeInfo{Type: field.TypeInt}, Annotations: generated},
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, typ.Fields[0].IsGenerated())
+	require.True(t, typ.Fields[1].IsGenerated())
+	require.Equal(t, []*Field{typ.Fields[1]}, typ.GeneratedFields())
+	require.Empty(t, typ.Fields[1].RequiredFor())
+	require.False(t, typ.Fields[1].SupportsMutationAdd())
+	require.Equal(t, []*Field{typ.Fields[0]}, typ.MutableFields())
+	require.Equal(t, &schema.GeneratedExpr{Expr: "price * quantity", Type: "STORED"}, typ.Fields[1].Column().Generated)
+
+	_, err = NewType(&Config{Package: "entc/gen", Storage: drivers[0]}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "total", Info: &field.TypeInfo{Type: field.TypeInt}, Default: true, DefaultValue: 1, Annotations: generated},
+		},
+	})
+	require.EqualError(t, err, `generated field "total" cannot have default values`)
+
+	_, err = NewType(&Config{Package: "entc/gen", Storage: drivers[1]}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "total", Info: &field.TypeInfo{Type: field.TypeInt}, Annotations: generated},
+		},
+	})
+	require.EqualError(t, err, `generated field "total" is not supported by storage driver "gremlin"`)
+}
+
+func TestType_EnumTypeField(t *testing.T) {
+	status := map[string]any{
+		entsql.Annotation{}.Name(): entsql.EnumType("status"),
+	}
+	typ, err := NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "status", Info: &field.TypeInfo{Type: field.TypeEnum}, Enums: []struct{ N, V string }{{V: "active"}, {V: "inactive"}}, Annotations: status},
+		},
+	})
+	require.NoError(t, err)
+	c := typ.Fields[0].Column()
+	require.Equal(t, "status", c.EnumType)
+	require.Equal(t, []string{"active", "inactive"}, c.Enums)
+
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "status", Info: &field.TypeInfo{Type: field.TypeString}, Annotations: status},
+		},
+	})
+	require.EqualError(t, err, `native enum type "status" cannot be defined on non-enum field "status"`)
+}
+
+func TestType_ArrayField(t *testing.T) {
+	array := map[string]any{
+		entsql.Annotation{}.Name(): entsql.Array(),
+	}
+	typ, err := NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "tags", Info: &field.TypeInfo{Type: field.TypeJSON, Ident: "[]string"}, Annotations: array},
+			{Name: "ids", Info: &field.TypeInfo{Type: field.TypeJSON, Ident: "[]int"}, Annotations: array, SchemaType: map[string]string{dialect.Postgres: "integer[]", dialect.MySQL: "json"}},
+			{Name: "meta", Info: &field.TypeInfo{Type: field.TypeJSON, Ident: "[]string"}},
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, typ.Fields[0].IsArray())
+	require.Equal(t, "string", typ.Fields[0].ArrayElem())
+	require.Equal(t, map[string]string{dialect.Postgres: "text[]"}, typ.Fields[0].Column().SchemaType)
+	require.Equal(t, map[string]string{dialect.Postgres: "integer[]", dialect.MySQL: "json"}, typ.Fields[1].Column().SchemaType)
+	require.False(t, typ.Fields[2].IsArray())
+	require.Nil(t, typ.Fields[2].Column().SchemaType)
+
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "meta", Info: &field.TypeInfo{Type: field.TypeJSON, Ident: "map[string]interface {}"}, Annotations: array},
+		},
+	})
+	require.EqualError(t, err, `array field "meta" must be a slice of strings, integers, floats or booleans`)
+}
+
+func TestType_DecimalField(t *testing.T) {
+	typ, err := NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "amount", Info: &field.TypeInfo{Type: field.TypeDecimal}, Precision: 10, Scale: 2},
+		},
+	})
+	require.NoError(t, err)
+	f := typ.Fields[0]
+	require.Equal(t, "string", f.Type.String())
+	require.Equal(t, "sql.NullString", f.ScanType())
+	require.Equal(t, "v.String", f.ScanTypeField("v"))
+	require.False(t, f.SupportsMutationAdd(), "string values cannot be summed")
+	c := f.Column()
+	require.Equal(t, field.TypeDecimal, c.Type)
+	require.Equal(t, int64(10), c.Precision)
+	require.Equal(t, int64(2), c.Scale)
+}
+
+func TestType_DurationField(t *testing.T) {
+	info := &field.TypeInfo{Type: field.TypeDuration, PkgPath: "time"}
+	typ, err := NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "timeout", Info: info, Default: true, DefaultValue: int64(time.Minute)},
+			{Name: "delay", Info: info, Annotations: map[string]any{
+				entsql.Annotation{}.Name(): entsql.DurationFormat(sql.DurationTime),
+			}},
+			{Name: "ttl", Info: info, Annotations: map[string]any{
+				entsql.Annotation{}.Name(): entsql.DurationFormat(sql.DurationNanoseconds),
+			}},
+		},
+	})
+	require.NoError(t, err)
+	f := typ.Fields[0]
+	require.True(t, f.IsDuration())
+	require.Equal(t, "time.Duration", f.Type.String())
+	require.Equal(t, "sql.NullDuration", f.ScanType())
+	require.Equal(t, "value.Duration", f.ScanTypeField("value"))
+	require.True(t, f.SupportsMutationAdd())
+	require.Equal(t, "sql.Duration(v)", f.DurationValue("v"))
+	require.Equal(t, "sql.Durations(vs)", f.DurationValue("vs", true))
+	c := f.Column()
+	require.Equal(t, field.TypeDuration, c.Type)
+	require.Equal(t, int64(time.Minute), c.Default)
+	require.Nil(t, c.SchemaType)
+
+	require.Equal(t, "sql.Duration(v, sql.DurationTime)", typ.Fields[1].DurationValue("v"))
+	require.Equal(t, map[string]string{dialect.MySQL: "time(6)"}, typ.Fields[1].Column().SchemaType)
+	require.Equal(t, "sql.Duration(v, sql.DurationNanoseconds)", typ.Fields[2].DurationValue("v"))
+	require.Equal(t, map[string]string{dialect.Postgres: "bigint"}, typ.Fields[2].Column().SchemaType)
+
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "timeout", Info: &field.TypeInfo{Type: field.TypeInt64}, Annotations: map[string]any{
+				entsql.Annotation{}.Name(): entsql.DurationFormat(sql.DurationTime),
+			}},
+		},
+	})
+	require.EqualError(t, err, `duration format "time" cannot be defined on non-duration field "timeout"`)
+}
+
+func TestType_EmbedField(t *testing.T) {
+	type Address struct {
+		Street  string
+		ZipCode string
+	}
+	embed := func(fd *field.Descriptor) *load.Field {
+		f, err := load.NewField(fd)
+		require.NoError(t, err)
+		return f
+	}
+	typ, err := NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}},
+			embed(field.Embed("address", Address{}).Optional().Descriptor()),
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, typ.Fields, 3)
+	require.Len(t, typ.Embeds, 1)
+	e := typ.Embeds[0]
+	require.Equal(t, "Address", e.StructField())
+	require.Equal(t, "gen.Address", e.Type.String())
+	require.Equal(t, "SetAddress", e.MutationSet())
+	require.Equal(t, "ClearAddress", e.MutationClear())
+	require.Equal(t, []*Field{typ.Fields[1], typ.Fields[2]}, e.Fields)
+	for i, name := range []string{"address_street", "address_zip_code"} {
+		f := typ.Fields[i+1]
+		require.Equal(t, name, f.Name)
+		require.Equal(t, e, f.Embed())
+		require.True(t, f.Optional)
+		require.True(t, f.Nillable)
+		require.True(t, f.Column().Nullable)
+	}
+	require.Equal(t, "ZipCode", typ.Fields[2].EmbedMember())
+	require.Nil(t, typ.Fields[0].Embed())
+
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			embed(field.Embed("address", Address{}).Descriptor()),
+			{Name: "address_street", Info: &field.TypeInfo{Type: field.TypeString}},
+		},
+	})
+	require.EqualError(t, err, `field "address_street" redeclared for type "T"`)
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "address", Info: &field.TypeInfo{Type: field.TypeString}},
+			embed(field.Embed("address", Address{}).Descriptor()),
+		},
+	})
+	require.EqualError(t, err, `field "address" redeclared for type "T"`)
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			embed(field.Embed("value", Address{}).Descriptor()),
+		},
+	})
+	require.EqualError(t, err, `embedded field "value" conflicts with the Value method of type "T"`)
+}
+
 func TestType_Label(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -224,6 +542,19 @@ func TestType_AddIndex(t *testing.T) {
 
 	err = typ.AddIndex(&load.Index{Unique: true, Fields: []string{"name"}, Edges: []string{"owner"}})
 	require.NoError(t, err, "valid index on M2O relation and field")
+
+	lower := map[string]any{entsql.IndexAnnotation{}.Name(): entsql.IndexExprs("lower(name)")}
+	err = typ.AddIndex(&load.Index{Unique: true, Annotations: lower})
+	require.EqualError(t, err, "expression index must define its name using the StorageKey option")
+
+	err = typ.AddIndex(&load.Index{Unique: true, StorageKey: "user_lower_name", Annotations: lower})
+	require.NoError(t, err, "valid index defined only on expressions")
+
+	err = typ.AddIndex(&load.Index{Fields: []string{"name"}, Annotations: map[string]any{entsql.IndexAnnotation{}.Name(): entsql.IndexExprColumn("text", "lower(text)")}})
+	require.EqualError(t, err, `index "user_name" has entsql.IndexExprColumn for unknown column "text"`)
+
+	err = typ.AddIndex(&load.Index{Fields: []string{"name", "text"}, Annotations: map[string]any{entsql.IndexAnnotation{}.Name(): entsql.IndexExprColumn("text", "lower(text)")}})
+	require.NoError(t, err, "valid index with expression column")
 }
 
 func TestField_Constant(t *testing.T) {
@@ -6,9 +6,13 @@ package cascadelete
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"entgo.io/ent/entc/integration/cascadelete/ent"
+	"entgo.io/ent/entc/integration/cascadelete/ent/comment"
+	"entgo.io/ent/entc/integration/cascadelete/ent/migrate"
+	"entgo.io/ent/entc/integration/cascadelete/ent/user"
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/require"
@@ -39,3 +43,62 @@ func TestCascadeDelete(t *testing.T) {
 	require.Equal(t, posts[0].ID, client.Post.Query().OnlyIDX(ctx))
 	require.Equal(t, comments[0].ID, client.Comment.Query().OnlyIDX(ctx))
 }
+
+func TestDeleteActions(t *testing.T) {
+	for name, fk := range map[string]bool{"WithForeignKeys": true, "WithoutForeignKeys": false} {
+		t.Run(name, func(t *testing.T) {
+			client, err := ent.Open("sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", name))
+			require.NoError(t, err)
+			defer client.Close()
+			// Without foreign keys, the delete actions are applied only by the generated code.
+			require.NoError(t, client.Schema.Create(context.Background(), migrate.WithForeignKeys(fk)))
+			DeleteActions(t, client)
+		})
+	}
+}
+
+func DeleteActions(t *testing.T, client *ent.Client) {
+	ctx := context.Background()
+	author := client.User.Create().SaveX(ctx)
+	post := client.Post.Create().SaveX(ctx)
+	root := client.Comment.Create().SetText("Go").SetPost(post).SetAuthor(author).SaveX(ctx)
+	reply := client.Comment.Create().SetText("Ent").SetPost(post).SetParent(root).SaveX(ctx)
+	client.Comment.Create().SetText("GraphQL").SetPost(post).SetParent(reply).ExecX(ctx)
+	other := client.Comment.Create().SetText("gRPC").SetPost(post).SaveX(ctx)
+
+	t.Log("Authors of comments cannot be deleted (RESTRICT)")
+	err := client.User.DeleteOne(author).Exec(ctx)
+	require.True(t, ent.IsConstraintError(err))
+	require.True(t, client.User.Query().Where(user.ID(author.ID)).ExistX(ctx))
+
+	t.Log("Delete the root comment with its replies, recursively (CASCADE)")
+	client.Comment.DeleteOne(root).ExecX(ctx)
+	require.Equal(t, other.ID, client.Comment.Query().OnlyIDX(ctx))
+	client.User.DeleteOne(author).ExecX(ctx)
+
+	t.Log("Delete a post and detach its tags (SET NULL)")
+	tags := client.Tag.CreateBulk(
+		client.Tag.Create().SetName("go"),
+		client.Tag.Create().SetName("ent"),
+	).SaveX(ctx)
+	posts := client.Post.CreateBulk(
+		client.Post.Create().AddTags(tags...),
+		client.Post.Create().AddTags(tags[0]),
+	).SaveX(ctx)
+	client.Post.DeleteOne(posts[0]).ExecX(ctx)
+	require.Equal(t, 2, client.Tag.Query().CountX(ctx))
+	require.Equal(t, posts[1].ID, tags[0].QueryPosts().OnlyIDX(ctx))
+	require.Zero(t, tags[1].QueryPosts().CountX(ctx))
+
+	t.Log("Delete actions of many entities are applied in chunks")
+	client.Comment.Delete().ExecX(ctx)
+	roots := client.Comment.MapCreateBulk(make([]int, 1500), func(c *ent.CommentCreate, _ int) {
+		c.SetText("root").SetPost(posts[1])
+	}).SaveX(ctx)
+	client.Comment.MapCreateBulk(roots, func(c *ent.CommentCreate, i int) {
+		c.SetText("reply").SetPost(posts[1]).SetParent(roots[i])
+	}).ExecX(ctx)
+	n := client.Comment.Delete().Where(comment.Text("root")).ExecX(ctx)
+	require.Equal(t, len(roots), n)
+	require.Zero(t, client.Comment.Query().CountX(ctx))
+}
@@ -8,6 +8,8 @@ package ent
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
@@ -31,7 +33,7 @@ func (_d *CommentDelete) Where(ps ...predicate.Comment) *CommentDelete {
 
 // Exec executes the deletion query and returns how many vertices were deleted.
 func (_d *CommentDelete) Exec(ctx context.Context) (int, error) {
-	return withHooks(ctx, _d.sqlExec, _d.mutation, _d.hooks)
+	return withHooks(ctx, _d.execDeleteActions, _d.mutation, _d.hooks)
 }
 
 // ExecX is like Exec, but panics if an error occurs.
@@ -43,6 +45,89 @@ func (_d *CommentDelete) ExecX(ctx context.Context) int {
 	return n
 }
 
+// execDeleteActions applies the delete actions that were defined on the edges of the Comment
+// entities, and deletes them. All operations are executed in the same transaction.
+func (_d *CommentDelete) execDeleteActions(ctx context.Context) (int, error) {
+	tx, err := _d.driver.Tx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	cfg := _d.config
+	cfg.driver = &txDriver{tx: tx, drv: _d.driver}
+	n, err := _d.deleteActions(ctx, cfg)
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: %v", err, rerr)
+		}
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// deleteActions applies the delete actions on the neighbors of the matched Comments
+// before deleting them. The neighbors that are already being deleted are skipped. The identifiers
+// are selected directly from the database, bypassing the interceptors and the privacy policies of
+// the query builders, and they are processed in chunks of deleteActionsBatchSize.
+func (_d *CommentDelete) deleteActions(ctx context.Context, cfg config) (int, error) {
+	query := (&CommentQuery{config: cfg, ctx: &QueryContext{Type: TypeComment}}).Where(_d.mutation.predicates...)
+	selector := query.sqlQuery(ctx)
+	ids, err := selectIDs[int](ctx, cfg.driver, selector.Select(selector.C(comment.FieldID)))
+	if err != nil || len(ids) == 0 {
+		_d.mutation.done = true
+		return 0, err
+	}
+	ctx = withDeleting(ctx, comment.Label, ids)
+	for chunk := range slices.Chunk(ids, deleteActionsBatchSize) {
+		repliesIDs, err := _d.repliesIDs(ctx, cfg, chunk)
+		if err != nil {
+			return 0, err
+		}
+		for nchunk := range slices.Chunk(repliesIDs, deleteActionsBatchSize) {
+			if _, err := NewCommentClient(cfg).Delete().Where(comment.IDIn(nchunk...)).Exec(ctx); err != nil {
+				return 0, err
+			}
+		}
+	}
+	var n int
+	for chunk := range slices.Chunk(ids, deleteActionsBatchSize) {
+		mutation := newCommentMutation(cfg, OpDelete)
+		mutation.Where(comment.IDIn(chunk...))
+		affected, err := (&CommentDelete{config: cfg, mutation: mutation}).sqlExec(ctx)
+		if err != nil {
+			return 0, err
+		}
+		n += affected
+	}
+	_d.mutation.done = true
+	return n, nil
+}
+
+// repliesIDs returns the identifiers of the "replies" neighbors of the given Comments
+// that are not being deleted.
+func (_d *CommentDelete) repliesIDs(ctx context.Context, cfg config, ids []int) ([]int, error) {
+	_q := (&CommentQuery{config: cfg, ctx: &QueryContext{Type: TypeComment}}).Where(comment.IDIn(ids...))
+	var fromU *sql.Selector
+	selector := _q.sqlQuery(ctx)
+	if err := selector.Err(); err != nil {
+		return nil, err
+	}
+	step := sqlgraph.NewStep(
+		sqlgraph.From(comment.Table, comment.FieldID, selector),
+		sqlgraph.To(comment.Table, comment.FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, comment.RepliesTable, comment.RepliesColumn),
+	)
+	fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+
+	nids, err := selectIDs[int](ctx, cfg.driver, fromU.Select(fromU.C(comment.FieldID)).Distinct())
+	if err != nil {
+		return nil, err
+	}
+	return notDeleting(ctx, comment.Label, nids), nil
+}
+
 func (_d *CommentDelete) sqlExec(ctx context.Context) (int, error) {
 	_spec := sqlgraph.NewDeleteSpec(comment.Table, sqlgraph.NewFieldSpec(comment.FieldID, field.TypeInt))
 	if ps := _d.mutation.predicates; len(ps) > 0 {
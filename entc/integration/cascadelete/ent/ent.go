@@ -11,13 +11,16 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"sync"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/entc/integration/cascadelete/ent/comment"
 	"entgo.io/ent/entc/integration/cascadelete/ent/post"
+	"entgo.io/ent/entc/integration/cascadelete/ent/tag"
 	"entgo.io/ent/entc/integration/cascadelete/ent/user"
 )
 
@@ -81,6 +84,7 @@ func checkColumn(t, c string) error {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
 			comment.Table: comment.ValidColumn,
 			post.Table:    post.ValidColumn,
+			tag.Table:     tag.ValidColumn,
 			user.Table:    user.ValidColumn,
 		})
 	})
@@ -94,7 +98,7 @@ func Asc(fields ...string) func(*sql.Selector) {
 			if err := checkColumn(s.TableName(), f); err != nil {
 				s.AddError(&ValidationError{Name: f, err: fmt.Errorf("ent: %w", err)})
 			}
-			s.OrderBy(sql.Asc(s.C(f)))
+			s.OrderByKey(&sql.OrderKey{Expr: sql.Expr(s.C(f))})
 		}
 	}
 }
@@ -106,7 +110,7 @@ func Desc(fields ...string) func(*sql.Selector) {
 			if err := checkColumn(s.TableName(), f); err != nil {
 				s.AddError(&ValidationError{Name: f, err: fmt.Errorf("ent: %w", err)})
 			}
-			s.OrderBy(sql.Desc(s.C(f)))
+			s.OrderByKey(&sql.OrderKey{Expr: sql.Expr(s.C(f)), Desc: true})
 		}
 	}
 }
@@ -530,6 +534,64 @@ func withHooks[V Value, M any, PM interface {
 	return nv, nil
 }
 
+// deletingKey is the context key that holds the identifiers of the entities that
+// are being deleted by the delete actions of the edges (edge.OnDelete), per type.
+type deletingKey struct{}
+
+// withDeleting returns a new context that marks the given entities as being deleted.
+func withDeleting[T any](ctx context.Context, label string, ids []T) context.Context {
+	m := make(map[string]any)
+	if prev, ok := ctx.Value(deletingKey{}).(map[string]any); ok {
+		for k, v := range prev {
+			m[k] = v
+		}
+	}
+	m[label] = append(append([]T(nil), deleting[T](ctx, label)...), ids...)
+	return context.WithValue(ctx, deletingKey{}, m)
+}
+
+// deleting returns the identifiers of the entities of the given type that are being
+// deleted, in order to exclude them from the delete actions of their neighbors.
+func deleting[T any](ctx context.Context, label string) []T {
+	m, _ := ctx.Value(deletingKey{}).(map[string]any)
+	ids, _ := m[label].([]T)
+	return ids
+}
+
+// notDeleting filters out the identifiers of the entities that are being deleted.
+func notDeleting[T comparable](ctx context.Context, label string, ids []T) []T {
+	skip := make(map[T]struct{})
+	for _, id := range deleting[T](ctx, label) {
+		skip[id] = struct{}{}
+	}
+	return slices.DeleteFunc(ids, func(id T) bool {
+		_, ok := skip[id]
+		return ok
+	})
+}
+
+// deleteActionsBatchSize is the maximum number of identifiers that
+// are passed as arguments to the statements of the delete actions.
+const deleteActionsBatchSize = 1000
+
+// selectIDs executes the given selector of identifiers directly on the driver.
+func selectIDs[T any](ctx context.Context, drv dialect.Driver, selector *sql.Selector) ([]T, error) {
+	if err := selector.Err(); err != nil {
+		return nil, err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := drv.Query(ctx, query, args, rows); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []T
+	if err := sql.ScanSlice(rows, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 // setContextOp returns a new context with the given QueryContext attached (including its op) in case it does not exist.
 func setContextOp(ctx context.Context, qc *QueryContext, op string) context.Context {
 	if ent.QueryFromContext(ctx) == nil {
@@ -8,11 +8,14 @@ package ent
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/entc/integration/cascadelete/ent/post"
 	"entgo.io/ent/entc/integration/cascadelete/ent/predicate"
+	"entgo.io/ent/entc/integration/cascadelete/ent/tag"
 	"entgo.io/ent/schema/field"
 )
 
@@ -31,7 +34,7 @@ func (_d *PostDelete) Where(ps ...predicate.Post) *PostDelete {
 
 // Exec executes the deletion query and returns how many vertices were deleted.
 func (_d *PostDelete) Exec(ctx context.Context) (int, error) {
-	return withHooks(ctx, _d.sqlExec, _d.mutation, _d.hooks)
+	return withHooks(ctx, _d.execDeleteActions, _d.mutation, _d.hooks)
 }
 
 // ExecX is like Exec, but panics if an error occurs.
@@ -43,6 +46,83 @@ func (_d *PostDelete) ExecX(ctx context.Context) int {
 	return n
 }
 
+// execDeleteActions applies the delete actions that were defined on the edges of the Post
+// entities, and deletes them. All operations are executed in the same transaction.
+func (_d *PostDelete) execDeleteActions(ctx context.Context) (int, error) {
+	tx, err := _d.driver.Tx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	cfg := _d.config
+	cfg.driver = &txDriver{tx: tx, drv: _d.driver}
+	n, err := _d.deleteActions(ctx, cfg)
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: %v", err, rerr)
+		}
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// deleteActions applies the delete actions on the neighbors of the matched Posts
+// before deleting them. The neighbors that are already being deleted are skipped. The identifiers
+// are selected directly from the database, bypassing the interceptors and the privacy policies of
+// the query builders, and they are processed in chunks of deleteActionsBatchSize.
+func (_d *PostDelete) deleteActions(ctx context.Context, cfg config) (int, error) {
+	query := (&PostQuery{config: cfg, ctx: &QueryContext{Type: TypePost}}).Where(_d.mutation.predicates...)
+	selector := query.sqlQuery(ctx)
+	ids, err := selectIDs[int](ctx, cfg.driver, selector.Select(selector.C(post.FieldID)))
+	if err != nil || len(ids) == 0 {
+		_d.mutation.done = true
+		return 0, err
+	}
+	ctx = withDeleting(ctx, post.Label, ids)
+	for chunk := range slices.Chunk(ids, deleteActionsBatchSize) {
+		if err := NewPostClient(cfg).Update().Where(post.IDIn(chunk...)).ClearTags().Exec(ctx); err != nil {
+			return 0, err
+		}
+	}
+	var n int
+	for chunk := range slices.Chunk(ids, deleteActionsBatchSize) {
+		mutation := newPostMutation(cfg, OpDelete)
+		mutation.Where(post.IDIn(chunk...))
+		affected, err := (&PostDelete{config: cfg, mutation: mutation}).sqlExec(ctx)
+		if err != nil {
+			return 0, err
+		}
+		n += affected
+	}
+	_d.mutation.done = true
+	return n, nil
+}
+
+// tagsIDs returns the identifiers of the "tags" neighbors of the given Posts
+// that are not being deleted.
+func (_d *PostDelete) tagsIDs(ctx context.Context, cfg config, ids []int) ([]int, error) {
+	_q := (&PostQuery{config: cfg, ctx: &QueryContext{Type: TypePost}}).Where(post.IDIn(ids...))
+	var fromU *sql.Selector
+	selector := _q.sqlQuery(ctx)
+	if err := selector.Err(); err != nil {
+		return nil, err
+	}
+	step := sqlgraph.NewStep(
+		sqlgraph.From(post.Table, post.FieldID, selector),
+		sqlgraph.To(tag.Table, tag.FieldID),
+		sqlgraph.Edge(sqlgraph.M2M, false, post.TagsTable, post.TagsPrimaryKey...),
+	)
+	fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+
+	nids, err := selectIDs[int](ctx, cfg.driver, fromU.Select(fromU.C(tag.FieldID)).Distinct())
+	if err != nil {
+		return nil, err
+	}
+	return notDeleting(ctx, tag.Label, nids), nil
+}
+
 func (_d *PostDelete) sqlExec(ctx context.Context) (int, error) {
 	_spec := sqlgraph.NewDeleteSpec(post.Table, sqlgraph.NewFieldSpec(post.FieldID, field.TypeInt))
 	if ps := _d.mutation.predicates; len(ps) > 0 {
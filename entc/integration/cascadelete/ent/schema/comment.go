@@ -31,5 +31,12 @@ func (Comment) Edges() []ent.Edge {
 			Field("post_id").
 			Required().
 			Unique(),
+		edge.From("author", User.Type).
+			Ref("comments").
+			Unique(),
+		edge.To("replies", Comment.Type).
+			Annotations(edge.OnDelete(edge.Cascade)).
+			From("parent").
+			Unique(),
 	}
 }
@@ -37,5 +37,7 @@ func (Post) Edges() []ent.Edge {
 			Annotations(entsql.Annotation{
 				OnDelete: entsql.Cascade,
 			}),
+		edge.To("tags", Tag.Type).
+			Annotations(edge.OnDelete(edge.SetNull)),
 	}
 }
@@ -31,5 +31,7 @@ func (User) Edges() []ent.Edge {
 			Annotations(entsql.Annotation{
 				OnDelete: entsql.Cascade,
 			}),
+		edge.To("comments", Comment.Type).
+			Annotations(edge.OnDelete(edge.Restrict)),
 	}
 }
@@ -8,9 +8,12 @@ package ent
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/entc/integration/cascadelete/ent/comment"
 	"entgo.io/ent/entc/integration/cascadelete/ent/predicate"
 	"entgo.io/ent/entc/integration/cascadelete/ent/user"
 	"entgo.io/ent/schema/field"
@@ -31,7 +34,7 @@ func (_d *UserDelete) Where(ps ...predicate.User) *UserDelete {
 
 // Exec executes the deletion query and returns how many vertices were deleted.
 func (_d *UserDelete) Exec(ctx context.Context) (int, error) {
-	return withHooks(ctx, _d.sqlExec, _d.mutation, _d.hooks)
+	return withHooks(ctx, _d.execDeleteActions, _d.mutation, _d.hooks)
 }
 
 // ExecX is like Exec, but panics if an error occurs.
@@ -43,6 +46,86 @@ func (_d *UserDelete) ExecX(ctx context.Context) int {
 	return n
 }
 
+// execDeleteActions applies the delete actions that were defined on the edges of the User
+// entities, and deletes them. All operations are executed in the same transaction.
+func (_d *UserDelete) execDeleteActions(ctx context.Context) (int, error) {
+	tx, err := _d.driver.Tx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	cfg := _d.config
+	cfg.driver = &txDriver{tx: tx, drv: _d.driver}
+	n, err := _d.deleteActions(ctx, cfg)
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: %v", err, rerr)
+		}
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// deleteActions applies the delete actions on the neighbors of the matched Users
+// before deleting them. The neighbors that are already being deleted are skipped. The identifiers
+// are selected directly from the database, bypassing the interceptors and the privacy policies of
+// the query builders, and they are processed in chunks of deleteActionsBatchSize.
+func (_d *UserDelete) deleteActions(ctx context.Context, cfg config) (int, error) {
+	query := (&UserQuery{config: cfg, ctx: &QueryContext{Type: TypeUser}}).Where(_d.mutation.predicates...)
+	selector := query.sqlQuery(ctx)
+	ids, err := selectIDs[int](ctx, cfg.driver, selector.Select(selector.C(user.FieldID)))
+	if err != nil || len(ids) == 0 {
+		_d.mutation.done = true
+		return 0, err
+	}
+	ctx = withDeleting(ctx, user.Label, ids)
+	for chunk := range slices.Chunk(ids, deleteActionsBatchSize) {
+		switch commentsIDs, err := _d.commentsIDs(ctx, cfg, chunk); {
+		case err != nil:
+			return 0, err
+		case len(commentsIDs) > 0:
+			return 0, &ConstraintError{msg: `cannot delete User with "comments" edges (delete action: RESTRICT)`}
+		}
+	}
+	var n int
+	for chunk := range slices.Chunk(ids, deleteActionsBatchSize) {
+		mutation := newUserMutation(cfg, OpDelete)
+		mutation.Where(user.IDIn(chunk...))
+		affected, err := (&UserDelete{config: cfg, mutation: mutation}).sqlExec(ctx)
+		if err != nil {
+			return 0, err
+		}
+		n += affected
+	}
+	_d.mutation.done = true
+	return n, nil
+}
+
+// commentsIDs returns the identifiers of the "comments" neighbors of the given Users
+// that are not being deleted.
+func (_d *UserDelete) commentsIDs(ctx context.Context, cfg config, ids []int) ([]int, error) {
+	_q := (&UserQuery{config: cfg, ctx: &QueryContext{Type: TypeUser}}).Where(user.IDIn(ids...))
+	var fromU *sql.Selector
+	selector := _q.sqlQuery(ctx)
+	if err := selector.Err(); err != nil {
+		return nil, err
+	}
+	step := sqlgraph.NewStep(
+		sqlgraph.From(user.Table, user.FieldID, selector),
+		sqlgraph.To(comment.Table, comment.FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, user.CommentsTable, user.CommentsColumn),
+	)
+	fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+
+	nids, err := selectIDs[int](ctx, cfg.driver, fromU.Select(fromU.C(comment.FieldID)).Distinct())
+	if err != nil {
+		return nil, err
+	}
+	return notDeleting(ctx, comment.Label, nids), nil
+}
+
 func (_d *UserDelete) sqlExec(ctx context.Context) (int, error) {
 	_spec := sqlgraph.NewDeleteSpec(user.Table, sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt))
 	if ps := _d.mutation.predicates; len(ps) > 0 {
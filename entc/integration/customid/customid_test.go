@@ -19,6 +19,7 @@ import (
 	"entgo.io/ent/entc/integration/customid/ent/blob"
 	"entgo.io/ent/entc/integration/customid/ent/doc"
 	"entgo.io/ent/entc/integration/customid/ent/intsid"
+	"entgo.io/ent/entc/integration/customid/ent/invoice"
 	"entgo.io/ent/entc/integration/customid/ent/pet"
 	"entgo.io/ent/entc/integration/customid/ent/token"
 	"entgo.io/ent/entc/integration/customid/ent/user"
@@ -54,6 +55,7 @@ func TestMySQL(t *testing.T) {
 			err = client.Schema.Create(context.Background(), schema.WithHooks(clearDefault, skipBytesID))
 			require.NoError(t, err)
 			CustomID(t, client)
+			CompositeID(t, client)
 		})
 	}
 }
@@ -79,6 +81,7 @@ func TestPostgres(t *testing.T) {
 			require.NoError(t, err)
 			CustomID(t, client)
 			BytesID(t, client)
+			CompositeID(t, client)
 		})
 	}
 }
@@ -90,6 +93,7 @@ func TestSQLite(t *testing.T) {
 	require.NoError(t, client.Schema.Create(context.Background(), schema.WithHooks(clearDefault)))
 	CustomID(t, client)
 	BytesID(t, client)
+	CompositeID(t, client)
 }
 
 func CustomID(t *testing.T, client *ent.Client) {
@@ -259,6 +263,45 @@ func CustomID(t *testing.T, client *ent.Client) {
 	})
 }
 
+func CompositeID(t *testing.T, client *ent.Client) {
+	ctx := context.Background()
+	a8m := client.User.Create().SetID(100).SaveX(ctx)
+	inv1 := client.Invoice.Create().SetTenantID(1).SetNumber("A-100").SetOwner(a8m).SaveX(ctx)
+	require.Equal(t, 1, inv1.TenantID)
+	require.Equal(t, "A-100", inv1.Number)
+	client.Invoice.Create().SetTenantID(2).SetNumber("A-100").SaveX(ctx)
+	_, err := client.Invoice.Create().SetTenantID(1).SetNumber("A-100").Save(ctx)
+	require.True(t, ent.IsConstraintError(err), "duplicate composite id")
+
+	key := ent.InvoiceKey{TenantID: 1, Number: "A-100"}
+	inv := client.Invoice.GetX(ctx, key)
+	require.Equal(t, key, ent.InvoiceKey{TenantID: inv.TenantID, Number: inv.Number})
+	require.Equal(t, a8m.ID, inv.QueryOwner().OnlyIDX(ctx))
+	require.Equal(t, a8m.ID, client.Invoice.QueryOwner(inv).OnlyIDX(ctx))
+	require.Equal(t, inv.Number, a8m.QueryInvoices().OnlyX(ctx).Number)
+	owner := client.User.Query().Where(user.ID(a8m.ID)).WithInvoices().OnlyX(ctx)
+	require.Len(t, owner.Edges.Invoices, 1)
+	_, err = client.Invoice.Get(ctx, ent.InvoiceKey{TenantID: 3, Number: "A-100"})
+	require.True(t, ent.IsNotFound(err))
+
+	inv = client.Invoice.UpdateOneID(key).SetNote("shipped").SaveX(ctx)
+	require.Equal(t, "shipped", inv.Note)
+	inv = client.Invoice.UpdateOne(inv).ClearNote().ClearOwner().SaveX(ctx)
+	require.Empty(t, inv.Note)
+	require.False(t, inv.QueryOwner().ExistX(ctx))
+	require.Empty(t, client.Invoice.GetX(ctx, ent.InvoiceKey{TenantID: 2, Number: "A-100"}).Note, "other tenants are not updated")
+	_, err = client.Invoice.UpdateOneID(ent.InvoiceKey{TenantID: 3, Number: "A-100"}).SetNote("shipped").Save(ctx)
+	require.True(t, ent.IsNotFound(err))
+
+	client.Invoice.DeleteOneID(key).ExecX(ctx)
+	require.False(t, client.Invoice.Query().Where(invoice.TenantID(1)).ExistX(ctx))
+	err = client.Invoice.DeleteOneID(key).Exec(ctx)
+	require.True(t, ent.IsNotFound(err))
+	inv = client.Invoice.GetX(ctx, ent.InvoiceKey{TenantID: 2, Number: "A-100"})
+	client.Invoice.DeleteOne(inv).ExecX(ctx)
+	require.Zero(t, client.Invoice.Query().CountX(ctx))
+}
+
 func BytesID(t *testing.T, client *ent.Client) {
 	ctx := context.Background()
 	s := client.Session.Create().SaveX(ctx)
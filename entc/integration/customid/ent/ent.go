@@ -24,6 +24,7 @@ import (
 	"entgo.io/ent/entc/integration/customid/ent/doc"
 	"entgo.io/ent/entc/integration/customid/ent/group"
 	"entgo.io/ent/entc/integration/customid/ent/intsid"
+	"entgo.io/ent/entc/integration/customid/ent/invoice"
 	"entgo.io/ent/entc/integration/customid/ent/link"
 	"entgo.io/ent/entc/integration/customid/ent/mixinid"
 	"entgo.io/ent/entc/integration/customid/ent/note"
@@ -101,6 +102,7 @@ func checkColumn(t, c string) error {
 			doc.Table:      doc.ValidColumn,
 			group.Table:    group.ValidColumn,
 			intsid.Table:   intsid.ValidColumn,
+			invoice.Table:  invoice.ValidColumn,
 			link.Table:     link.ValidColumn,
 			mixinid.Table:  mixinid.ValidColumn,
 			note.Table:     note.ValidColumn,
@@ -122,7 +124,7 @@ func Asc(fields ...string) func(*sql.Selector) {
 			if err := checkColumn(s.TableName(), f); err != nil {
 				s.AddError(&ValidationError{Name: f, err: fmt.Errorf("ent: %w", err)})
 			}
-			s.OrderBy(sql.Asc(s.C(f)))
+			s.OrderByKey(&sql.OrderKey{Expr: sql.Expr(s.C(f))})
 		}
 	}
 }
@@ -134,7 +136,7 @@ func Desc(fields ...string) func(*sql.Selector) {
 			if err := checkColumn(s.TableName(), f); err != nil {
 				s.AddError(&ValidationError{Name: f, err: fmt.Errorf("ent: %w", err)})
 			}
-			s.OrderBy(sql.Desc(s.C(f)))
+			s.OrderByKey(&sql.OrderKey{Expr: sql.Expr(s.C(f)), Desc: true})
 		}
 	}
 }
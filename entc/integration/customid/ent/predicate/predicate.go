@@ -34,6 +34,9 @@ type Group func(*sql.Selector)
 // IntSID is the predicate function for intsid builders.
 type IntSID func(*sql.Selector)
 
+// Invoice is the predicate function for invoice builders.
+type Invoice func(*sql.Selector)
+
 // Link is the predicate function for link builders.
 type Link func(*sql.Selector)
 
@@ -32,5 +32,6 @@ func (User) Edges() []ent.Edge {
 			From("parent").
 			Unique(),
 		edge.To("pets", Pet.Type),
+		edge.To("invoices", Invoice.Type),
 	}
 }
@@ -37,9 +37,11 @@ type UserEdges struct {
 	Children []*User `json:"children,omitempty"`
 	// Pets holds the value of the pets edge.
 	Pets []*Pet `json:"pets,omitempty"`
+	// Invoices holds the value of the invoices edge.
+	Invoices []*Invoice `json:"invoices,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [4]bool
+	loadedTypes [5]bool
 }
 
 // GroupsOrErr returns the Groups value or an error if the edge
@@ -80,6 +82,15 @@ func (e UserEdges) PetsOrErr() ([]*Pet, error) {
 	return nil, &NotLoadedError{edge: "pets"}
 }
 
+// InvoicesOrErr returns the Invoices value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) InvoicesOrErr() ([]*Invoice, error) {
+	if e.loadedTypes[4] {
+		return e.Invoices, nil
+	}
+	return nil, &NotLoadedError{edge: "invoices"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*User) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
@@ -150,6 +161,11 @@ func (_m *User) QueryPets() *PetQuery {
 	return NewUserClient(_m.config).QueryPets(_m)
 }
 
+// QueryInvoices queries the "invoices" edge of the User entity.
+func (_m *User) QueryInvoices() *InvoiceQuery {
+	return NewUserClient(_m.config).QueryInvoices(_m)
+}
+
 // Update returns a builder for updating this User.
 // Note that you need to call User.Unwrap() before calling this method if this User
 // was returned from a transaction, and the transaction was committed or rolled back.
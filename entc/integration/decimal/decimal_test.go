@@ -0,0 +1,135 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package decimal
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/entc/integration/decimal/ent"
+	"entgo.io/ent/entc/integration/decimal/ent/account"
+	"entgo.io/ent/entc/integration/decimal/ent/schema"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLite(t *testing.T) {
+	client, err := ent.Open(dialect.SQLite, "file:ent?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+	defer client.Close()
+	require.NoError(t, client.Schema.Create(context.Background()))
+	Decimals(t, client)
+}
+
+func TestMySQL(t *testing.T) {
+	for version, port := range map[string]int{"57": 3307, "8": 3308} {
+		t.Run(version, func(t *testing.T) {
+			db, err := sql.Open(dialect.MySQL, fmt.Sprintf("root:pass@tcp(localhost:%d)/", port))
+			require.NoError(t, err)
+			defer db.Close()
+			ctx := context.Background()
+			err = db.Exec(ctx, "CREATE DATABASE IF NOT EXISTS `decimal`", []any{}, nil)
+			require.NoError(t, err, "creating database")
+			defer db.Exec(ctx, "DROP DATABASE IF EXISTS `decimal`", []any{}, nil)
+			client, err := ent.Open(dialect.MySQL, fmt.Sprintf("root:pass@tcp(localhost:%d)/decimal", port))
+			require.NoError(t, err, "connecting to decimal database")
+			defer client.Close()
+			require.NoError(t, client.Schema.Create(ctx))
+			Decimals(t, client)
+		})
+	}
+}
+
+func TestPostgres(t *testing.T) {
+	for version, port := range map[string]int{"12": 5433, "13": 5434} {
+		t.Run(version, func(t *testing.T) {
+			dsn := fmt.Sprintf("host=localhost port=%d user=postgres password=pass sslmode=disable", port)
+			db, err := sql.Open(dialect.Postgres, dsn)
+			require.NoError(t, err)
+			defer db.Close()
+			ctx := context.Background()
+			_, err = db.ExecContext(ctx, `CREATE DATABASE "decimal"`)
+			require.NoError(t, err, "creating database")
+			defer db.ExecContext(ctx, `DROP DATABASE "decimal"`)
+			client, err := ent.Open(dialect.Postgres, dsn+" dbname=decimal")
+			require.NoError(t, err, "connecting to decimal database")
+			defer client.Close()
+			require.NoError(t, client.Schema.Create(ctx))
+			Decimals(t, client)
+		})
+	}
+}
+
+func Decimals(t *testing.T, client *ent.Client) {
+	ctx := context.Background()
+	a1 := client.Account.Create().SetBalance(schema.NewAmount("10.25")).SaveX(ctx)
+	a2 := client.Account.Create().SetRate("0.0625").SetLimit("100.50").SaveX(ctx)
+
+	t.Log("Decimal values are stored and loaded exactly")
+	a1 = client.Account.GetX(ctx, a1.ID)
+	requireAmount(t, "10.25", a1.Balance)
+	requireDecimal(t, "0.05", a1.Rate)
+	require.Nil(t, a1.Limit)
+	a2 = client.Account.GetX(ctx, a2.ID)
+	requireAmount(t, "0", a2.Balance)
+	requireDecimal(t, "0.0625", a2.Rate)
+	require.NotNil(t, a2.Limit)
+	requireDecimal(t, "100.50", *a2.Limit)
+
+	t.Log("Predicates compare decimal values numerically")
+	ids := client.Account.Query().Where(account.BalanceGT(schema.NewAmount("9.5"))).IDsX(ctx)
+	require.Equal(t, []int{a1.ID}, ids)
+	ids = client.Account.Query().Where(account.RateGT("0.0600")).IDsX(ctx)
+	require.Equal(t, []int{a2.ID}, ids)
+	ids = client.Account.Query().Where(account.LimitIsNil()).IDsX(ctx)
+	require.Equal(t, []int{a1.ID}, ids)
+
+	t.Log("Go types that implement the Add method can be summed by the update builders")
+	a1 = a1.Update().AddBalance(schema.NewAmount("0.5")).SaveX(ctx)
+	requireAmount(t, "10.75", a1.Balance)
+	n := client.Account.Update().AddBalance(schema.NewAmount("1.25")).SaveX(ctx)
+	require.Equal(t, 2, n)
+	requireAmount(t, "12", client.Account.GetX(ctx, a1.ID).Balance)
+	requireAmount(t, "1.25", client.Account.GetX(ctx, a2.ID).Balance)
+
+	t.Log("String decimals can be summed using SQL expressions")
+	client.Account.UpdateOne(a2).
+		Modify(func(u *sql.UpdateBuilder) {
+			u.Set(account.FieldRate, sql.ExprFunc(func(b *sql.Builder) {
+				b.Ident(account.FieldRate).WriteOp(sql.OpAdd).
+					WriteString("CAST(").Arg("0.0625").WriteString(" AS DECIMAL(5,4))")
+			}))
+		}).
+		ExecX(ctx)
+	a2 = client.Account.GetX(ctx, a2.ID)
+	requireDecimal(t, "0.125", a2.Rate)
+	a2 = a2.Update().ClearLimit().SaveX(ctx)
+	require.Nil(t, a2.Limit)
+	require.Nil(t, client.Account.GetX(ctx, a2.ID).Limit)
+}
+
+// requireDecimal asserts that the given decimal literals are numerically
+// equal, as databases may format them differently (e.g. "0.05" and "0.0500").
+func requireDecimal(t *testing.T, expected, actual string) {
+	t.Helper()
+	x, ok := new(big.Rat).SetString(expected)
+	require.True(t, ok, "invalid decimal %q", expected)
+	y, ok := new(big.Rat).SetString(actual)
+	require.True(t, ok, "invalid decimal %q", actual)
+	require.Zero(t, x.Cmp(y), "expected %s, got %s", expected, actual)
+}
+
+// requireAmount asserts that the given amount equals the decimal literal.
+func requireAmount(t *testing.T, expected string, actual schema.Amount) {
+	t.Helper()
+	require.Zero(t, schema.NewAmount(expected).Cmp(actual), "expected %s, got %s", expected, actual)
+}
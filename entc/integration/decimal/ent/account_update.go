@@ -0,0 +1,336 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/entc/integration/decimal/ent/account"
+	"entgo.io/ent/entc/integration/decimal/ent/predicate"
+	"entgo.io/ent/entc/integration/decimal/ent/schema"
+	"entgo.io/ent/schema/field"
+)
+
+// AccountUpdate is the builder for updating Account entities.
+type AccountUpdate struct {
+	config
+	hooks     []Hook
+	mutation  *AccountMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// Where appends a list predicates to the AccountUpdate builder.
+func (_u *AccountUpdate) Where(ps ...predicate.Account) *AccountUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetBalance sets the "balance" field.
+func (_u *AccountUpdate) SetBalance(v schema.Amount) *AccountUpdate {
+	_u.mutation.ResetBalance()
+	_u.mutation.SetBalance(v)
+	return _u
+}
+
+// SetNillableBalance sets the "balance" field if the given value is not nil.
+func (_u *AccountUpdate) SetNillableBalance(v *schema.Amount) *AccountUpdate {
+	if v != nil {
+		_u.SetBalance(*v)
+	}
+	return _u
+}
+
+// AddBalance adds value to the "balance" field.
+func (_u *AccountUpdate) AddBalance(v schema.Amount) *AccountUpdate {
+	_u.mutation.AddBalance(v)
+	return _u
+}
+
+// SetRate sets the "rate" field.
+func (_u *AccountUpdate) SetRate(v string) *AccountUpdate {
+	_u.mutation.SetRate(v)
+	return _u
+}
+
+// SetNillableRate sets the "rate" field if the given value is not nil.
+func (_u *AccountUpdate) SetNillableRate(v *string) *AccountUpdate {
+	if v != nil {
+		_u.SetRate(*v)
+	}
+	return _u
+}
+
+// SetLimit sets the "limit" field.
+func (_u *AccountUpdate) SetLimit(v string) *AccountUpdate {
+	_u.mutation.SetLimit(v)
+	return _u
+}
+
+// SetNillableLimit sets the "limit" field if the given value is not nil.
+func (_u *AccountUpdate) SetNillableLimit(v *string) *AccountUpdate {
+	if v != nil {
+		_u.SetLimit(*v)
+	}
+	return _u
+}
+
+// ClearLimit clears the value of the "limit" field.
+func (_u *AccountUpdate) ClearLimit() *AccountUpdate {
+	_u.mutation.ClearLimit()
+	return _u
+}
+
+// Mutation returns the AccountMutation object of the builder.
+func (_u *AccountUpdate) Mutation() *AccountMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *AccountUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *AccountUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *AccountUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *AccountUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (_u *AccountUpdate) Modify(modifiers ...func(u *sql.UpdateBuilder)) *AccountUpdate {
+	_u.modifiers = append(_u.modifiers, modifiers...)
+	return _u
+}
+
+func (_u *AccountUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(account.Table, account.Columns, sqlgraph.NewFieldSpec(account.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Balance(); ok {
+		_spec.SetField(account.FieldBalance, field.TypeDecimal, value)
+	}
+	if value, ok := _u.mutation.AddedBalance(); ok {
+		_spec.AddField(account.FieldBalance, field.TypeDecimal, value)
+	}
+	if value, ok := _u.mutation.Rate(); ok {
+		_spec.SetField(account.FieldRate, field.TypeDecimal, value)
+	}
+	if value, ok := _u.mutation.Limit(); ok {
+		_spec.SetField(account.FieldLimit, field.TypeDecimal, value)
+	}
+	if _u.mutation.LimitCleared() {
+		_spec.ClearField(account.FieldLimit, field.TypeDecimal)
+	}
+	_spec.AddModifiers(_u.modifiers...)
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{account.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// AccountUpdateOne is the builder for updating a single Account entity.
+type AccountUpdateOne struct {
+	config
+	fields    []string
+	hooks     []Hook
+	mutation  *AccountMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// SetBalance sets the "balance" field.
+func (_u *AccountUpdateOne) SetBalance(v schema.Amount) *AccountUpdateOne {
+	_u.mutation.ResetBalance()
+	_u.mutation.SetBalance(v)
+	return _u
+}
+
+// SetNillableBalance sets the "balance" field if the given value is not nil.
+func (_u *AccountUpdateOne) SetNillableBalance(v *schema.Amount) *AccountUpdateOne {
+	if v != nil {
+		_u.SetBalance(*v)
+	}
+	return _u
+}
+
+// AddBalance adds value to the "balance" field.
+func (_u *AccountUpdateOne) AddBalance(v schema.Amount) *AccountUpdateOne {
+	_u.mutation.AddBalance(v)
+	return _u
+}
+
+// SetRate sets the "rate" field.
+func (_u *AccountUpdateOne) SetRate(v string) *AccountUpdateOne {
+	_u.mutation.SetRate(v)
+	return _u
+}
+
+// SetNillableRate sets the "rate" field if the given value is not nil.
+func (_u *AccountUpdateOne) SetNillableRate(v *string) *AccountUpdateOne {
+	if v != nil {
+		_u.SetRate(*v)
+	}
+	return _u
+}
+
+// SetLimit sets the "limit" field.
+func (_u *AccountUpdateOne) SetLimit(v string) *AccountUpdateOne {
+	_u.mutation.SetLimit(v)
+	return _u
+}
+
+// SetNillableLimit sets the "limit" field if the given value is not nil.
+func (_u *AccountUpdateOne) SetNillableLimit(v *string) *AccountUpdateOne {
+	if v != nil {
+		_u.SetLimit(*v)
+	}
+	return _u
+}
+
+// ClearLimit clears the value of the "limit" field.
+func (_u *AccountUpdateOne) ClearLimit() *AccountUpdateOne {
+	_u.mutation.ClearLimit()
+	return _u
+}
+
+// Mutation returns the AccountMutation object of the builder.
+func (_u *AccountUpdateOne) Mutation() *AccountMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the AccountUpdate builder.
+func (_u *AccountUpdateOne) Where(ps ...predicate.Account) *AccountUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *AccountUpdateOne) Select(field string, fields ...string) *AccountUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated Account entity.
+func (_u *AccountUpdateOne) Save(ctx context.Context) (*Account, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *AccountUpdateOne) SaveX(ctx context.Context) *Account {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *AccountUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *AccountUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (_u *AccountUpdateOne) Modify(modifiers ...func(u *sql.UpdateBuilder)) *AccountUpdateOne {
+	_u.modifiers = append(_u.modifiers, modifiers...)
+	return _u
+}
+
+func (_u *AccountUpdateOne) sqlSave(ctx context.Context) (_node *Account, err error) {
+	_spec := sqlgraph.NewUpdateSpec(account.Table, account.Columns, sqlgraph.NewFieldSpec(account.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Account.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, account.FieldID)
+		for _, f := range fields {
+			if !account.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != account.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Balance(); ok {
+		_spec.SetField(account.FieldBalance, field.TypeDecimal, value)
+	}
+	if value, ok := _u.mutation.AddedBalance(); ok {
+		_spec.AddField(account.FieldBalance, field.TypeDecimal, value)
+	}
+	if value, ok := _u.mutation.Rate(); ok {
+		_spec.SetField(account.FieldRate, field.TypeDecimal, value)
+	}
+	if value, ok := _u.mutation.Limit(); ok {
+		_spec.SetField(account.FieldLimit, field.TypeDecimal, value)
+	}
+	if _u.mutation.LimitCleared() {
+		_spec.ClearField(account.FieldLimit, field.TypeDecimal)
+	}
+	_spec.AddModifiers(_u.modifiers...)
+	_node = &Account{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{account.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
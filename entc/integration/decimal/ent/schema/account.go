@@ -0,0 +1,107 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// Account holds the schema definition for the Account entity.
+type Account struct {
+	ent.Schema
+}
+
+// Fields of the Account.
+func (Account) Fields() []ent.Field {
+	return []ent.Field{
+		field.Decimal("balance").
+			Precision(10).
+			Scale(2).
+			GoType(Amount{}).
+			DefaultFunc(func() Amount {
+				return NewAmount("0")
+			}),
+		field.Decimal("rate").
+			Precision(5).
+			Scale(4).
+			Default("0.05"),
+		field.Decimal("limit").
+			Precision(10).
+			Scale(2).
+			Optional().
+			Nillable(),
+	}
+}
+
+// Amount is an exact decimal Go type that implements the "Add(T) T"
+// method, and therefore, can be summed by the update builders.
+type Amount struct {
+	r *big.Rat
+}
+
+// NewAmount returns a new Amount from the given decimal literal.
+// It panics if the literal is not a valid decimal number.
+func NewAmount(s string) Amount {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		panic(fmt.Sprintf("schema: invalid amount %q", s))
+	}
+	return Amount{r: r}
+}
+
+// Add returns the sum of the two amounts.
+func (a Amount) Add(b Amount) Amount {
+	return Amount{r: new(big.Rat).Add(a.rat(), b.rat())}
+}
+
+// Cmp compares the two amounts. See big.Rat.Cmp for details.
+func (a Amount) Cmp(b Amount) int {
+	return a.rat().Cmp(b.rat())
+}
+
+// String implements the fmt.Stringer interface.
+func (a Amount) String() string {
+	return a.rat().FloatString(2)
+}
+
+// Value implements the driver.Valuer interface.
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (a *Amount) Scan(v any) (err error) {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case int64:
+		s = fmt.Sprint(v)
+	case float64:
+		s = fmt.Sprint(v)
+	default:
+		return fmt.Errorf("schema: unexpected amount type %T", v)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return fmt.Errorf("schema: invalid amount %q", s)
+	}
+	a.r = r
+	return nil
+}
+
+func (a Amount) rat() *big.Rat {
+	if a.r == nil {
+		return new(big.Rat)
+	}
+	return a.r
+}
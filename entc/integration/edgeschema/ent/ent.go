@@ -122,7 +122,7 @@ func Asc(fields ...string) func(*sql.Selector) {
 			if err := checkColumn(s.TableName(), f); err != nil {
 				s.AddError(&ValidationError{Name: f, err: fmt.Errorf("ent: %w", err)})
 			}
-			s.OrderBy(sql.Asc(s.C(f)))
+			s.OrderByKey(&sql.OrderKey{Expr: sql.Expr(s.C(f))})
 		}
 	}
 }
@@ -134,7 +134,7 @@ func Desc(fields ...string) func(*sql.Selector) {
 			if err := checkColumn(s.TableName(), f); err != nil {
 				s.AddError(&ValidationError{Name: f, err: fmt.Errorf("ent: %w", err)})
 			}
-			s.OrderBy(sql.Desc(s.C(f)))
+			s.OrderByKey(&sql.OrderKey{Expr: sql.Expr(s.C(f)), Desc: true})
 		}
 	}
 }
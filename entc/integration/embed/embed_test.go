@@ -0,0 +1,121 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package embed
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/entc/integration/embed/ent"
+	"entgo.io/ent/entc/integration/embed/ent/schema"
+	"entgo.io/ent/entc/integration/embed/ent/user"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMySQL(t *testing.T) {
+	for version, port := range map[string]int{"56": 3306, "57": 3307, "8": 3308} {
+		t.Run(version, func(t *testing.T) {
+			db, err := sql.Open(dialect.MySQL, fmt.Sprintf("root:pass@tcp(localhost:%d)/", port))
+			require.NoError(t, err)
+			defer db.Close()
+			ctx := context.Background()
+			err = db.Exec(ctx, "CREATE DATABASE IF NOT EXISTS embed", []any{}, nil)
+			require.NoError(t, err, "creating database")
+			defer db.Exec(ctx, "DROP DATABASE IF EXISTS embed", []any{}, nil)
+
+			client, err := ent.Open(dialect.MySQL, fmt.Sprintf("root:pass@tcp(localhost:%d)/embed", port))
+			require.NoError(t, err, "connecting to embed database")
+			defer client.Close()
+			require.NoError(t, client.Schema.Create(ctx))
+			Embed(t, client)
+		})
+	}
+}
+
+func TestPostgres(t *testing.T) {
+	for version, port := range map[string]int{"10": 5430, "11": 5431, "12": 5433, "13": 5434} {
+		t.Run(version, func(t *testing.T) {
+			dsn := fmt.Sprintf("host=localhost port=%d user=postgres password=pass sslmode=disable", port)
+			db, err := sql.Open(dialect.Postgres, dsn)
+			require.NoError(t, err)
+			defer db.Close()
+			ctx := context.Background()
+			err = db.Exec(ctx, "CREATE DATABASE embed", []any{}, nil)
+			require.NoError(t, err, "creating database")
+			defer db.Exec(ctx, "DROP DATABASE IF EXISTS embed", []any{}, nil)
+
+			client, err := ent.Open(dialect.Postgres, dsn+" dbname=embed")
+			require.NoError(t, err, "connecting to embed database")
+			defer client.Close()
+			require.NoError(t, client.Schema.Create(ctx))
+			Embed(t, client)
+		})
+	}
+}
+
+func TestSQLite(t *testing.T) {
+	client, err := ent.Open(dialect.SQLite, "file:ent?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+	defer client.Close()
+	require.NoError(t, client.Schema.Create(context.Background()))
+	Embed(t, client)
+}
+
+func Embed(t *testing.T, client *ent.Client) {
+	ctx := context.Background()
+	loc := schema.Location{Lat: 32.08, Lng: 34.78}
+	addr := schema.Address{Street: "Rothschild 1", City: "Tel Aviv", Zip: "6688101"}
+	a8m := client.User.Create().SetName("a8m").SetLocation(loc).SetAddress(addr).SaveX(ctx)
+	require.Equal(t, loc, a8m.Location())
+	require.Equal(t, &addr, a8m.Address())
+	nat := client.User.Create().SetName("nati").SetLocation(loc).SaveX(ctx)
+	require.Nil(t, nat.Address(), "all address columns are NULL")
+	nat = client.User.GetX(ctx, nat.ID)
+	require.Nil(t, nat.Address())
+	require.Equal(t, loc, nat.Location())
+
+	// Members of embedded fields can be set separately.
+	_, err := client.User.Create().SetName("ariel").SetLocationLat(1).Save(ctx)
+	require.Error(t, err, "missing required member of the location field")
+	ariel := client.User.Create().SetName("ariel").SetLocationLat(1).SetLocationLng(2).SetAddressCity("Haifa").SaveX(ctx)
+	require.Equal(t, schema.Location{Lat: 1, Lng: 2}, ariel.Location())
+	require.Equal(t, &schema.Address{City: "Haifa"}, ariel.Address())
+
+	// Predicates on the embedded field as a whole.
+	require.Equal(t, a8m.ID, client.User.Query().Where(user.AddressEQ(addr)).OnlyIDX(ctx))
+	require.Equal(t, nat.ID, client.User.Query().Where(user.AddressIsNil()).OnlyIDX(ctx))
+	require.Equal(t, []int{a8m.ID, ariel.ID}, client.User.Query().Where(user.AddressNotNil()).Order(ent.Asc(user.FieldID)).IDsX(ctx))
+	require.Equal(t, []int{a8m.ID, nat.ID}, client.User.Query().Where(user.LocationEQ(loc)).Order(ent.Asc(user.FieldID)).IDsX(ctx))
+	require.False(t, client.User.Query().Where(user.AddressEQ(schema.Address{City: "Tel Aviv"})).ExistX(ctx))
+
+	// Predicates on the members of the embedded field.
+	require.Equal(t, a8m.ID, client.User.Query().Where(user.AddressZip("6688101")).OnlyIDX(ctx))
+	require.Equal(t, a8m.ID, client.User.Query().Where(user.AddressZipHasPrefix("668")).OnlyIDX(ctx))
+	require.Equal(t, ariel.ID, client.User.Query().Where(user.AddressCityEQ("Haifa")).OnlyIDX(ctx))
+	require.Equal(t, []int{nat.ID, ariel.ID}, client.User.Query().Where(user.AddressZipIsNil()).Order(ent.Asc(user.FieldID)).IDsX(ctx))
+	require.Equal(t, ariel.ID, client.User.Query().Where(user.LocationLatLT(10)).OnlyIDX(ctx))
+
+	// Update and clear the embedded fields.
+	addr.Zip = "6688102"
+	a8m = a8m.Update().SetAddress(addr).SetLocationLng(35).SaveX(ctx)
+	require.Equal(t, &addr, a8m.Address())
+	require.Equal(t, schema.Location{Lat: loc.Lat, Lng: 35}, a8m.Location())
+	a8m = client.User.GetX(ctx, a8m.ID)
+	require.Equal(t, schema.Zip("6688102"), a8m.Address().Zip)
+	a8m = a8m.Update().ClearAddress().SaveX(ctx)
+	require.Nil(t, a8m.Address())
+	require.Nil(t, client.User.GetX(ctx, a8m.ID).Address())
+	client.User.Update().Where(user.ID(nat.ID)).SetAddress(addr).ExecX(ctx)
+	require.Equal(t, &addr, client.User.GetX(ctx, nat.ID).Address())
+	client.User.Update().ClearAddress().ExecX(ctx)
+	require.Equal(t, 3, client.User.Query().Where(user.AddressIsNil()).CountX(ctx))
+}
@@ -0,0 +1,820 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/entc/integration/embed/ent/predicate"
+	"entgo.io/ent/entc/integration/embed/ent/schema"
+	"entgo.io/ent/entc/integration/embed/ent/user"
+)
+
+const (
+	// Operation types.
+	OpCreate    = ent.OpCreate
+	OpDelete    = ent.OpDelete
+	OpDeleteOne = ent.OpDeleteOne
+	OpUpdate    = ent.OpUpdate
+	OpUpdateOne = ent.OpUpdateOne
+
+	// Node types.
+	TypeUser = "User"
+)
+
+// UserMutation represents an operation that mutates the User nodes in the graph.
+type UserMutation struct {
+	config
+	op              Op
+	typ             string
+	id              *int
+	name            *string
+	location_lat    *float64
+	addlocation_lat *float64
+	location_lng    *float64
+	addlocation_lng *float64
+	address_street  *string
+	address_city    *string
+	address_zip     *schema.Zip
+	clearedFields   map[string]struct{}
+	done            bool
+	oldValue        func(context.Context) (*User, error)
+	predicates      []predicate.User
+}
+
+var _ ent.Mutation = (*UserMutation)(nil)
+
+// userOption allows management of the mutation configuration using functional options.
+type userOption func(*UserMutation)
+
+// newUserMutation creates new mutation for the User entity.
+func newUserMutation(c config, op Op, opts ...userOption) *UserMutation {
+	m := &UserMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeUser,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withUserID sets the ID field of the mutation.
+func withUserID(id int) userOption {
+	return func(m *UserMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *User
+		)
+		m.oldValue = func(ctx context.Context) (*User, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().User.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withUser sets the old User of the mutation.
+func withUser(node *User) userOption {
+	return func(m *UserMutation) {
+		m.oldValue = func(context.Context) (*User, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m UserMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m UserMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *UserMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *UserMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists && len(m.predicates) > 0 {
+			m.predicates = append(m.predicates, user.ID(id))
+			return m.Client().User.Query().Where(m.predicates...).IDs(ctx)
+		} else if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().User.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetName sets the "name" field.
+func (m *UserMutation) SetName(s string) {
+	m.name = &s
+}
+
+// Name returns the value of the "name" field in the mutation.
+func (m *UserMutation) Name() (r string, exists bool) {
+	v := m.name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldName returns the old "name" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
+	}
+	return oldValue.Name, nil
+}
+
+// ResetName resets all changes to the "name" field.
+func (m *UserMutation) ResetName() {
+	m.name = nil
+}
+
+// SetLocationLat sets the "location_lat" field.
+func (m *UserMutation) SetLocationLat(f float64) {
+	m.location_lat = &f
+	m.addlocation_lat = nil
+}
+
+// LocationLat returns the value of the "location_lat" field in the mutation.
+func (m *UserMutation) LocationLat() (r float64, exists bool) {
+	v := m.location_lat
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLocationLat returns the old "location_lat" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldLocationLat(ctx context.Context) (v float64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLocationLat is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLocationLat requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLocationLat: %w", err)
+	}
+	return oldValue.LocationLat, nil
+}
+
+// AddLocationLat adds f to the "location_lat" field.
+func (m *UserMutation) AddLocationLat(f float64) {
+	if m.addlocation_lat != nil {
+		*m.addlocation_lat += f
+	} else {
+		m.addlocation_lat = &f
+	}
+}
+
+// AddedLocationLat returns the value that was added to the "location_lat" field in this mutation.
+func (m *UserMutation) AddedLocationLat() (r float64, exists bool) {
+	v := m.addlocation_lat
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetLocationLat resets all changes to the "location_lat" field.
+func (m *UserMutation) ResetLocationLat() {
+	m.location_lat = nil
+	m.addlocation_lat = nil
+}
+
+// SetLocationLng sets the "location_lng" field.
+func (m *UserMutation) SetLocationLng(f float64) {
+	m.location_lng = &f
+	m.addlocation_lng = nil
+}
+
+// LocationLng returns the value of the "location_lng" field in the mutation.
+func (m *UserMutation) LocationLng() (r float64, exists bool) {
+	v := m.location_lng
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLocationLng returns the old "location_lng" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldLocationLng(ctx context.Context) (v float64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLocationLng is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLocationLng requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLocationLng: %w", err)
+	}
+	return oldValue.LocationLng, nil
+}
+
+// AddLocationLng adds f to the "location_lng" field.
+func (m *UserMutation) AddLocationLng(f float64) {
+	if m.addlocation_lng != nil {
+		*m.addlocation_lng += f
+	} else {
+		m.addlocation_lng = &f
+	}
+}
+
+// AddedLocationLng returns the value that was added to the "location_lng" field in this mutation.
+func (m *UserMutation) AddedLocationLng() (r float64, exists bool) {
+	v := m.addlocation_lng
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetLocationLng resets all changes to the "location_lng" field.
+func (m *UserMutation) ResetLocationLng() {
+	m.location_lng = nil
+	m.addlocation_lng = nil
+}
+
+// SetAddressStreet sets the "address_street" field.
+func (m *UserMutation) SetAddressStreet(s string) {
+	m.address_street = &s
+}
+
+// AddressStreet returns the value of the "address_street" field in the mutation.
+func (m *UserMutation) AddressStreet() (r string, exists bool) {
+	v := m.address_street
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAddressStreet returns the old "address_street" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldAddressStreet(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAddressStreet is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAddressStreet requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAddressStreet: %w", err)
+	}
+	return oldValue.AddressStreet, nil
+}
+
+// ClearAddressStreet clears the value of the "address_street" field.
+func (m *UserMutation) ClearAddressStreet() {
+	m.address_street = nil
+	m.clearedFields[user.FieldAddressStreet] = struct{}{}
+}
+
+// AddressStreetCleared returns if the "address_street" field was cleared in this mutation.
+func (m *UserMutation) AddressStreetCleared() bool {
+	_, ok := m.clearedFields[user.FieldAddressStreet]
+	return ok
+}
+
+// ResetAddressStreet resets all changes to the "address_street" field.
+func (m *UserMutation) ResetAddressStreet() {
+	m.address_street = nil
+	delete(m.clearedFields, user.FieldAddressStreet)
+}
+
+// SetAddressCity sets the "address_city" field.
+func (m *UserMutation) SetAddressCity(s string) {
+	m.address_city = &s
+}
+
+// AddressCity returns the value of the "address_city" field in the mutation.
+func (m *UserMutation) AddressCity() (r string, exists bool) {
+	v := m.address_city
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAddressCity returns the old "address_city" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldAddressCity(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAddressCity is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAddressCity requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAddressCity: %w", err)
+	}
+	return oldValue.AddressCity, nil
+}
+
+// ClearAddressCity clears the value of the "address_city" field.
+func (m *UserMutation) ClearAddressCity() {
+	m.address_city = nil
+	m.clearedFields[user.FieldAddressCity] = struct{}{}
+}
+
+// AddressCityCleared returns if the "address_city" field was cleared in this mutation.
+func (m *UserMutation) AddressCityCleared() bool {
+	_, ok := m.clearedFields[user.FieldAddressCity]
+	return ok
+}
+
+// ResetAddressCity resets all changes to the "address_city" field.
+func (m *UserMutation) ResetAddressCity() {
+	m.address_city = nil
+	delete(m.clearedFields, user.FieldAddressCity)
+}
+
+// SetAddressZip sets the "address_zip" field.
+func (m *UserMutation) SetAddressZip(s schema.Zip) {
+	m.address_zip = &s
+}
+
+// AddressZip returns the value of the "address_zip" field in the mutation.
+func (m *UserMutation) AddressZip() (r schema.Zip, exists bool) {
+	v := m.address_zip
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAddressZip returns the old "address_zip" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldAddressZip(ctx context.Context) (v *schema.Zip, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAddressZip is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAddressZip requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAddressZip: %w", err)
+	}
+	return oldValue.AddressZip, nil
+}
+
+// ClearAddressZip clears the value of the "address_zip" field.
+func (m *UserMutation) ClearAddressZip() {
+	m.address_zip = nil
+	m.clearedFields[user.FieldAddressZip] = struct{}{}
+}
+
+// AddressZipCleared returns if the "address_zip" field was cleared in this mutation.
+func (m *UserMutation) AddressZipCleared() bool {
+	_, ok := m.clearedFields[user.FieldAddressZip]
+	return ok
+}
+
+// ResetAddressZip resets all changes to the "address_zip" field.
+func (m *UserMutation) ResetAddressZip() {
+	m.address_zip = nil
+	delete(m.clearedFields, user.FieldAddressZip)
+}
+
+// SetLocation sets the fields of the "location" embedded field.
+func (m *UserMutation) SetLocation(v schema.Location) {
+	m.SetLocationLat(v.Lat)
+	m.SetLocationLng(v.Lng)
+}
+
+// Location returns the value of the "location" embedded field in the mutation.
+// The value exists only if all its fields were set in the mutation.
+func (m *UserMutation) Location() (r schema.Location, exists bool) {
+	if r.Lat, exists = m.LocationLat(); !exists {
+		return r, false
+	}
+	if r.Lng, exists = m.LocationLng(); !exists {
+		return r, false
+	}
+	return r, true
+}
+
+// ResetLocation resets all changes to the fields of the "location" embedded field.
+func (m *UserMutation) ResetLocation() {
+	m.ResetLocationLat()
+	m.ResetLocationLng()
+}
+
+// SetAddress sets the fields of the "address" embedded field.
+func (m *UserMutation) SetAddress(v schema.Address) {
+	m.SetAddressStreet(v.Street)
+	m.SetAddressCity(v.City)
+	m.SetAddressZip(v.Zip)
+}
+
+// Address returns the value of the "address" embedded field in the mutation.
+// The value exists only if all its fields were set in the mutation.
+func (m *UserMutation) Address() (r schema.Address, exists bool) {
+	if r.Street, exists = m.AddressStreet(); !exists {
+		return r, false
+	}
+	if r.City, exists = m.AddressCity(); !exists {
+		return r, false
+	}
+	if r.Zip, exists = m.AddressZip(); !exists {
+		return r, false
+	}
+	return r, true
+}
+
+// ClearAddress clears the fields of the "address" embedded field.
+func (m *UserMutation) ClearAddress() {
+	m.ClearAddressStreet()
+	m.ClearAddressCity()
+	m.ClearAddressZip()
+}
+
+// ResetAddress resets all changes to the fields of the "address" embedded field.
+func (m *UserMutation) ResetAddress() {
+	m.ResetAddressStreet()
+	m.ResetAddressCity()
+	m.ResetAddressZip()
+}
+
+// Where appends a list predicates to the UserMutation builder.
+func (m *UserMutation) Where(ps ...predicate.User) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the UserMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *UserMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.User, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *UserMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *UserMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (User).
+func (m *UserMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *UserMutation) Fields() []string {
+	fields := make([]string, 0, 6)
+	if m.name != nil {
+		fields = append(fields, user.FieldName)
+	}
+	if m.location_lat != nil {
+		fields = append(fields, user.FieldLocationLat)
+	}
+	if m.location_lng != nil {
+		fields = append(fields, user.FieldLocationLng)
+	}
+	if m.address_street != nil {
+		fields = append(fields, user.FieldAddressStreet)
+	}
+	if m.address_city != nil {
+		fields = append(fields, user.FieldAddressCity)
+	}
+	if m.address_zip != nil {
+		fields = append(fields, user.FieldAddressZip)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *UserMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case user.FieldName:
+		return m.Name()
+	case user.FieldLocationLat:
+		return m.LocationLat()
+	case user.FieldLocationLng:
+		return m.LocationLng()
+	case user.FieldAddressStreet:
+		return m.AddressStreet()
+	case user.FieldAddressCity:
+		return m.AddressCity()
+	case user.FieldAddressZip:
+		return m.AddressZip()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *UserMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case user.FieldName:
+		return m.OldName(ctx)
+	case user.FieldLocationLat:
+		return m.OldLocationLat(ctx)
+	case user.FieldLocationLng:
+		return m.OldLocationLng(ctx)
+	case user.FieldAddressStreet:
+		return m.OldAddressStreet(ctx)
+	case user.FieldAddressCity:
+		return m.OldAddressCity(ctx)
+	case user.FieldAddressZip:
+		return m.OldAddressZip(ctx)
+	}
+	return nil, fmt.Errorf("unknown User field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *UserMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case user.FieldName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetName(v)
+		return nil
+	case user.FieldLocationLat:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLocationLat(v)
+		return nil
+	case user.FieldLocationLng:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLocationLng(v)
+		return nil
+	case user.FieldAddressStreet:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAddressStreet(v)
+		return nil
+	case user.FieldAddressCity:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAddressCity(v)
+		return nil
+	case user.FieldAddressZip:
+		v, ok := value.(schema.Zip)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAddressZip(v)
+		return nil
+	}
+	return fmt.Errorf("unknown User field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *UserMutation) AddedFields() []string {
+	var fields []string
+	if m.addlocation_lat != nil {
+		fields = append(fields, user.FieldLocationLat)
+	}
+	if m.addlocation_lng != nil {
+		fields = append(fields, user.FieldLocationLng)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *UserMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case user.FieldLocationLat:
+		return m.AddedLocationLat()
+	case user.FieldLocationLng:
+		return m.AddedLocationLng()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *UserMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case user.FieldLocationLat:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddLocationLat(v)
+		return nil
+	case user.FieldLocationLng:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddLocationLng(v)
+		return nil
+	}
+	return fmt.Errorf("unknown User numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *UserMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(user.FieldAddressStreet) {
+		fields = append(fields, user.FieldAddressStreet)
+	}
+	if m.FieldCleared(user.FieldAddressCity) {
+		fields = append(fields, user.FieldAddressCity)
+	}
+	if m.FieldCleared(user.FieldAddressZip) {
+		fields = append(fields, user.FieldAddressZip)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *UserMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *UserMutation) ClearField(name string) error {
+	switch name {
+	case user.FieldAddressStreet:
+		m.ClearAddressStreet()
+		return nil
+	case user.FieldAddressCity:
+		m.ClearAddressCity()
+		return nil
+	case user.FieldAddressZip:
+		m.ClearAddressZip()
+		return nil
+	}
+	return fmt.Errorf("unknown User nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *UserMutation) ResetField(name string) error {
+	switch name {
+	case user.FieldName:
+		m.ResetName()
+		return nil
+	case user.FieldLocationLat:
+		m.ResetLocationLat()
+		return nil
+	case user.FieldLocationLng:
+		m.ResetLocationLng()
+		return nil
+	case user.FieldAddressStreet:
+		m.ResetAddressStreet()
+		return nil
+	case user.FieldAddressCity:
+		m.ResetAddressCity()
+		return nil
+	case user.FieldAddressZip:
+		m.ResetAddressZip()
+		return nil
+	}
+	return fmt.Errorf("unknown User field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *UserMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *UserMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *UserMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *UserMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *UserMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *UserMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *UserMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown User unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *UserMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown User edge %s", name)
+}
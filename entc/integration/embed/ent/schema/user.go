@@ -0,0 +1,41 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// Zip is a postal code.
+type Zip string
+
+// Address is a value object that is embedded in the users table.
+type Address struct {
+	Street string
+	City   string
+	Zip    Zip
+}
+
+// Location is a value object that is embedded in the users table.
+type Location struct {
+	Lat float64
+	Lng float64
+}
+
+// User holds the schema definition for the User entity.
+type User struct {
+	ent.Schema
+}
+
+// Fields of the User.
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name"),
+		field.Embed("location", Location{}),
+		field.Embed("address", Address{}).
+			Optional(),
+	}
+}
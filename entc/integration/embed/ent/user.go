@@ -0,0 +1,200 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/entc/integration/embed/ent/schema"
+	"entgo.io/ent/entc/integration/embed/ent/user"
+)
+
+// User is the model entity for the User schema.
+type User struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// LocationLat holds the value of the "location_lat" field.
+	LocationLat float64 `json:"location_lat,omitempty"`
+	// LocationLng holds the value of the "location_lng" field.
+	LocationLng float64 `json:"location_lng,omitempty"`
+	// AddressStreet holds the value of the "address_street" field.
+	AddressStreet *string `json:"address_street,omitempty"`
+	// AddressCity holds the value of the "address_city" field.
+	AddressCity *string `json:"address_city,omitempty"`
+	// AddressZip holds the value of the "address_zip" field.
+	AddressZip   *schema.Zip `json:"address_zip,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*User) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case user.FieldLocationLat, user.FieldLocationLng:
+			values[i] = new(sql.NullFloat64)
+		case user.FieldID:
+			values[i] = new(sql.NullInt64)
+		case user.FieldName, user.FieldAddressStreet, user.FieldAddressCity, user.FieldAddressZip:
+			values[i] = new(sql.NullString)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the User fields.
+func (_m *User) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case user.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case user.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				_m.Name = value.String
+			}
+		case user.FieldLocationLat:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field location_lat", values[i])
+			} else if value.Valid {
+				_m.LocationLat = value.Float64
+			}
+		case user.FieldLocationLng:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field location_lng", values[i])
+			} else if value.Valid {
+				_m.LocationLng = value.Float64
+			}
+		case user.FieldAddressStreet:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field address_street", values[i])
+			} else if value.Valid {
+				_m.AddressStreet = new(string)
+				*_m.AddressStreet = value.String
+			}
+		case user.FieldAddressCity:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field address_city", values[i])
+			} else if value.Valid {
+				_m.AddressCity = new(string)
+				*_m.AddressCity = value.String
+			}
+		case user.FieldAddressZip:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field address_zip", values[i])
+			} else if value.Valid {
+				_m.AddressZip = new(schema.Zip)
+				*_m.AddressZip = schema.Zip(value.String)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the User.
+// This includes values selected through modifiers, order, etc.
+func (_m *User) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Location returns the value of the "location" embedded field.
+func (_m *User) Location() schema.Location {
+	return schema.Location{
+		Lat: _m.LocationLat,
+		Lng: _m.LocationLng,
+	}
+}
+
+// Address returns the value of the "address" embedded field, or nil if all its columns are NULL.
+func (_m *User) Address() *schema.Address {
+	if _m.AddressStreet == nil && _m.AddressCity == nil && _m.AddressZip == nil {
+		return nil
+	}
+	v := &schema.Address{}
+	if _m.AddressStreet != nil {
+		v.Street = *_m.AddressStreet
+	}
+	if _m.AddressCity != nil {
+		v.City = *_m.AddressCity
+	}
+	if _m.AddressZip != nil {
+		v.Zip = *_m.AddressZip
+	}
+	return v
+}
+
+// Update returns a builder for updating this User.
+// Note that you need to call User.Unwrap() before calling this method if this User
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *User) Update() *UserUpdateOne {
+	return NewUserClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the User entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *User) Unwrap() *User {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: User is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *User) String() string {
+	var builder strings.Builder
+	builder.WriteString("User(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("name=")
+	builder.WriteString(_m.Name)
+	builder.WriteString(", ")
+	builder.WriteString("location_lat=")
+	builder.WriteString(fmt.Sprintf("%v", _m.LocationLat))
+	builder.WriteString(", ")
+	builder.WriteString("location_lng=")
+	builder.WriteString(fmt.Sprintf("%v", _m.LocationLng))
+	builder.WriteString(", ")
+	if v := _m.AddressStreet; v != nil {
+		builder.WriteString("address_street=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	if v := _m.AddressCity; v != nil {
+		builder.WriteString("address_city=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	if v := _m.AddressZip; v != nil {
+		builder.WriteString("address_zip=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Users is a parsable slice of User.
+type Users []*User
@@ -0,0 +1,529 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Code generated by ent, DO NOT EDIT.
+
+package user
+
+import (
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/entc/integration/embed/ent/predicate"
+	"entgo.io/ent/entc/integration/embed/ent/schema"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.User {
+	return predicate.User(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.User {
+	return predicate.User(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.User {
+	return predicate.User(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldID, id))
+}
+
+// Name applies equality check predicate on the "name" field. It's identical to NameEQ.
+func Name(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldName, v))
+}
+
+// LocationLat applies equality check predicate on the "location_lat" field. It's identical to LocationLatEQ.
+func LocationLat(v float64) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldLocationLat, v))
+}
+
+// LocationLng applies equality check predicate on the "location_lng" field. It's identical to LocationLngEQ.
+func LocationLng(v float64) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldLocationLng, v))
+}
+
+// AddressStreet applies equality check predicate on the "address_street" field. It's identical to AddressStreetEQ.
+func AddressStreet(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldAddressStreet, v))
+}
+
+// AddressCity applies equality check predicate on the "address_city" field. It's identical to AddressCityEQ.
+func AddressCity(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldAddressCity, v))
+}
+
+// AddressZip applies equality check predicate on the "address_zip" field. It's identical to AddressZipEQ.
+func AddressZip(v schema.Zip) predicate.User {
+	vc := string(v)
+	return predicate.User(sql.FieldEQ(FieldAddressZip, vc))
+}
+
+// NameEQ applies the EQ predicate on the "name" field.
+func NameEQ(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldName, v))
+}
+
+// NameNEQ applies the NEQ predicate on the "name" field.
+func NameNEQ(v string) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldName, v))
+}
+
+// NameIn applies the In predicate on the "name" field.
+func NameIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldIn(FieldName, vs...))
+}
+
+// NameNotIn applies the NotIn predicate on the "name" field.
+func NameNotIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldName, vs...))
+}
+
+// NameGT applies the GT predicate on the "name" field.
+func NameGT(v string) predicate.User {
+	return predicate.User(sql.FieldGT(FieldName, v))
+}
+
+// NameGTE applies the GTE predicate on the "name" field.
+func NameGTE(v string) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldName, v))
+}
+
+// NameLT applies the LT predicate on the "name" field.
+func NameLT(v string) predicate.User {
+	return predicate.User(sql.FieldLT(FieldName, v))
+}
+
+// NameLTE applies the LTE predicate on the "name" field.
+func NameLTE(v string) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldName, v))
+}
+
+// NameContains applies the Contains predicate on the "name" field.
+func NameContains(v string) predicate.User {
+	return predicate.User(sql.FieldContains(FieldName, v))
+}
+
+// NameHasPrefix applies the HasPrefix predicate on the "name" field.
+func NameHasPrefix(v string) predicate.User {
+	return predicate.User(sql.FieldHasPrefix(FieldName, v))
+}
+
+// NameHasSuffix applies the HasSuffix predicate on the "name" field.
+func NameHasSuffix(v string) predicate.User {
+	return predicate.User(sql.FieldHasSuffix(FieldName, v))
+}
+
+// NameEqualFold applies the EqualFold predicate on the "name" field.
+func NameEqualFold(v string) predicate.User {
+	return predicate.User(sql.FieldEqualFold(FieldName, v))
+}
+
+// NameContainsFold applies the ContainsFold predicate on the "name" field.
+func NameContainsFold(v string) predicate.User {
+	return predicate.User(sql.FieldContainsFold(FieldName, v))
+}
+
+// LocationLatEQ applies the EQ predicate on the "location_lat" field.
+func LocationLatEQ(v float64) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldLocationLat, v))
+}
+
+// LocationLatNEQ applies the NEQ predicate on the "location_lat" field.
+func LocationLatNEQ(v float64) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldLocationLat, v))
+}
+
+// LocationLatIn applies the In predicate on the "location_lat" field.
+func LocationLatIn(vs ...float64) predicate.User {
+	return predicate.User(sql.FieldIn(FieldLocationLat, vs...))
+}
+
+// LocationLatNotIn applies the NotIn predicate on the "location_lat" field.
+func LocationLatNotIn(vs ...float64) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldLocationLat, vs...))
+}
+
+// LocationLatGT applies the GT predicate on the "location_lat" field.
+func LocationLatGT(v float64) predicate.User {
+	return predicate.User(sql.FieldGT(FieldLocationLat, v))
+}
+
+// LocationLatGTE applies the GTE predicate on the "location_lat" field.
+func LocationLatGTE(v float64) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldLocationLat, v))
+}
+
+// LocationLatLT applies the LT predicate on the "location_lat" field.
+func LocationLatLT(v float64) predicate.User {
+	return predicate.User(sql.FieldLT(FieldLocationLat, v))
+}
+
+// LocationLatLTE applies the LTE predicate on the "location_lat" field.
+func LocationLatLTE(v float64) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldLocationLat, v))
+}
+
+// LocationLngEQ applies the EQ predicate on the "location_lng" field.
+func LocationLngEQ(v float64) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldLocationLng, v))
+}
+
+// LocationLngNEQ applies the NEQ predicate on the "location_lng" field.
+func LocationLngNEQ(v float64) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldLocationLng, v))
+}
+
+// LocationLngIn applies the In predicate on the "location_lng" field.
+func LocationLngIn(vs ...float64) predicate.User {
+	return predicate.User(sql.FieldIn(FieldLocationLng, vs...))
+}
+
+// LocationLngNotIn applies the NotIn predicate on the "location_lng" field.
+func LocationLngNotIn(vs ...float64) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldLocationLng, vs...))
+}
+
+// LocationLngGT applies the GT predicate on the "location_lng" field.
+func LocationLngGT(v float64) predicate.User {
+	return predicate.User(sql.FieldGT(FieldLocationLng, v))
+}
+
+// LocationLngGTE applies the GTE predicate on the "location_lng" field.
+func LocationLngGTE(v float64) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldLocationLng, v))
+}
+
+// LocationLngLT applies the LT predicate on the "location_lng" field.
+func LocationLngLT(v float64) predicate.User {
+	return predicate.User(sql.FieldLT(FieldLocationLng, v))
+}
+
+// LocationLngLTE applies the LTE predicate on the "location_lng" field.
+func LocationLngLTE(v float64) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldLocationLng, v))
+}
+
+// AddressStreetEQ applies the EQ predicate on the "address_street" field.
+func AddressStreetEQ(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldAddressStreet, v))
+}
+
+// AddressStreetNEQ applies the NEQ predicate on the "address_street" field.
+func AddressStreetNEQ(v string) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldAddressStreet, v))
+}
+
+// AddressStreetIn applies the In predicate on the "address_street" field.
+func AddressStreetIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldIn(FieldAddressStreet, vs...))
+}
+
+// AddressStreetNotIn applies the NotIn predicate on the "address_street" field.
+func AddressStreetNotIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldAddressStreet, vs...))
+}
+
+// AddressStreetGT applies the GT predicate on the "address_street" field.
+func AddressStreetGT(v string) predicate.User {
+	return predicate.User(sql.FieldGT(FieldAddressStreet, v))
+}
+
+// AddressStreetGTE applies the GTE predicate on the "address_street" field.
+func AddressStreetGTE(v string) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldAddressStreet, v))
+}
+
+// AddressStreetLT applies the LT predicate on the "address_street" field.
+func AddressStreetLT(v string) predicate.User {
+	return predicate.User(sql.FieldLT(FieldAddressStreet, v))
+}
+
+// AddressStreetLTE applies the LTE predicate on the "address_street" field.
+func AddressStreetLTE(v string) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldAddressStreet, v))
+}
+
+// AddressStreetContains applies the Contains predicate on the "address_street" field.
+func AddressStreetContains(v string) predicate.User {
+	return predicate.User(sql.FieldContains(FieldAddressStreet, v))
+}
+
+// AddressStreetHasPrefix applies the HasPrefix predicate on the "address_street" field.
+func AddressStreetHasPrefix(v string) predicate.User {
+	return predicate.User(sql.FieldHasPrefix(FieldAddressStreet, v))
+}
+
+// AddressStreetHasSuffix applies the HasSuffix predicate on the "address_street" field.
+func AddressStreetHasSuffix(v string) predicate.User {
+	return predicate.User(sql.FieldHasSuffix(FieldAddressStreet, v))
+}
+
+// AddressStreetIsNil applies the IsNil predicate on the "address_street" field.
+func AddressStreetIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldAddressStreet))
+}
+
+// AddressStreetNotNil applies the NotNil predicate on the "address_street" field.
+func AddressStreetNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldAddressStreet))
+}
+
+// AddressStreetEqualFold applies the EqualFold predicate on the "address_street" field.
+func AddressStreetEqualFold(v string) predicate.User {
+	return predicate.User(sql.FieldEqualFold(FieldAddressStreet, v))
+}
+
+// AddressStreetContainsFold applies the ContainsFold predicate on the "address_street" field.
+func AddressStreetContainsFold(v string) predicate.User {
+	return predicate.User(sql.FieldContainsFold(FieldAddressStreet, v))
+}
+
+// AddressCityEQ applies the EQ predicate on the "address_city" field.
+func AddressCityEQ(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldAddressCity, v))
+}
+
+// AddressCityNEQ applies the NEQ predicate on the "address_city" field.
+func AddressCityNEQ(v string) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldAddressCity, v))
+}
+
+// AddressCityIn applies the In predicate on the "address_city" field.
+func AddressCityIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldIn(FieldAddressCity, vs...))
+}
+
+// AddressCityNotIn applies the NotIn predicate on the "address_city" field.
+func AddressCityNotIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldAddressCity, vs...))
+}
+
+// AddressCityGT applies the GT predicate on the "address_city" field.
+func AddressCityGT(v string) predicate.User {
+	return predicate.User(sql.FieldGT(FieldAddressCity, v))
+}
+
+// AddressCityGTE applies the GTE predicate on the "address_city" field.
+func AddressCityGTE(v string) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldAddressCity, v))
+}
+
+// AddressCityLT applies the LT predicate on the "address_city" field.
+func AddressCityLT(v string) predicate.User {
+	return predicate.User(sql.FieldLT(FieldAddressCity, v))
+}
+
+// AddressCityLTE applies the LTE predicate on the "address_city" field.
+func AddressCityLTE(v string) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldAddressCity, v))
+}
+
+// AddressCityContains applies the Contains predicate on the "address_city" field.
+func AddressCityContains(v string) predicate.User {
+	return predicate.User(sql.FieldContains(FieldAddressCity, v))
+}
+
+// AddressCityHasPrefix applies the HasPrefix predicate on the "address_city" field.
+func AddressCityHasPrefix(v string) predicate.User {
+	return predicate.User(sql.FieldHasPrefix(FieldAddressCity, v))
+}
+
+// AddressCityHasSuffix applies the HasSuffix predicate on the "address_city" field.
+func AddressCityHasSuffix(v string) predicate.User {
+	return predicate.User(sql.FieldHasSuffix(FieldAddressCity, v))
+}
+
+// AddressCityIsNil applies the IsNil predicate on the "address_city" field.
+func AddressCityIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldAddressCity))
+}
+
+// AddressCityNotNil applies the NotNil predicate on the "address_city" field.
+func AddressCityNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldAddressCity))
+}
+
+// AddressCityEqualFold applies the EqualFold predicate on the "address_city" field.
+func AddressCityEqualFold(v string) predicate.User {
+	return predicate.User(sql.FieldEqualFold(FieldAddressCity, v))
+}
+
+// AddressCityContainsFold applies the ContainsFold predicate on the "address_city" field.
+func AddressCityContainsFold(v string) predicate.User {
+	return predicate.User(sql.FieldContainsFold(FieldAddressCity, v))
+}
+
+// AddressZipEQ applies the EQ predicate on the "address_zip" field.
+func AddressZipEQ(v schema.Zip) predicate.User {
+	vc := string(v)
+	return predicate.User(sql.FieldEQ(FieldAddressZip, vc))
+}
+
+// AddressZipNEQ applies the NEQ predicate on the "address_zip" field.
+func AddressZipNEQ(v schema.Zip) predicate.User {
+	vc := string(v)
+	return predicate.User(sql.FieldNEQ(FieldAddressZip, vc))
+}
+
+// AddressZipIn applies the In predicate on the "address_zip" field.
+func AddressZipIn(vs ...schema.Zip) predicate.User {
+	v := make([]any, len(vs))
+	for i := range v {
+		v[i] = string(vs[i])
+	}
+	return predicate.User(sql.FieldIn(FieldAddressZip, v...))
+}
+
+// AddressZipNotIn applies the NotIn predicate on the "address_zip" field.
+func AddressZipNotIn(vs ...schema.Zip) predicate.User {
+	v := make([]any, len(vs))
+	for i := range v {
+		v[i] = string(vs[i])
+	}
+	return predicate.User(sql.FieldNotIn(FieldAddressZip, v...))
+}
+
+// AddressZipGT applies the GT predicate on the "address_zip" field.
+func AddressZipGT(v schema.Zip) predicate.User {
+	vc := string(v)
+	return predicate.User(sql.FieldGT(FieldAddressZip, vc))
+}
+
+// AddressZipGTE applies the GTE predicate on the "address_zip" field.
+func AddressZipGTE(v schema.Zip) predicate.User {
+	vc := string(v)
+	return predicate.User(sql.FieldGTE(FieldAddressZip, vc))
+}
+
+// AddressZipLT applies the LT predicate on the "address_zip" field.
+func AddressZipLT(v schema.Zip) predicate.User {
+	vc := string(v)
+	return predicate.User(sql.FieldLT(FieldAddressZip, vc))
+}
+
+// AddressZipLTE applies the LTE predicate on the "address_zip" field.
+func AddressZipLTE(v schema.Zip) predicate.User {
+	vc := string(v)
+	return predicate.User(sql.FieldLTE(FieldAddressZip, vc))
+}
+
+// AddressZipContains applies the Contains predicate on the "address_zip" field.
+func AddressZipContains(v schema.Zip) predicate.User {
+	vc := string(v)
+	return predicate.User(sql.FieldContains(FieldAddressZip, vc))
+}
+
+// AddressZipHasPrefix applies the HasPrefix predicate on the "address_zip" field.
+func AddressZipHasPrefix(v schema.Zip) predicate.User {
+	vc := string(v)
+	return predicate.User(sql.FieldHasPrefix(FieldAddressZip, vc))
+}
+
+// AddressZipHasSuffix applies the HasSuffix predicate on the "address_zip" field.
+func AddressZipHasSuffix(v schema.Zip) predicate.User {
+	vc := string(v)
+	return predicate.User(sql.FieldHasSuffix(FieldAddressZip, vc))
+}
+
+// AddressZipIsNil applies the IsNil predicate on the "address_zip" field.
+func AddressZipIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldAddressZip))
+}
+
+// AddressZipNotNil applies the NotNil predicate on the "address_zip" field.
+func AddressZipNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldAddressZip))
+}
+
+// AddressZipEqualFold applies the EqualFold predicate on the "address_zip" field.
+func AddressZipEqualFold(v schema.Zip) predicate.User {
+	vc := string(v)
+	return predicate.User(sql.FieldEqualFold(FieldAddressZip, vc))
+}
+
+// AddressZipContainsFold applies the ContainsFold predicate on the "address_zip" field.
+func AddressZipContainsFold(v schema.Zip) predicate.User {
+	vc := string(v)
+	return predicate.User(sql.FieldContainsFold(FieldAddressZip, vc))
+}
+
+// LocationEQ applies the EQ predicate on all fields of the "location" embedded field.
+func LocationEQ(v schema.Location) predicate.User {
+	return And(
+		LocationLatEQ(v.Lat),
+		LocationLngEQ(v.Lng),
+	)
+}
+
+// AddressEQ applies the EQ predicate on all fields of the "address" embedded field.
+func AddressEQ(v schema.Address) predicate.User {
+	return And(
+		AddressStreetEQ(v.Street),
+		AddressCityEQ(v.City),
+		AddressZipEQ(v.Zip),
+	)
+}
+
+// AddressIsNil applies the IsNil predicate on all fields of the "address" embedded field.
+func AddressIsNil() predicate.User {
+	return And(
+		AddressStreetIsNil(),
+		AddressCityIsNil(),
+		AddressZipIsNil(),
+	)
+}
+
+// AddressNotNil applies the NotNil predicate on the "address" embedded field. It matches
+// entities that at least one of the fields of the embedded field is not NULL.
+func AddressNotNil() predicate.User {
+	return Or(
+		AddressStreetNotNil(),
+		AddressCityNotNil(),
+		AddressZipNotNil(),
+	)
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.User) predicate.User {
+	return predicate.User(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.User) predicate.User {
+	return predicate.User(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.User) predicate.User {
+	return predicate.User(sql.NotPredicates(p))
+}
@@ -0,0 +1,288 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/entc/integration/embed/ent/schema"
+	"entgo.io/ent/entc/integration/embed/ent/user"
+	"entgo.io/ent/schema/field"
+)
+
+// UserCreate is the builder for creating a User entity.
+type UserCreate struct {
+	config
+	mutation *UserMutation
+	hooks    []Hook
+}
+
+// SetName sets the "name" field.
+func (_c *UserCreate) SetName(v string) *UserCreate {
+	_c.mutation.SetName(v)
+	return _c
+}
+
+// SetLocationLat sets the "location_lat" field.
+func (_c *UserCreate) SetLocationLat(v float64) *UserCreate {
+	_c.mutation.SetLocationLat(v)
+	return _c
+}
+
+// SetLocationLng sets the "location_lng" field.
+func (_c *UserCreate) SetLocationLng(v float64) *UserCreate {
+	_c.mutation.SetLocationLng(v)
+	return _c
+}
+
+// SetAddressStreet sets the "address_street" field.
+func (_c *UserCreate) SetAddressStreet(v string) *UserCreate {
+	_c.mutation.SetAddressStreet(v)
+	return _c
+}
+
+// SetNillableAddressStreet sets the "address_street" field if the given value is not nil.
+func (_c *UserCreate) SetNillableAddressStreet(v *string) *UserCreate {
+	if v != nil {
+		_c.SetAddressStreet(*v)
+	}
+	return _c
+}
+
+// SetAddressCity sets the "address_city" field.
+func (_c *UserCreate) SetAddressCity(v string) *UserCreate {
+	_c.mutation.SetAddressCity(v)
+	return _c
+}
+
+// SetNillableAddressCity sets the "address_city" field if the given value is not nil.
+func (_c *UserCreate) SetNillableAddressCity(v *string) *UserCreate {
+	if v != nil {
+		_c.SetAddressCity(*v)
+	}
+	return _c
+}
+
+// SetAddressZip sets the "address_zip" field.
+func (_c *UserCreate) SetAddressZip(v schema.Zip) *UserCreate {
+	_c.mutation.SetAddressZip(v)
+	return _c
+}
+
+// SetNillableAddressZip sets the "address_zip" field if the given value is not nil.
+func (_c *UserCreate) SetNillableAddressZip(v *schema.Zip) *UserCreate {
+	if v != nil {
+		_c.SetAddressZip(*v)
+	}
+	return _c
+}
+
+// SetLocation sets the fields of the "location" embedded field.
+func (_c *UserCreate) SetLocation(v schema.Location) *UserCreate {
+	_c.mutation.SetLocation(v)
+	return _c
+}
+
+// SetAddress sets the fields of the "address" embedded field.
+func (_c *UserCreate) SetAddress(v schema.Address) *UserCreate {
+	_c.mutation.SetAddress(v)
+	return _c
+}
+
+// SetNillableAddress sets the fields of the "address" embedded field if the given value is not nil.
+func (_c *UserCreate) SetNillableAddress(v *schema.Address) *UserCreate {
+	if v != nil {
+		_c.SetAddress(*v)
+	}
+	return _c
+}
+
+// Mutation returns the UserMutation object of the builder.
+func (_c *UserCreate) Mutation() *UserMutation {
+	return _c.mutation
+}
+
+// Save creates the User in the database.
+func (_c *UserCreate) Save(ctx context.Context) (*User, error) {
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *UserCreate) SaveX(ctx context.Context) *User {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *UserCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *UserCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *UserCreate) check() error {
+	if _, ok := _c.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "User.name"`)}
+	}
+	if _, ok := _c.mutation.LocationLat(); !ok {
+		return &ValidationError{Name: "location_lat", err: errors.New(`ent: missing required field "User.location_lat"`)}
+	}
+	if _, ok := _c.mutation.LocationLng(); !ok {
+		return &ValidationError{Name: "location_lng", err: errors.New(`ent: missing required field "User.location_lng"`)}
+	}
+	return nil
+}
+
+func (_c *UserCreate) sqlSave(ctx context.Context) (*User, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *UserCreate) createSpec() (*User, *sqlgraph.CreateSpec) {
+	var (
+		_node = &User{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(user.Table, sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.Name(); ok {
+		_spec.SetField(user.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := _c.mutation.LocationLat(); ok {
+		_spec.SetField(user.FieldLocationLat, field.TypeFloat64, value)
+		_node.LocationLat = value
+	}
+	if value, ok := _c.mutation.LocationLng(); ok {
+		_spec.SetField(user.FieldLocationLng, field.TypeFloat64, value)
+		_node.LocationLng = value
+	}
+	if value, ok := _c.mutation.AddressStreet(); ok {
+		_spec.SetField(user.FieldAddressStreet, field.TypeString, value)
+		_node.AddressStreet = &value
+	}
+	if value, ok := _c.mutation.AddressCity(); ok {
+		_spec.SetField(user.FieldAddressCity, field.TypeString, value)
+		_node.AddressCity = &value
+	}
+	if value, ok := _c.mutation.AddressZip(); ok {
+		_spec.SetField(user.FieldAddressZip, field.TypeString, value)
+		_node.AddressZip = &value
+	}
+	return _node, _spec
+}
+
+// UserCreateBulk is the builder for creating many User entities in bulk.
+type UserCreateBulk struct {
+	config
+	err      error
+	builders []*UserCreate
+}
+
+// Save creates the User entities in the database.
+func (_c *UserCreateBulk) Save(ctx context.Context) ([]*User, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*User, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*UserMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *UserCreateBulk) SaveX(ctx context.Context) []*User {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *UserCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *UserCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,546 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/entc/integration/embed/ent/predicate"
+	"entgo.io/ent/entc/integration/embed/ent/schema"
+	"entgo.io/ent/entc/integration/embed/ent/user"
+	"entgo.io/ent/schema/field"
+)
+
+// UserUpdate is the builder for updating User entities.
+type UserUpdate struct {
+	config
+	hooks    []Hook
+	mutation *UserMutation
+}
+
+// Where appends a list predicates to the UserUpdate builder.
+func (_u *UserUpdate) Where(ps ...predicate.User) *UserUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *UserUpdate) SetName(v string) *UserUpdate {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *UserUpdate) SetNillableName(v *string) *UserUpdate {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// SetLocationLat sets the "location_lat" field.
+func (_u *UserUpdate) SetLocationLat(v float64) *UserUpdate {
+	_u.mutation.ResetLocationLat()
+	_u.mutation.SetLocationLat(v)
+	return _u
+}
+
+// SetNillableLocationLat sets the "location_lat" field if the given value is not nil.
+func (_u *UserUpdate) SetNillableLocationLat(v *float64) *UserUpdate {
+	if v != nil {
+		_u.SetLocationLat(*v)
+	}
+	return _u
+}
+
+// AddLocationLat adds value to the "location_lat" field.
+func (_u *UserUpdate) AddLocationLat(v float64) *UserUpdate {
+	_u.mutation.AddLocationLat(v)
+	return _u
+}
+
+// SetLocationLng sets the "location_lng" field.
+func (_u *UserUpdate) SetLocationLng(v float64) *UserUpdate {
+	_u.mutation.ResetLocationLng()
+	_u.mutation.SetLocationLng(v)
+	return _u
+}
+
+// SetNillableLocationLng sets the "location_lng" field if the given value is not nil.
+func (_u *UserUpdate) SetNillableLocationLng(v *float64) *UserUpdate {
+	if v != nil {
+		_u.SetLocationLng(*v)
+	}
+	return _u
+}
+
+// AddLocationLng adds value to the "location_lng" field.
+func (_u *UserUpdate) AddLocationLng(v float64) *UserUpdate {
+	_u.mutation.AddLocationLng(v)
+	return _u
+}
+
+// SetAddressStreet sets the "address_street" field.
+func (_u *UserUpdate) SetAddressStreet(v string) *UserUpdate {
+	_u.mutation.SetAddressStreet(v)
+	return _u
+}
+
+// SetNillableAddressStreet sets the "address_street" field if the given value is not nil.
+func (_u *UserUpdate) SetNillableAddressStreet(v *string) *UserUpdate {
+	if v != nil {
+		_u.SetAddressStreet(*v)
+	}
+	return _u
+}
+
+// ClearAddressStreet clears the value of the "address_street" field.
+func (_u *UserUpdate) ClearAddressStreet() *UserUpdate {
+	_u.mutation.ClearAddressStreet()
+	return _u
+}
+
+// SetAddressCity sets the "address_city" field.
+func (_u *UserUpdate) SetAddressCity(v string) *UserUpdate {
+	_u.mutation.SetAddressCity(v)
+	return _u
+}
+
+// SetNillableAddressCity sets the "address_city" field if the given value is not nil.
+func (_u *UserUpdate) SetNillableAddressCity(v *string) *UserUpdate {
+	if v != nil {
+		_u.SetAddressCity(*v)
+	}
+	return _u
+}
+
+// ClearAddressCity clears the value of the "address_city" field.
+func (_u *UserUpdate) ClearAddressCity() *UserUpdate {
+	_u.mutation.ClearAddressCity()
+	return _u
+}
+
+// SetAddressZip sets the "address_zip" field.
+func (_u *UserUpdate) SetAddressZip(v schema.Zip) *UserUpdate {
+	_u.mutation.SetAddressZip(v)
+	return _u
+}
+
+// SetNillableAddressZip sets the "address_zip" field if the given value is not nil.
+func (_u *UserUpdate) SetNillableAddressZip(v *schema.Zip) *UserUpdate {
+	if v != nil {
+		_u.SetAddressZip(*v)
+	}
+	return _u
+}
+
+// ClearAddressZip clears the value of the "address_zip" field.
+func (_u *UserUpdate) ClearAddressZip() *UserUpdate {
+	_u.mutation.ClearAddressZip()
+	return _u
+}
+
+// SetLocation sets the fields of the "location" embedded field.
+func (_u *UserUpdate) SetLocation(v schema.Location) *UserUpdate {
+	_u.mutation.SetLocation(v)
+	return _u
+}
+
+// SetNillableLocation sets the fields of the "location" embedded field if the given value is not nil.
+func (_u *UserUpdate) SetNillableLocation(v *schema.Location) *UserUpdate {
+	if v != nil {
+		_u.SetLocation(*v)
+	}
+	return _u
+}
+
+// SetAddress sets the fields of the "address" embedded field.
+func (_u *UserUpdate) SetAddress(v schema.Address) *UserUpdate {
+	_u.mutation.SetAddress(v)
+	return _u
+}
+
+// SetNillableAddress sets the fields of the "address" embedded field if the given value is not nil.
+func (_u *UserUpdate) SetNillableAddress(v *schema.Address) *UserUpdate {
+	if v != nil {
+		_u.SetAddress(*v)
+	}
+	return _u
+}
+
+// ClearAddress clears the fields of the "address" embedded field.
+func (_u *UserUpdate) ClearAddress() *UserUpdate {
+	_u.mutation.ClearAddress()
+	return _u
+}
+
+// Mutation returns the UserMutation object of the builder.
+func (_u *UserUpdate) Mutation() *UserMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *UserUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *UserUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *UserUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *UserUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *UserUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(user.Table, user.Columns, sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(user.FieldName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.LocationLat(); ok {
+		_spec.SetField(user.FieldLocationLat, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedLocationLat(); ok {
+		_spec.AddField(user.FieldLocationLat, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.LocationLng(); ok {
+		_spec.SetField(user.FieldLocationLng, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedLocationLng(); ok {
+		_spec.AddField(user.FieldLocationLng, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddressStreet(); ok {
+		_spec.SetField(user.FieldAddressStreet, field.TypeString, value)
+	}
+	if _u.mutation.AddressStreetCleared() {
+		_spec.ClearField(user.FieldAddressStreet, field.TypeString)
+	}
+	if value, ok := _u.mutation.AddressCity(); ok {
+		_spec.SetField(user.FieldAddressCity, field.TypeString, value)
+	}
+	if _u.mutation.AddressCityCleared() {
+		_spec.ClearField(user.FieldAddressCity, field.TypeString)
+	}
+	if value, ok := _u.mutation.AddressZip(); ok {
+		_spec.SetField(user.FieldAddressZip, field.TypeString, value)
+	}
+	if _u.mutation.AddressZipCleared() {
+		_spec.ClearField(user.FieldAddressZip, field.TypeString)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{user.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// UserUpdateOne is the builder for updating a single User entity.
+type UserUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *UserMutation
+}
+
+// SetName sets the "name" field.
+func (_u *UserUpdateOne) SetName(v string) *UserUpdateOne {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableName(v *string) *UserUpdateOne {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// SetLocationLat sets the "location_lat" field.
+func (_u *UserUpdateOne) SetLocationLat(v float64) *UserUpdateOne {
+	_u.mutation.ResetLocationLat()
+	_u.mutation.SetLocationLat(v)
+	return _u
+}
+
+// SetNillableLocationLat sets the "location_lat" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableLocationLat(v *float64) *UserUpdateOne {
+	if v != nil {
+		_u.SetLocationLat(*v)
+	}
+	return _u
+}
+
+// AddLocationLat adds value to the "location_lat" field.
+func (_u *UserUpdateOne) AddLocationLat(v float64) *UserUpdateOne {
+	_u.mutation.AddLocationLat(v)
+	return _u
+}
+
+// SetLocationLng sets the "location_lng" field.
+func (_u *UserUpdateOne) SetLocationLng(v float64) *UserUpdateOne {
+	_u.mutation.ResetLocationLng()
+	_u.mutation.SetLocationLng(v)
+	return _u
+}
+
+// SetNillableLocationLng sets the "location_lng" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableLocationLng(v *float64) *UserUpdateOne {
+	if v != nil {
+		_u.SetLocationLng(*v)
+	}
+	return _u
+}
+
+// AddLocationLng adds value to the "location_lng" field.
+func (_u *UserUpdateOne) AddLocationLng(v float64) *UserUpdateOne {
+	_u.mutation.AddLocationLng(v)
+	return _u
+}
+
+// SetAddressStreet sets the "address_street" field.
+func (_u *UserUpdateOne) SetAddressStreet(v string) *UserUpdateOne {
+	_u.mutation.SetAddressStreet(v)
+	return _u
+}
+
+// SetNillableAddressStreet sets the "address_street" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableAddressStreet(v *string) *UserUpdateOne {
+	if v != nil {
+		_u.SetAddressStreet(*v)
+	}
+	return _u
+}
+
+// ClearAddressStreet clears the value of the "address_street" field.
+func (_u *UserUpdateOne) ClearAddressStreet() *UserUpdateOne {
+	_u.mutation.ClearAddressStreet()
+	return _u
+}
+
+// SetAddressCity sets the "address_city" field.
+func (_u *UserUpdateOne) SetAddressCity(v string) *UserUpdateOne {
+	_u.mutation.SetAddressCity(v)
+	return _u
+}
+
+// SetNillableAddressCity sets the "address_city" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableAddressCity(v *string) *UserUpdateOne {
+	if v != nil {
+		_u.SetAddressCity(*v)
+	}
+	return _u
+}
+
+// ClearAddressCity clears the value of the "address_city" field.
+func (_u *UserUpdateOne) ClearAddressCity() *UserUpdateOne {
+	_u.mutation.ClearAddressCity()
+	return _u
+}
+
+// SetAddressZip sets the "address_zip" field.
+func (_u *UserUpdateOne) SetAddressZip(v schema.Zip) *UserUpdateOne {
+	_u.mutation.SetAddressZip(v)
+	return _u
+}
+
+// SetNillableAddressZip sets the "address_zip" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableAddressZip(v *schema.Zip) *UserUpdateOne {
+	if v != nil {
+		_u.SetAddressZip(*v)
+	}
+	return _u
+}
+
+// ClearAddressZip clears the value of the "address_zip" field.
+func (_u *UserUpdateOne) ClearAddressZip() *UserUpdateOne {
+	_u.mutation.ClearAddressZip()
+	return _u
+}
+
+// SetLocation sets the fields of the "location" embedded field.
+func (_u *UserUpdateOne) SetLocation(v schema.Location) *UserUpdateOne {
+	_u.mutation.SetLocation(v)
+	return _u
+}
+
+// SetNillableLocation sets the fields of the "location" embedded field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableLocation(v *schema.Location) *UserUpdateOne {
+	if v != nil {
+		_u.SetLocation(*v)
+	}
+	return _u
+}
+
+// SetAddress sets the fields of the "address" embedded field.
+func (_u *UserUpdateOne) SetAddress(v schema.Address) *UserUpdateOne {
+	_u.mutation.SetAddress(v)
+	return _u
+}
+
+// SetNillableAddress sets the fields of the "address" embedded field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableAddress(v *schema.Address) *UserUpdateOne {
+	if v != nil {
+		_u.SetAddress(*v)
+	}
+	return _u
+}
+
+// ClearAddress clears the fields of the "address" embedded field.
+func (_u *UserUpdateOne) ClearAddress() *UserUpdateOne {
+	_u.mutation.ClearAddress()
+	return _u
+}
+
+// Mutation returns the UserMutation object of the builder.
+func (_u *UserUpdateOne) Mutation() *UserMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the UserUpdate builder.
+func (_u *UserUpdateOne) Where(ps ...predicate.User) *UserUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *UserUpdateOne) Select(field string, fields ...string) *UserUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated User entity.
+func (_u *UserUpdateOne) Save(ctx context.Context) (*User, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *UserUpdateOne) SaveX(ctx context.Context) *User {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *UserUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *UserUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error) {
+	_spec := sqlgraph.NewUpdateSpec(user.Table, user.Columns, sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "User.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, user.FieldID)
+		for _, f := range fields {
+			if !user.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != user.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(user.FieldName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.LocationLat(); ok {
+		_spec.SetField(user.FieldLocationLat, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedLocationLat(); ok {
+		_spec.AddField(user.FieldLocationLat, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.LocationLng(); ok {
+		_spec.SetField(user.FieldLocationLng, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedLocationLng(); ok {
+		_spec.AddField(user.FieldLocationLng, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddressStreet(); ok {
+		_spec.SetField(user.FieldAddressStreet, field.TypeString, value)
+	}
+	if _u.mutation.AddressStreetCleared() {
+		_spec.ClearField(user.FieldAddressStreet, field.TypeString)
+	}
+	if value, ok := _u.mutation.AddressCity(); ok {
+		_spec.SetField(user.FieldAddressCity, field.TypeString, value)
+	}
+	if _u.mutation.AddressCityCleared() {
+		_spec.ClearField(user.FieldAddressCity, field.TypeString)
+	}
+	if value, ok := _u.mutation.AddressZip(); ok {
+		_spec.SetField(user.FieldAddressZip, field.TypeString, value)
+	}
+	if _u.mutation.AddressZipCleared() {
+		_spec.ClearField(user.FieldAddressZip, field.TypeString)
+	}
+	_node = &User{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{user.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -39,7 +39,7 @@ func (_u *APIUpdate) Mutation() *APIMutation {
 
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *APIUpdate) Save(ctx context.Context) (int, error) {
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
@@ -121,7 +121,7 @@ func (_u *APIUpdateOne) Select(field string, fields ...string) *APIUpdateOne {
 
 // Save executes the query and returns the updated Api entity.
 func (_u *APIUpdateOne) Save(ctx context.Context) (*Api, error) {
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
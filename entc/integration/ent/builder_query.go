@@ -9,6 +9,7 @@ package ent
 import (
 	"context"
 	"fmt"
+	"iter"
 	"math"
 
 	"entgo.io/ent"
@@ -430,6 +431,137 @@ func (_q *BuilderQuery) sqlQuery(ctx context.Context) *sql.Selector {
 	return selector
 }
 
+// Iter returns an iterator over the Builders that match the query. Unlike All, the rows
+// are scanned lazily, one at a time, and the iteration can be stopped at any point.
+//
+//	for node, err := range client.Builder.Query().Iter(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		// Process node.
+//	}
+//
+// Query interceptors are executed while the rows are streamed. However, the value they
+// receive from the next interceptor is empty, as the nodes are yielded to the caller.
+// Eager-loading edges is not supported by Iter, and IterChunks should be used instead.
+func (_q *BuilderQuery) Iter(ctx context.Context) iter.Seq2[*Builder, error] {
+	return func(yield func(*Builder, error) bool) {
+		ctx := setContextOp(ctx, _q.ctx, ent.OpQueryIter)
+		if err := _q.prepareQuery(ctx); err != nil {
+			yield(nil, err)
+			return
+		}
+		stopped := false
+		qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+			query, ok := q.(*BuilderQuery)
+			if !ok {
+				return nil, fmt.Errorf("unexpected query type %T", q)
+			}
+			for node, err := range query.sqlIter(ctx) {
+				if err != nil {
+					return nil, err
+				}
+				if !yield(node, nil) {
+					stopped = true
+					break
+				}
+			}
+			return []*Builder{}, nil
+		})
+		if _, err := withInterceptors[[]*Builder](ctx, _q, qr, _q.inters); err != nil && !stopped {
+			yield(nil, err)
+		}
+	}
+}
+
+func (_q *BuilderQuery) sqlIter(ctx context.Context) iter.Seq2[*Builder, error] {
+	return func(yield func(*Builder, error) bool) {
+		var (
+			_node *Builder
+			_spec = _q.querySpec()
+		)
+		_spec.ScanValues = func(columns []string) ([]any, error) {
+			return (*Builder).scanValues(nil, columns)
+		}
+		_spec.Assign = func(columns []string, values []any) error {
+			_node = &Builder{config: _q.config}
+			return _node.assignValues(columns, values)
+		}
+		if len(_q.modifiers) > 0 {
+			_spec.Modifiers = _q.modifiers
+		}
+		for err := range sqlgraph.QueryNodesIter(ctx, _q.driver, _spec) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(_node, nil) {
+				return
+			}
+		}
+	}
+}
+
+// IterChunks returns an iterator over the Builders that match the query. The nodes are
+// loaded in chunks of the given size using All, and therefore, eager-loaded edges, interceptors
+// and privacy policies are applied to each chunk.
+//
+// If no order was set on the query, the chunks are paginated using the ID column (keyset
+// pagination). Otherwise, they are paginated using OFFSET, and the order should be total.
+func (_q *BuilderQuery) IterChunks(ctx context.Context, size int) iter.Seq2[*Builder, error] {
+	return func(yield func(*Builder, error) bool) {
+		if size <= 0 {
+			yield(nil, fmt.Errorf("ent: invalid chunk size: %d", size))
+			return
+		}
+		var (
+			offset int
+			limit  = _q.ctx.Limit
+			last   any
+			keyset = len(_q.order) == 0
+		)
+		if _q.ctx.Offset != nil {
+			offset = *_q.ctx.Offset
+		}
+		for {
+			n := size
+			if limit != nil {
+				if n = min(n, *limit); n <= 0 {
+					return
+				}
+			}
+			query := _q.Clone().Offset(offset).Limit(n)
+			if keyset {
+				query.Order(builder.ByID())
+				if last != nil {
+					query.Where(sql.FieldGT(builder.FieldID, last))
+				}
+			}
+			nodes, err := query.All(ctx)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, node := range nodes {
+				if !yield(node, nil) {
+					return
+				}
+			}
+			if len(nodes) < n {
+				return
+			}
+			if keyset {
+				last, offset = nodes[len(nodes)-1].ID, 0
+			} else {
+				offset += len(nodes)
+			}
+			if limit != nil {
+				limit = func(v int) *int { return &v }(*limit - len(nodes))
+			}
+		}
+	}
+}
+
 // ForUpdate locks the selected rows against concurrent updates, and prevent them from being
 // updated, deleted or "selected ... for update" by other sessions, until the transaction is
 // either committed or rolled-back.
@@ -462,6 +594,73 @@ func (_q *BuilderQuery) Modify(modifiers ...func(s *sql.Selector)) *BuilderSelec
 	return _q.Select()
 }
 
+// Paginate returns the first Builders that are sorted after the given cursor, using keyset
+// pagination. The nodes are sorted by the query order, followed by the given options, and the
+// Builder ID is used as a tie-breaker. An empty cursor returns the first page.
+//
+//	page, err := client.Builder.Query().Paginate(ctx, "", 10)
+//	if err != nil {
+//		return err
+//	}
+//	for page.HasNextPage {
+//		page, err = client.Builder.Query().Paginate(ctx, page.EndCursor, 10)
+//		// ...
+//	}
+//
+// Any ordering option can be used, including ordering by edge-count and neighbor terms.
+// However, the cursor is valid only for queries with the same ordering, and the limit and
+// offset of the query are ignored.
+func (_q *BuilderQuery) Paginate(ctx context.Context, after string, first int, opts ...builder.OrderOption) (*Page[*Builder], error) {
+	if first <= 0 {
+		return nil, fmt.Errorf("ent: invalid page size: %d", first)
+	}
+	var values []any
+	if after != "" {
+		var err error
+		if values, err = sqlgraph.DecodeCursor(after); err != nil {
+			return nil, err
+		}
+	}
+	query := _q.Clone()
+	query.ctx.Offset = nil
+	query.Limit(first+1).Order(opts...).Order(builder.ByID(), func(s *sql.Selector) {
+		sqlgraph.KeysetAfter(s, values)
+	})
+	nodes, err := query.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	page := &Page[*Builder]{Nodes: nodes}
+	if len(nodes) > first {
+		page.Nodes, page.HasNextPage = nodes[:first], true
+	}
+	if n := len(page.Nodes); n > 0 {
+		if page.StartCursor, err = page.Nodes[0].cursor(); err != nil {
+			return nil, err
+		}
+		if page.EndCursor, err = page.Nodes[n-1].cursor(); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// cursor returns the pagination cursor of the Builder that was loaded by Paginate.
+func (_m *Builder) cursor() (string, error) {
+	var values []any
+	for i := 0; ; i++ {
+		v, err := _m.Value(sqlgraph.CursorColumn(i))
+		if err != nil {
+			break
+		}
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return "", fmt.Errorf("ent: missing cursor values for Builder")
+	}
+	return sqlgraph.EncodeCursor(values)
+}
+
 // BuilderGroupBy is the group-by builder for Builder entities.
 type BuilderGroupBy struct {
 	selector
@@ -39,7 +39,7 @@ func (_u *BuilderUpdate) Mutation() *BuilderMutation {
 
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *BuilderUpdate) Save(ctx context.Context) (int, error) {
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
@@ -121,7 +121,7 @@ func (_u *BuilderUpdateOne) Select(field string, fields ...string) *BuilderUpdat
 
 // Save executes the query and returns the updated Builder entity.
 func (_u *BuilderUpdateOne) Save(ctx context.Context) (*Builder, error) {
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
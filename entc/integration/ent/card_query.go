@@ -9,7 +9,9 @@ package ent
 import (
 	"context"
 	"database/sql/driver"
+	"errors"
 	"fmt"
+	"iter"
 	"math"
 
 	"entgo.io/ent"
@@ -658,6 +660,144 @@ func (_q *CardQuery) sqlQuery(ctx context.Context) *sql.Selector {
 	return selector
 }
 
+// Iter returns an iterator over the Cards that match the query. Unlike All, the rows
+// are scanned lazily, one at a time, and the iteration can be stopped at any point.
+//
+//	for node, err := range client.Card.Query().Iter(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		// Process node.
+//	}
+//
+// Query interceptors are executed while the rows are streamed. However, the value they
+// receive from the next interceptor is empty, as the nodes are yielded to the caller.
+// Eager-loading edges is not supported by Iter, and IterChunks should be used instead.
+func (_q *CardQuery) Iter(ctx context.Context) iter.Seq2[*Card, error] {
+	return func(yield func(*Card, error) bool) {
+		ctx := setContextOp(ctx, _q.ctx, ent.OpQueryIter)
+		if _q.withOwner != nil || _q.withSpec != nil || _q.withNamedSpec != nil {
+			yield(nil, errors.New("ent: eager-loading edges is not supported by Iter, use IterChunks instead"))
+			return
+		}
+		if err := _q.prepareQuery(ctx); err != nil {
+			yield(nil, err)
+			return
+		}
+		stopped := false
+		qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+			query, ok := q.(*CardQuery)
+			if !ok {
+				return nil, fmt.Errorf("unexpected query type %T", q)
+			}
+			for node, err := range query.sqlIter(ctx) {
+				if err != nil {
+					return nil, err
+				}
+				if !yield(node, nil) {
+					stopped = true
+					break
+				}
+			}
+			return []*Card{}, nil
+		})
+		if _, err := withInterceptors[[]*Card](ctx, _q, qr, _q.inters); err != nil && !stopped {
+			yield(nil, err)
+		}
+	}
+}
+
+func (_q *CardQuery) sqlIter(ctx context.Context) iter.Seq2[*Card, error] {
+	return func(yield func(*Card, error) bool) {
+		var (
+			_node *Card
+			_spec = _q.querySpec()
+		)
+		if _q.withFKs {
+			_spec.Node.Columns = append(_spec.Node.Columns, card.ForeignKeys...)
+		}
+		_spec.ScanValues = func(columns []string) ([]any, error) {
+			return (*Card).scanValues(nil, columns)
+		}
+		_spec.Assign = func(columns []string, values []any) error {
+			_node = &Card{config: _q.config}
+			return _node.assignValues(columns, values)
+		}
+		if len(_q.modifiers) > 0 {
+			_spec.Modifiers = _q.modifiers
+		}
+		for err := range sqlgraph.QueryNodesIter(ctx, _q.driver, _spec) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(_node, nil) {
+				return
+			}
+		}
+	}
+}
+
+// IterChunks returns an iterator over the Cards that match the query. The nodes are
+// loaded in chunks of the given size using All, and therefore, eager-loaded edges, interceptors
+// and privacy policies are applied to each chunk.
+//
+// If no order was set on the query, the chunks are paginated using the ID column (keyset
+// pagination). Otherwise, they are paginated using OFFSET, and the order should be total.
+func (_q *CardQuery) IterChunks(ctx context.Context, size int) iter.Seq2[*Card, error] {
+	return func(yield func(*Card, error) bool) {
+		if size <= 0 {
+			yield(nil, fmt.Errorf("ent: invalid chunk size: %d", size))
+			return
+		}
+		var (
+			offset int
+			limit  = _q.ctx.Limit
+			last   any
+			keyset = len(_q.order) == 0
+		)
+		if _q.ctx.Offset != nil {
+			offset = *_q.ctx.Offset
+		}
+		for {
+			n := size
+			if limit != nil {
+				if n = min(n, *limit); n <= 0 {
+					return
+				}
+			}
+			query := _q.Clone().Offset(offset).Limit(n)
+			if keyset {
+				query.Order(card.ByID())
+				if last != nil {
+					query.Where(sql.FieldGT(card.FieldID, last))
+				}
+			}
+			nodes, err := query.All(ctx)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, node := range nodes {
+				if !yield(node, nil) {
+					return
+				}
+			}
+			if len(nodes) < n {
+				return
+			}
+			if keyset {
+				last, offset = nodes[len(nodes)-1].ID, 0
+			} else {
+				offset += len(nodes)
+			}
+			if limit != nil {
+				limit = func(v int) *int { return &v }(*limit - len(nodes))
+			}
+		}
+	}
+}
+
 // ForUpdate locks the selected rows against concurrent updates, and prevent them from being
 // updated, deleted or "selected ... for update" by other sessions, until the transaction is
 // either committed or rolled-back.
@@ -704,6 +844,73 @@ func (_q *CardQuery) WithNamedSpec(name string, opts ...func(*SpecQuery)) *CardQ
 	return _q
 }
 
+// Paginate returns the first Cards that are sorted after the given cursor, using keyset
+// pagination. The nodes are sorted by the query order, followed by the given options, and the
+// Card ID is used as a tie-breaker. An empty cursor returns the first page.
+//
+//	page, err := client.Card.Query().Paginate(ctx, "", 10)
+//	if err != nil {
+//		return err
+//	}
+//	for page.HasNextPage {
+//		page, err = client.Card.Query().Paginate(ctx, page.EndCursor, 10)
+//		// ...
+//	}
+//
+// Any ordering option can be used, including ordering by edge-count and neighbor terms.
+// However, the cursor is valid only for queries with the same ordering, and the limit and
+// offset of the query are ignored.
+func (_q *CardQuery) Paginate(ctx context.Context, after string, first int, opts ...card.OrderOption) (*Page[*Card], error) {
+	if first <= 0 {
+		return nil, fmt.Errorf("ent: invalid page size: %d", first)
+	}
+	var values []any
+	if after != "" {
+		var err error
+		if values, err = sqlgraph.DecodeCursor(after); err != nil {
+			return nil, err
+		}
+	}
+	query := _q.Clone()
+	query.ctx.Offset = nil
+	query.Limit(first+1).Order(opts...).Order(card.ByID(), func(s *sql.Selector) {
+		sqlgraph.KeysetAfter(s, values)
+	})
+	nodes, err := query.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	page := &Page[*Card]{Nodes: nodes}
+	if len(nodes) > first {
+		page.Nodes, page.HasNextPage = nodes[:first], true
+	}
+	if n := len(page.Nodes); n > 0 {
+		if page.StartCursor, err = page.Nodes[0].cursor(); err != nil {
+			return nil, err
+		}
+		if page.EndCursor, err = page.Nodes[n-1].cursor(); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// cursor returns the pagination cursor of the Card that was loaded by Paginate.
+func (_m *Card) cursor() (string, error) {
+	var values []any
+	for i := 0; ; i++ {
+		v, err := _m.Value(sqlgraph.CursorColumn(i))
+		if err != nil {
+			break
+		}
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return "", fmt.Errorf("ent: missing cursor values for Card")
+	}
+	return sqlgraph.EncodeCursor(values)
+}
+
 // CardGroupBy is the group-by builder for Card entities.
 type CardGroupBy struct {
 	selector
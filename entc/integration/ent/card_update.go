@@ -151,7 +151,7 @@ func (_u *CardUpdate) RemoveSpec(v ...*Spec) *CardUpdate {
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *CardUpdate) Save(ctx context.Context) (int, error) {
 	_u.defaults()
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
@@ -452,7 +452,7 @@ func (_u *CardUpdateOne) Select(field string, fields ...string) *CardUpdateOne {
 // Save executes the query and returns the updated Card entity.
 func (_u *CardUpdateOne) Save(ctx context.Context) (*Card, error) {
 	_u.defaults()
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"time"
 
 	"entgo.io/ent"
 	"entgo.io/ent/entc/integration/ent/migrate"
@@ -197,7 +198,7 @@ var ErrTxStarted = errors.New("ent: cannot start a transaction within a transact
 // is used until the transaction is committed or rolled back.
 func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 	if _, ok := c.driver.(*txDriver); ok {
-		return nil, ErrTxStarted
+		return newSavepoint(ctx, c.config)
 	}
 	tx, err := newTx(ctx, c.driver)
 	if err != nil {
@@ -233,7 +234,11 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 // BeginTx returns a transactional client with specified options.
 func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
 	if _, ok := c.driver.(*txDriver); ok {
-		return nil, errors.New("ent: cannot start a transaction within a transaction")
+		// Savepoints inherit the options of the transaction they were created in.
+		if opts != nil && *opts != (sql.TxOptions{}) {
+			return nil, errors.New("ent: transaction options are not supported by savepoints")
+		}
+		return newSavepoint(ctx, c.config)
 	}
 	tx, err := c.driver.(interface {
 		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
@@ -324,6 +329,148 @@ func (c *Client) Driver() dialect.Driver {
 	return c.driver
 }
 
+// Page is a page of nodes returned by the Paginate method of the query builders.
+type Page[T any] struct {
+	// Nodes of the page.
+	Nodes []T
+	// HasNextPage reports if there are more nodes after the page.
+	HasNextPage bool
+	// StartCursor and EndCursor are the opaque cursors of the first and last
+	// nodes in the page. They are empty if the page has no nodes.
+	StartCursor, EndCursor string
+}
+
+type (
+	// TxOption configures the execution of Client.WithTx.
+	TxOption func(*txConfig)
+
+	// txConfig holds the configuration of Client.WithTx.
+	txConfig struct {
+		opts       *sql.TxOptions
+		attempts   int
+		backoff    func(attempt int) time.Duration
+		retryable  func(error) bool
+		onCommit   []CommitHook
+		onRollback []RollbackHook
+	}
+)
+
+// TxWithOptions sets the options used for starting the transactions.
+func TxWithOptions(opts *sql.TxOptions) TxOption {
+	return func(c *txConfig) {
+		c.opts = opts
+	}
+}
+
+// TxWithAttempts sets the maximum number of attempts for executing the
+// transaction, including the first one. Defaults to 3.
+func TxWithAttempts(n int) TxOption {
+	return func(c *txConfig) {
+		c.attempts = n
+	}
+}
+
+// TxWithBackoff sets the function that returns the duration to wait before
+// the given attempt (starting from 1) is executed. Defaults to exponential
+// backoff starting from 10ms and capped at 1s.
+func TxWithBackoff(f func(attempt int) time.Duration) TxOption {
+	return func(c *txConfig) {
+		c.backoff = f
+	}
+}
+
+// TxWithRetryIf sets the function that reports if a failed transaction can
+// be retried. Defaults to sqlgraph.IsRetryableError.
+func TxWithRetryIf(f func(error) bool) TxOption {
+	return func(c *txConfig) {
+		c.retryable = f
+	}
+}
+
+// TxWithOnCommit registers the given hooks on the transaction of each attempt.
+func TxWithOnCommit(hooks ...CommitHook) TxOption {
+	return func(c *txConfig) {
+		c.onCommit = append(c.onCommit, hooks...)
+	}
+}
+
+// TxWithOnRollback registers the given hooks on the transaction of each attempt.
+func TxWithOnRollback(hooks ...RollbackHook) TxOption {
+	return func(c *txConfig) {
+		c.onRollback = append(c.onRollback, hooks...)
+	}
+}
+
+// WithTx executes fn in a transaction and commits it if fn returns without an error.
+// If fn or the commit fails with a retryable error, such as a serialization failure or
+// a deadlock, the transaction is rolled back and fn is executed again in a new one.
+//
+//	err := client.WithTx(ctx, func(tx *ent.Tx) error {
+//		// Use tx as a regular client.
+//		return nil
+//	}, ent.TxWithAttempts(5))
+//
+// Note that fn may be executed more than once, and therefore, it should not have side
+// effects outside the transaction. Side effects can be registered as commit hooks instead.
+func (c *Client) WithTx(ctx context.Context, fn func(tx *Tx) error, opts ...TxOption) error {
+	cfg := &txConfig{
+		attempts: 3,
+		backoff: func(attempt int) time.Duration {
+			return min(10*time.Millisecond<<(attempt-1), time.Second)
+		},
+		retryable: sqlgraph.IsRetryableError,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	var err error
+	for attempt := 1; attempt <= max(cfg.attempts, 1); attempt++ {
+		if attempt > 1 {
+			t := time.NewTimer(cfg.backoff(attempt - 1))
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return errors.Join(err, ctx.Err())
+			case <-t.C:
+			}
+		}
+		if err = c.withTx(ctx, cfg, fn); err == nil || !cfg.retryable(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// withTx executes a single attempt of WithTx.
+func (c *Client) withTx(ctx context.Context, cfg *txConfig, fn func(tx *Tx) error) (err error) {
+	tx, err := c.BeginTx(ctx, cfg.opts)
+	if err != nil {
+		return err
+	}
+	for _, h := range cfg.onCommit {
+		tx.OnCommit(h)
+	}
+	for _, h := range cfg.onRollback {
+		tx.OnRollback(h)
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			tx.Rollback()
+			panic(v)
+		}
+	}()
+	if err := fn(tx); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: rolling back transaction: %v", err, rerr)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ent: committing transaction: %w", err)
+	}
+	return nil
+}
+
 // Mutate implements the ent.Mutator interface.
 func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 	switch m := m.(type) {
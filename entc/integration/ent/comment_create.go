@@ -101,7 +101,7 @@ func (_c *CommentCreate) Mutation() *CommentMutation {
 
 // Save creates the Comment in the database.
 func (_c *CommentCreate) Save(ctx context.Context) (*Comment, error) {
-	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+	return withHooks(ctx, withOutbox(&_c.config, _c.sqlSave, _c.mutation), _c.mutation, _c.hooks)
 }
 
 // SaveX calls Save and panics if Save returns an error.
@@ -617,9 +617,22 @@ func (_c *CommentCreateBulk) Save(ctx context.Context) ([]*Comment, error) {
 		}(i, ctx)
 	}
 	if len(mutators) > 0 {
-		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+		ms := make([]*CommentMutation, len(_c.builders))
+		for i := range _c.builders {
+			ms[i] = _c.builders[i].mutation
+		}
+		exec := func(ctx context.Context) (Value, error) {
+			return mutators[0].Mutate(ctx, _c.builders[0].mutation)
+		}
+		exec = withOutbox(&_c.config, exec, ms...)
+		if _, err := exec(ctx); err != nil {
 			return nil, err
 		}
+		// Nodes that were created by the mutations
+		// should not reference the completed transaction.
+		for _, n := range nodes {
+			n.driver = _c.driver
+		}
 	}
 	return nodes, nil
 }
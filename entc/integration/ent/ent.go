@@ -14,6 +14,7 @@ import (
 	"sync"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/entc/integration/ent/api"
@@ -127,7 +128,7 @@ func Asc(fields ...string) func(*sql.Selector) {
 			if err := checkColumn(s.TableName(), f); err != nil {
 				s.AddError(&ValidationError{Name: f, err: fmt.Errorf("ent: %w", err)})
 			}
-			s.OrderBy(sql.Asc(s.C(f)))
+			s.OrderByKey(&sql.OrderKey{Expr: sql.Expr(s.C(f))})
 		}
 	}
 }
@@ -139,7 +140,7 @@ func Desc(fields ...string) func(*sql.Selector) {
 			if err := checkColumn(s.TableName(), f); err != nil {
 				s.AddError(&ValidationError{Name: f, err: fmt.Errorf("ent: %w", err)})
 			}
-			s.OrderBy(sql.Desc(s.C(f)))
+			s.OrderByKey(&sql.OrderKey{Expr: sql.Expr(s.C(f)), Desc: true})
 		}
 	}
 }
@@ -563,6 +564,289 @@ func withHooks[V Value, M any, PM interface {
 	return nv, nil
 }
 
+// txMutation is implemented by the mutations that are executed with withMutationTx.
+type txMutation interface {
+	// swapDriver sets the driver of the mutation and returns the previous one.
+	swapDriver(dialect.Driver) dialect.Driver
+}
+
+// withMutationTx wraps the execution of the given mutations with a transaction (if they are not executed
+// in one already). The prepare function is called in the transaction before the mutations are executed, and
+// the function it returns is called after they were executed, before the transaction is committed. It is used
+// for writing the changes of the mutations to other tables (e.g. history or outbox) in the same transaction.
+func withMutationTx[V any, M txMutation](cfg *config, exec func(context.Context) (V, error), ms []M, prepare func(context.Context, dialect.Driver) (func() error, error)) func(context.Context) (V, error) {
+	return func(ctx context.Context) (v V, err error) {
+		drv := cfg.driver
+		tx, err := drv.Tx(ctx)
+		if err != nil {
+			return v, err
+		}
+		txd := &txDriver{tx: tx, drv: drv}
+		cfg.driver = txd
+		defer func() { cfg.driver = drv }()
+		rollback := func(err error) (v V, _ error) {
+			if rerr := tx.Rollback(); rerr != nil {
+				err = fmt.Errorf("%w: %v", err, rerr)
+			}
+			return v, err
+		}
+		// The mutations (and the hooks that are executed with them)
+		// should use the transaction until it is completed.
+		for _, m := range ms {
+			prev := m.swapDriver(txd)
+			defer m.swapDriver(prev)
+		}
+		done, err := prepare(ctx, txd)
+		if err != nil {
+			return rollback(err)
+		}
+		if v, err = exec(ctx); err != nil {
+			return rollback(err)
+		}
+		if err := done(); err != nil {
+			return rollback(err)
+		}
+		if err := tx.Commit(); err != nil {
+			var zero V
+			return zero, err
+		}
+		// Entities that were created or updated by the mutations
+		// should not reference the completed transaction.
+		if n, ok := any(v).(interface{ setDriver(dialect.Driver) }); ok {
+			n.setDriver(drv)
+		}
+		return v, nil
+	}
+}
+
+// swapDriver implements the txMutation interface.
+func (m *APIMutation) swapDriver(drv dialect.Driver) dialect.Driver {
+	prev := m.driver
+	m.driver = drv
+	return prev
+}
+
+// setDriver sets the driver of the Api.
+func (_m *Api) setDriver(drv dialect.Driver) {
+	_m.driver = drv
+}
+
+// swapDriver implements the txMutation interface.
+func (m *BuilderMutation) swapDriver(drv dialect.Driver) dialect.Driver {
+	prev := m.driver
+	m.driver = drv
+	return prev
+}
+
+// setDriver sets the driver of the Builder.
+func (_m *Builder) setDriver(drv dialect.Driver) {
+	_m.driver = drv
+}
+
+// swapDriver implements the txMutation interface.
+func (m *CardMutation) swapDriver(drv dialect.Driver) dialect.Driver {
+	prev := m.driver
+	m.driver = drv
+	return prev
+}
+
+// setDriver sets the driver of the Card.
+func (_m *Card) setDriver(drv dialect.Driver) {
+	_m.driver = drv
+}
+
+// swapDriver implements the txMutation interface.
+func (m *CommentMutation) swapDriver(drv dialect.Driver) dialect.Driver {
+	prev := m.driver
+	m.driver = drv
+	return prev
+}
+
+// setDriver sets the driver of the Comment.
+func (_m *Comment) setDriver(drv dialect.Driver) {
+	_m.driver = drv
+}
+
+// swapDriver implements the txMutation interface.
+func (m *ExValueScanMutation) swapDriver(drv dialect.Driver) dialect.Driver {
+	prev := m.driver
+	m.driver = drv
+	return prev
+}
+
+// setDriver sets the driver of the ExValueScan.
+func (_m *ExValueScan) setDriver(drv dialect.Driver) {
+	_m.driver = drv
+}
+
+// swapDriver implements the txMutation interface.
+func (m *FieldTypeMutation) swapDriver(drv dialect.Driver) dialect.Driver {
+	prev := m.driver
+	m.driver = drv
+	return prev
+}
+
+// setDriver sets the driver of the FieldType.
+func (_m *FieldType) setDriver(drv dialect.Driver) {
+	_m.driver = drv
+}
+
+// swapDriver implements the txMutation interface.
+func (m *FileMutation) swapDriver(drv dialect.Driver) dialect.Driver {
+	prev := m.driver
+	m.driver = drv
+	return prev
+}
+
+// setDriver sets the driver of the File.
+func (_m *File) setDriver(drv dialect.Driver) {
+	_m.driver = drv
+}
+
+// swapDriver implements the txMutation interface.
+func (m *FileTypeMutation) swapDriver(drv dialect.Driver) dialect.Driver {
+	prev := m.driver
+	m.driver = drv
+	return prev
+}
+
+// setDriver sets the driver of the FileType.
+func (_m *FileType) setDriver(drv dialect.Driver) {
+	_m.driver = drv
+}
+
+// swapDriver implements the txMutation interface.
+func (m *GoodsMutation) swapDriver(drv dialect.Driver) dialect.Driver {
+	prev := m.driver
+	m.driver = drv
+	return prev
+}
+
+// setDriver sets the driver of the Goods.
+func (_m *Goods) setDriver(drv dialect.Driver) {
+	_m.driver = drv
+}
+
+// swapDriver implements the txMutation interface.
+func (m *GroupMutation) swapDriver(drv dialect.Driver) dialect.Driver {
+	prev := m.driver
+	m.driver = drv
+	return prev
+}
+
+// setDriver sets the driver of the Group.
+func (_m *Group) setDriver(drv dialect.Driver) {
+	_m.driver = drv
+}
+
+// swapDriver implements the txMutation interface.
+func (m *GroupInfoMutation) swapDriver(drv dialect.Driver) dialect.Driver {
+	prev := m.driver
+	m.driver = drv
+	return prev
+}
+
+// setDriver sets the driver of the GroupInfo.
+func (_m *GroupInfo) setDriver(drv dialect.Driver) {
+	_m.driver = drv
+}
+
+// swapDriver implements the txMutation interface.
+func (m *ItemMutation) swapDriver(drv dialect.Driver) dialect.Driver {
+	prev := m.driver
+	m.driver = drv
+	return prev
+}
+
+// setDriver sets the driver of the Item.
+func (_m *Item) setDriver(drv dialect.Driver) {
+	_m.driver = drv
+}
+
+// swapDriver implements the txMutation interface.
+func (m *LicenseMutation) swapDriver(drv dialect.Driver) dialect.Driver {
+	prev := m.driver
+	m.driver = drv
+	return prev
+}
+
+// setDriver sets the driver of the License.
+func (_m *License) setDriver(drv dialect.Driver) {
+	_m.driver = drv
+}
+
+// swapDriver implements the txMutation interface.
+func (m *NodeMutation) swapDriver(drv dialect.Driver) dialect.Driver {
+	prev := m.driver
+	m.driver = drv
+	return prev
+}
+
+// setDriver sets the driver of the Node.
+func (_m *Node) setDriver(drv dialect.Driver) {
+	_m.driver = drv
+}
+
+// swapDriver implements the txMutation interface.
+func (m *PCMutation) swapDriver(drv dialect.Driver) dialect.Driver {
+	prev := m.driver
+	m.driver = drv
+	return prev
+}
+
+// setDriver sets the driver of the PC.
+func (_m *PC) setDriver(drv dialect.Driver) {
+	_m.driver = drv
+}
+
+// swapDriver implements the txMutation interface.
+func (m *PetMutation) swapDriver(drv dialect.Driver) dialect.Driver {
+	prev := m.driver
+	m.driver = drv
+	return prev
+}
+
+// setDriver sets the driver of the Pet.
+func (_m *Pet) setDriver(drv dialect.Driver) {
+	_m.driver = drv
+}
+
+// swapDriver implements the txMutation interface.
+func (m *SpecMutation) swapDriver(drv dialect.Driver) dialect.Driver {
+	prev := m.driver
+	m.driver = drv
+	return prev
+}
+
+// setDriver sets the driver of the Spec.
+func (_m *Spec) setDriver(drv dialect.Driver) {
+	_m.driver = drv
+}
+
+// swapDriver implements the txMutation interface.
+func (m *TaskMutation) swapDriver(drv dialect.Driver) dialect.Driver {
+	prev := m.driver
+	m.driver = drv
+	return prev
+}
+
+// setDriver sets the driver of the Task.
+func (_m *Task) setDriver(drv dialect.Driver) {
+	_m.driver = drv
+}
+
+// swapDriver implements the txMutation interface.
+func (m *UserMutation) swapDriver(drv dialect.Driver) dialect.Driver {
+	prev := m.driver
+	m.driver = drv
+	return prev
+}
+
+// setDriver sets the driver of the User.
+func (_m *User) setDriver(drv dialect.Driver) {
+	_m.driver = drv
+}
+
 // setContextOp returns a new context with the given QueryContext attached (including its op) in case it does not exist.
 func setContextOp(ctx context.Context, qc *QueryContext, op string) context.Context {
 	if ent.QueryFromContext(ctx) == nil {
@@ -131,7 +131,7 @@ func (_u *ExValueScanUpdate) Mutation() *ExValueScanMutation {
 
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *ExValueScanUpdate) Save(ctx context.Context) (int, error) {
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
@@ -368,7 +368,7 @@ func (_u *ExValueScanUpdateOne) Select(field string, fields ...string) *ExValueS
 
 // Save executes the query and returns the updated ExValueScan entity.
 func (_u *ExValueScanUpdateOne) Save(ctx context.Context) (*ExValueScan, error) {
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
@@ -1350,7 +1350,7 @@ func (_u *FieldTypeUpdate) Mutation() *FieldTypeMutation {
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *FieldTypeUpdate) Save(ctx context.Context) (int, error) {
 	_u.defaults()
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
@@ -3263,7 +3263,7 @@ func (_u *FieldTypeUpdateOne) Select(field string, fields ...string) *FieldTypeU
 // Save executes the query and returns the updated FieldType entity.
 func (_u *FieldTypeUpdateOne) Save(ctx context.Context) (*FieldType, error) {
 	_u.defaults()
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
@@ -118,7 +118,7 @@ func (_u *FileTypeUpdate) RemoveFiles(v ...*File) *FileTypeUpdate {
 
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *FileTypeUpdate) Save(ctx context.Context) (int, error) {
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
@@ -350,7 +350,7 @@ func (_u *FileTypeUpdateOne) Select(field string, fields ...string) *FileTypeUpd
 
 // Save executes the query and returns the updated FileType entity.
 func (_u *FileTypeUpdateOne) Save(ctx context.Context) (*FileType, error) {
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
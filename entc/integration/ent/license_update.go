@@ -47,7 +47,7 @@ func (_u *LicenseUpdate) Mutation() *LicenseMutation {
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *LicenseUpdate) Save(ctx context.Context) (int, error) {
 	_u.defaults()
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
@@ -147,7 +147,7 @@ func (_u *LicenseUpdateOne) Select(field string, fields ...string) *LicenseUpdat
 // Save executes the query and returns the updated License entity.
 func (_u *LicenseUpdateOne) Save(ctx context.Context) (*License, error) {
 	_u.defaults()
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
@@ -597,6 +597,30 @@ var (
 			},
 		},
 	}
+	// EntOutboxColumns holds the columns for the "ent_outbox" table.
+	EntOutboxColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "type", Type: field.TypeString},
+		{Name: "op", Type: field.TypeString},
+		{Name: "entity_id", Type: field.TypeString},
+		{Name: "payload", Type: field.TypeJSON},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "processed_at", Type: field.TypeTime, Nullable: true},
+	}
+	// EntOutboxTable holds the schema information for the "ent_outbox" table.
+	EntOutboxTable = &schema.Table{
+		Name:       "ent_outbox",
+		Comment:    "Change records of the mutations that were not relayed yet",
+		Columns:    EntOutboxColumns,
+		PrimaryKey: []*schema.Column{EntOutboxColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "ent_outbox_processed_at_id",
+				Unique:  false,
+				Columns: []*schema.Column{EntOutboxColumns[6], EntOutboxColumns[0]},
+			},
+		},
+	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
 		ApisTable,
@@ -622,6 +646,7 @@ var (
 		UserGroupsTable,
 		UserFriendsTable,
 		UserFollowingTable,
+		EntOutboxTable,
 	}
 )
 
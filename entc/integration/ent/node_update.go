@@ -130,7 +130,7 @@ func (_u *NodeUpdate) ClearNext() *NodeUpdate {
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *NodeUpdate) Save(ctx context.Context) (int, error) {
 	_u.defaults()
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
@@ -383,7 +383,7 @@ func (_u *NodeUpdateOne) Select(field string, fields ...string) *NodeUpdateOne {
 // Save executes the query and returns the updated Node entity.
 func (_u *NodeUpdateOne) Save(ctx context.Context) (*Node, error) {
 	_u.defaults()
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
@@ -39,7 +39,7 @@ func (_u *PCUpdate) Mutation() *PCMutation {
 
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *PCUpdate) Save(ctx context.Context) (int, error) {
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
@@ -121,7 +121,7 @@ func (_u *PCUpdateOne) Select(field string, fields ...string) *PCUpdateOne {
 
 // Save executes the query and returns the updated PC entity.
 func (_u *PCUpdateOne) Save(ctx context.Context) (*PC, error) {
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
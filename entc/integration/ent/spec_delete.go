@@ -31,7 +31,7 @@ func (_d *SpecDelete) Where(ps ...predicate.Spec) *SpecDelete {
 
 // Exec executes the deletion query and returns how many vertices were deleted.
 func (_d *SpecDelete) Exec(ctx context.Context) (int, error) {
-	return withHooks(ctx, _d.sqlExec, _d.mutation, _d.hooks)
+	return withHooks(ctx, withOutbox(&_d.config, _d.sqlExec, _d.mutation), _d.mutation, _d.hooks)
 }
 
 // ExecX is like Exec, but panics if an error occurs.
@@ -76,7 +76,7 @@ func (_u *SpecUpdate) RemoveCard(v ...*Card) *SpecUpdate {
 
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *SpecUpdate) Save(ctx context.Context) (int, error) {
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
@@ -239,7 +239,7 @@ func (_u *SpecUpdateOne) Select(field string, fields ...string) *SpecUpdateOne {
 
 // Save executes the query and returns the updated Spec entity.
 func (_u *SpecUpdateOne) Save(ctx context.Context) (*Spec, error) {
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+	return withHooks(ctx, withOutbox(&_u.config, _u.sqlSave, _u.mutation), _u.mutation, _u.hooks)
 }
 
 // SaveX is like Save, but panics if an error occurs.
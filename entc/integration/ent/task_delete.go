@@ -32,7 +32,7 @@ func (_d *TaskDelete) Where(ps ...predicate.Task) *TaskDelete {
 
 // Exec executes the deletion query and returns how many vertices were deleted.
 func (_d *TaskDelete) Exec(ctx context.Context) (int, error) {
-	return withHooks(ctx, _d.sqlExec, _d.mutation, _d.hooks)
+	return withHooks(ctx, withOutbox(&_d.config, _d.sqlExec, _d.mutation), _d.mutation, _d.hooks)
 }
 
 // ExecX is like Exec, but panics if an error occurs.
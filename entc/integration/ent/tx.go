@@ -13,6 +13,7 @@ import (
 	"sync"
 
 	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
 )
 
 // Tx is a transactional client that is created by calling Client.Tx().
@@ -292,3 +293,26 @@ func (tx *txDriver) QueryContext(ctx context.Context, query string, args ...any)
 	}
 	return q.QueryContext(ctx, query, args...)
 }
+
+// Savepoint creates a savepoint in the transaction, and returns a nested transactional
+// client that is bound to it. Committing the nested transaction releases the savepoint,
+// and rolling it back discards all changes that were made after the savepoint was created.
+//
+// Note that the hooks registered on the nested transaction are executed when it is committed
+// or rolled back, and not when the outer transaction is.
+func (tx *Tx) Savepoint(ctx context.Context) (*Tx, error) {
+	return newSavepoint(ctx, tx.config)
+}
+
+// newSavepoint creates a savepoint using the transactional driver of the given config.
+func newSavepoint(ctx context.Context, cfg config) (*Tx, error) {
+	parent := cfg.driver.(*txDriver)
+	sp, err := sql.Savepoint(ctx, parent.tx)
+	if err != nil {
+		return nil, fmt.Errorf("ent: creating savepoint: %w", err)
+	}
+	cfg.driver = &txDriver{tx: sp, drv: parent.drv}
+	tx := &Tx{ctx: ctx, config: cfg}
+	tx.init()
+	return tx, nil
+}
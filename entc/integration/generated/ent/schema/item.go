@@ -0,0 +1,34 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/field"
+)
+
+// Item holds the schema definition for the Item entity.
+type Item struct {
+	ent.Schema
+}
+
+// Fields of the Item.
+func (Item) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name"),
+		field.Int("price"),
+		field.Int("quantity").
+			Default(1),
+		field.Int("total").
+			Annotations(
+				entsql.Generated("price * quantity", entsql.Stored),
+			),
+		field.String("label").
+			Annotations(
+				entsql.Generated("upper(name)", entsql.Stored),
+			),
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package generated
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/entc/integration/generated/ent"
+	"entgo.io/ent/entc/integration/generated/ent/item"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLite(t *testing.T) {
+	client, err := ent.Open(dialect.SQLite, "file:ent?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+	defer client.Close()
+	require.NoError(t, client.Schema.Create(context.Background()))
+	Generated(t, client)
+}
+
+func TestMySQL(t *testing.T) {
+	for version, port := range map[string]int{"57": 3307, "8": 3308} {
+		t.Run(version, func(t *testing.T) {
+			db, err := sql.Open(dialect.MySQL, fmt.Sprintf("root:pass@tcp(localhost:%d)/", port))
+			require.NoError(t, err)
+			defer db.Close()
+			ctx := context.Background()
+			err = db.Exec(ctx, "CREATE DATABASE IF NOT EXISTS generated", []any{}, nil)
+			require.NoError(t, err, "creating database")
+			defer db.Exec(ctx, "DROP DATABASE IF EXISTS generated", []any{}, nil)
+			client, err := ent.Open(dialect.MySQL, fmt.Sprintf("root:pass@tcp(localhost:%d)/generated", port))
+			require.NoError(t, err, "connecting to generated database")
+			defer client.Close()
+			require.NoError(t, client.Schema.Create(ctx))
+			Generated(t, client)
+		})
+	}
+}
+
+func TestPostgres(t *testing.T) {
+	for version, port := range map[string]int{"12": 5433, "13": 5434} {
+		t.Run(version, func(t *testing.T) {
+			dsn := fmt.Sprintf("host=localhost port=%d user=postgres password=pass sslmode=disable", port)
+			db, err := sql.Open(dialect.Postgres, dsn)
+			require.NoError(t, err)
+			defer db.Close()
+			ctx := context.Background()
+			_, err = db.ExecContext(ctx, "CREATE DATABASE generated")
+			require.NoError(t, err, "creating database")
+			defer db.ExecContext(ctx, "DROP DATABASE generated")
+			client, err := ent.Open(dialect.Postgres, dsn+" dbname=generated")
+			require.NoError(t, err, "connecting to generated database")
+			defer client.Close()
+			require.NoError(t, client.Schema.Create(ctx))
+			Generated(t, client)
+		})
+	}
+}
+
+func Generated(t *testing.T, client *ent.Client) {
+	ctx := context.Background()
+
+	t.Log("Generated columns are read back on creation")
+	it := client.Item.Create().SetName("pen").SetPrice(10).SetQuantity(3).SaveX(ctx)
+	require.Equal(t, 30, it.Total)
+	require.Equal(t, "PEN", it.Label)
+	items := client.Item.MapCreateBulk(make([]int, 3), func(c *ent.ItemCreate, i int) {
+		c.SetName(fmt.Sprintf("item%d", i)).SetPrice(i + 1).SetQuantity(10)
+	}).SaveX(ctx)
+	for i, it := range items {
+		require.Equal(t, (i+1)*10, it.Total)
+		require.Equal(t, fmt.Sprintf("ITEM%d", i), it.Label)
+	}
+
+	t.Log("Generated columns are read back on update")
+	it = it.Update().SetQuantity(5).SaveX(ctx)
+	require.Equal(t, 50, it.Total)
+	client.Item.Update().Where(item.NameHasPrefix("item")).AddPrice(1).ExecX(ctx)
+	totals := client.Item.Query().Where(item.NameHasPrefix("item")).Order(item.ByID()).Select(item.FieldTotal).IntsX(ctx)
+	require.Equal(t, []int{20, 30, 40}, totals)
+
+	t.Log("Generated columns can be used in predicates and ordering")
+	names := client.Item.Query().
+		Where(item.TotalGT(25)).
+		Order(item.ByTotal(sql.OrderDesc())).
+		Select(item.FieldName).
+		StringsX(ctx)
+	require.Equal(t, []string{"pen", "item2", "item1"}, names)
+	require.Equal(t, items[0].ID, client.Item.Query().Where(item.LabelEQ("ITEM0")).OnlyIDX(ctx))
+
+	t.Log("Migrating the schema again does not change the generated columns")
+	require.NoError(t, client.Schema.Create(ctx))
+}
@@ -0,0 +1,110 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package history
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	entgo "entgo.io/ent"
+	"entgo.io/ent/dialect/sql/history"
+	"entgo.io/ent/entc/integration/history/ent"
+	"entgo.io/ent/entc/integration/history/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	client, err := ent.Open("sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+	defer client.Close()
+	ctx := context.Background()
+	require.NoError(t, client.Schema.Create(ctx))
+
+	a8m := client.User.Create().SetName("a8m").SetAge(30).SaveX(ctx)
+	created := tick()
+	// Entities that were created by the builders do not reference their completed transaction.
+	a8m.Update().SetAge(31).ExecX(history.WithActor(ctx, "admin"))
+	updated := tick()
+	client.User.Update().Where(user.Name("a8m")).SetAge(32).ExecX(ctx)
+	client.User.DeleteOne(a8m).ExecX(ctx)
+
+	t.Log("Every mutation records a revision of the entity")
+	revs := client.User.History().Of(a8m.ID).AllX(ctx)
+	require.Len(t, revs, 4)
+	for i, op := range []entgo.Op{ent.OpCreate, ent.OpUpdateOne, ent.OpUpdate, ent.OpDeleteOne} {
+		require.Equal(t, op, revs[i].Op)
+		require.Equal(t, a8m.ID, revs[i].Entity.ID)
+	}
+	for i, age := range []int{30, 31, 32} {
+		require.Equal(t, age, revs[i].Entity.Age)
+		require.Equal(t, "a8m", revs[i].Entity.Name)
+	}
+	require.Equal(t, "admin", revs[1].Actor)
+	require.Empty(t, revs[2].Actor)
+	require.True(t, revs[3].Deleted())
+
+	t.Log("AsOf returns the state of the entities at the given time")
+	revs = client.User.History().AsOf(created).AllX(ctx)
+	require.Len(t, revs, 1)
+	require.Equal(t, 30, revs[0].Entity.Age)
+	revs = client.User.History().AsOf(updated).AllX(ctx)
+	require.Len(t, revs, 1)
+	require.Equal(t, 31, revs[0].Entity.Age)
+	require.Empty(t, client.User.History().AsOf(time.Now()).AllX(ctx), "deleted entities are skipped")
+
+	t.Log("Revisions are rolled back with the transaction of the mutation")
+	tx, err := client.Tx(ctx)
+	require.NoError(t, err)
+	nati := tx.User.Create().SetName("nati").SaveX(ctx)
+	tx.User.UpdateOne(nati).SetAge(20).ExecX(ctx)
+	require.Len(t, tx.User.History().Of(nati.ID).AllX(ctx), 2)
+	require.NoError(t, tx.Rollback())
+	require.Empty(t, client.User.History().Of(nati.ID).AllX(ctx))
+
+	tx, err = client.Tx(ctx)
+	require.NoError(t, err)
+	nati = tx.User.Create().SetName("nati").SaveX(ctx)
+	require.NoError(t, tx.Commit())
+	revs = client.User.History().Of(nati.ID).AllX(ctx)
+	require.Len(t, revs, 1)
+	require.Equal(t, ent.OpCreate, revs[0].Op)
+	nati = nati.Unwrap()
+
+	t.Log("Failed mutations do not record revisions")
+	err = client.User.Create().SetName("nati").Exec(ctx)
+	require.True(t, ent.IsConstraintError(err))
+	err = client.User.UpdateOne(nati).SetName("a8m").SetAge(21).Exec(ctx)
+	require.NoError(t, err, "a8m was deleted")
+	err = client.User.Create().SetName("a8m").Exec(ctx)
+	require.True(t, ent.IsConstraintError(err))
+	revs = client.User.History().AllX(ctx)
+	require.Len(t, revs, 6)
+	require.Equal(t, 21, revs[5].Entity.Age)
+
+	t.Log("Bulk creation records the revisions of all entities, including their foreign-keys")
+	pets := client.Pet.CreateBulk(
+		client.Pet.Create().SetName("pedro").SetOwner(nati),
+		client.Pet.Create().SetName("xabi"),
+	).SaveX(ctx)
+	prevs := client.Pet.History().AllX(ctx)
+	require.Len(t, prevs, 2)
+	for i := range pets {
+		require.Equal(t, pets[i].ID, prevs[i].Entity.ID)
+		require.Equal(t, pets[i].Name, prevs[i].Entity.Name)
+	}
+	require.Equal(t, nati.ID, prevs[0].Entity.QueryOwner().OnlyIDX(ctx))
+	require.Zero(t, prevs[1].Entity.QueryOwner().CountX(ctx))
+}
+
+// tick returns the current time and waits a bit, in order
+// to separate the revisions that are recorded before and after it.
+func tick() time.Time {
+	now := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	return now
+}
@@ -16,6 +16,7 @@ import (
 	"math/big"
 	"net"
 	"net/url"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"runtime"
@@ -28,6 +29,7 @@ import (
 
 	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/outbox"
 	sqlschema "entgo.io/ent/dialect/sql/schema"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/entc/integration/ent"
@@ -69,6 +71,34 @@ func TestSQLite(t *testing.T) {
 	}
 }
 
+func TestSQLiteRetryTx(t *testing.T) {
+	// A file database is used, because the tables of shared-cache in-memory databases are
+	// locked with SQLITE_LOCKED, while the retried transactions fail with SQLITE_BUSY.
+	dsn := fmt.Sprintf("file:%s?_fk=1&_busy_timeout=0", filepath.Join(t.TempDir(), "ent.db"))
+	client := enttest.Open(t, dialect.SQLite, dsn, opts)
+	defer client.Close()
+	ctx := context.Background()
+
+	// The other transaction holds the write lock of the
+	// database, until the first attempt of WithTx fails.
+	other, err := client.Tx(ctx)
+	require.NoError(t, err)
+	other.User.Create().SetName("nati").SetAge(30).ExecX(ctx)
+	var attempts int
+	err = client.WithTx(ctx, func(tx *ent.Tx) error {
+		attempts++
+		err := tx.User.Create().SetName("a8m").SetAge(30).Exec(ctx)
+		if attempts == 1 {
+			require.True(t, sqlgraph.IsSerializationError(err), "database is locked")
+			require.NoError(t, other.Commit())
+		}
+		return err
+	}, ent.TxWithBackoff(func(int) time.Duration { return 0 }))
+	require.NoError(t, err)
+	require.Equal(t, 2, attempts)
+	require.Equal(t, []string{"a8m", "nati"}, client.User.Query().Order(user.ByName()).Select(user.FieldName).StringsX(ctx))
+}
+
 func TestMySQL(t *testing.T) {
 	for version, port := range map[string]int{"56": 3306, "57": 3307, "8": 3308} {
 		addr := net.JoinHostPort("localhost", strconv.Itoa(port))
@@ -132,6 +162,39 @@ func TestPostgres(t *testing.T) {
 	}
 }
 
+// Session variables that are set in transactions must not be kept in
+// the session (i.e. the pooled connection) after they are completed.
+func TestPostgresVars(t *testing.T) {
+	drv, err := sql.Open(dialect.Postgres, "host=localhost port=5437 user=postgres dbname=test password=pass sslmode=disable")
+	require.NoError(t, err)
+	defer drv.Close()
+	// A single connection ensures all statements share the same session.
+	drv.DB().SetMaxOpenConns(1)
+	ctx := context.Background()
+	current := func(ctx context.Context, ex dialect.ExecQuerier) string {
+		rows := &sql.Rows{}
+		require.NoError(t, ex.Query(ctx, "SELECT COALESCE(current_setting('app.tenant', true), '')", []any{}, rows))
+		defer rows.Close()
+		v, err := sql.ScanString(rows)
+		require.NoError(t, err)
+		return v
+	}
+	tx, err := drv.Tx(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "1'--", current(sql.WithVar(ctx, "app.tenant", "1'--"), tx))
+	require.NoError(t, tx.Commit())
+	require.Empty(t, current(ctx, drv), "variable should not be kept after commit")
+
+	tx, err = drv.Tx(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "2", current(sql.WithVar(ctx, "app.tenant", "2"), tx))
+	require.NoError(t, tx.Rollback())
+	require.Empty(t, current(ctx, drv), "variable should not be kept after rollback")
+
+	require.Equal(t, "3", current(sql.WithVar(ctx, "app.tenant", "3"), drv))
+	require.Empty(t, current(ctx, drv), "variable should be reset after the statement")
+}
+
 var (
 	opts = enttest.WithMigrateOptions(
 		migrate.WithDropIndex(true),
@@ -141,12 +204,16 @@ var (
 		Sanity,
 		NoSchemaChanges,
 		Tx,
+		RetryTx,
 		Lock,
 		Indexes,
 		Types,
 		Clone,
 		EntQL,
 		Paging,
+		Iter,
+		Paginate,
+		Outbox,
 		Select,
 		Aggregate,
 		Delete,
@@ -551,6 +618,212 @@ func Paging(t *testing.T, client *ent.Client) {
 	}
 }
 
+func Iter(t *testing.T, client *ent.Client) {
+	ctx := context.Background()
+	for i := 1; i <= 10; i++ {
+		u := client.User.Create().SetName(fmt.Sprintf("name-%d", i)).SetAge(i).SaveX(ctx)
+		client.Pet.Create().SetName(fmt.Sprintf("pet-%d", i)).SetOwner(u).ExecX(ctx)
+	}
+	var ages []int
+	for u, err := range client.User.Query().Where(user.AgeGT(2)).Order(ent.Asc(user.FieldAge)).Iter(ctx) {
+		require.NoError(t, err)
+		ages = append(ages, u.Age)
+		if u.Age == 6 {
+			break
+		}
+	}
+	require.Equal(t, []int{3, 4, 5, 6}, ages)
+	var errs []error
+	for _, err := range client.User.Query().WithPets().Iter(ctx) {
+		errs = append(errs, err)
+	}
+	require.Len(t, errs, 1, "eager-loading is not supported by Iter")
+	require.Error(t, errs[0])
+
+	ages = ages[:0]
+	for u, err := range client.User.Query().WithPets().Order(ent.Desc(user.FieldAge)).IterChunks(ctx, 3) {
+		require.NoError(t, err)
+		require.Len(t, u.Edges.Pets, 1)
+		require.Equal(t, fmt.Sprintf("pet-%d", u.Age), u.Edges.Pets[0].Name)
+		ages = append(ages, u.Age)
+	}
+	require.Equal(t, []int{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}, ages)
+	ages = ages[:0]
+	for u, err := range client.User.Query().Order(ent.Asc(user.FieldAge)).Offset(2).Limit(5).IterChunks(ctx, 2) {
+		require.NoError(t, err)
+		ages = append(ages, u.Age)
+	}
+	require.Equal(t, []int{3, 4, 5, 6, 7}, ages)
+	errs = errs[:0]
+	for _, err := range client.User.Query().IterChunks(ctx, 0) {
+		errs = append(errs, err)
+	}
+	require.Len(t, errs, 1)
+	require.Error(t, errs[0])
+
+	// Without an explicit order, the chunks are paginated by the ID column.
+	var ids []int
+	for u, err := range client.User.Query().WithPets().Offset(1).Limit(7).IterChunks(ctx, 3) {
+		require.NoError(t, err)
+		require.Len(t, u.Edges.Pets, 1)
+		ids = append(ids, u.ID)
+	}
+	require.Equal(t, client.User.Query().Order(user.ByID()).Offset(1).Limit(7).IDsX(ctx), ids)
+	// Deleting the visited nodes does not shift the next chunks, as they
+	// are read after the last ID of the previous chunk, and not using OFFSET.
+	ids = ids[:0]
+	expected := client.User.Query().Order(user.ByID()).IDsX(ctx)
+	for u, err := range client.User.Query().IterChunks(ctx, 3) {
+		require.NoError(t, err)
+		client.Pet.Delete().Where(pet.HasOwnerWith(user.ID(u.ID))).ExecX(ctx)
+		client.User.DeleteOne(u).ExecX(ctx)
+		ids = append(ids, u.ID)
+	}
+	require.Equal(t, expected, ids)
+	require.Zero(t, client.User.Query().CountX(ctx))
+}
+
+func Paginate(t *testing.T, client *ent.Client) {
+	ctx := context.Background()
+	for i := 1; i <= 10; i++ {
+		u := client.User.Create().SetName(fmt.Sprintf("name-%d", i)).SetAge(i % 4).SaveX(ctx)
+		for j := 0; j < i%3; j++ {
+			client.Pet.Create().SetName(fmt.Sprintf("pet-%d-%d", i, j)).SetOwner(u).ExecX(ctx)
+		}
+	}
+	// paginate returns the identifiers of all users with positive age, by reading them page by page.
+	paginate := func(opts ...user.OrderOption) []int {
+		var (
+			ids    []int
+			cursor string
+		)
+		for {
+			page, err := client.User.Query().Where(user.AgeGT(0)).Paginate(ctx, cursor, 3, opts...)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(page.Nodes), 3)
+			for _, u := range page.Nodes {
+				ids = append(ids, u.ID)
+			}
+			if !page.HasNextPage {
+				return ids
+			}
+			cursor = page.EndCursor
+		}
+	}
+	for _, opts := range [][]user.OrderOption{
+		nil,
+		{user.ByAge()},
+		{user.ByAge(sql.OrderDesc())},
+		{user.ByAge(sql.OrderDesc()), user.ByName()},
+		{user.ByPetsCount(sql.OrderDesc())},
+	} {
+		expected := client.User.Query().Where(user.AgeGT(0)).Order(opts...).Order(user.ByID()).IDsX(ctx)
+		require.Len(t, expected, 8)
+		require.Equal(t, expected, paginate(opts...))
+	}
+	// Pets without an owner are sorted by a NULL neighbor term.
+	client.Pet.Create().SetName("stray-1").ExecX(ctx)
+	client.Pet.Create().SetName("stray-2").ExecX(ctx)
+	for _, opts := range [][]pet.OrderOption{
+		{pet.ByOwnerField(user.FieldAge)},
+		{pet.ByOwnerField(user.FieldAge, sql.OrderDesc()), pet.ByName()},
+		{pet.ByOwnerField(user.FieldName, sql.OrderDesc())},
+	} {
+		expected := client.Pet.Query().Order(opts...).Order(pet.ByID()).IDsX(ctx)
+		require.Len(t, expected, 12)
+		var (
+			ids    []int
+			cursor string
+		)
+		for {
+			page, err := client.Pet.Query().Paginate(ctx, cursor, 5, opts...)
+			require.NoError(t, err)
+			for _, p := range page.Nodes {
+				ids = append(ids, p.ID)
+			}
+			if !page.HasNextPage {
+				break
+			}
+			cursor = page.EndCursor
+		}
+		require.Equal(t, expected, ids)
+	}
+	page, err := client.User.Query().Where(user.AgeGT(0)).Paginate(ctx, "", 10)
+	require.NoError(t, err)
+	require.Len(t, page.Nodes, 8)
+	require.False(t, page.HasNextPage)
+	page, err = client.User.Query().Where(user.AgeGT(0)).Paginate(ctx, page.EndCursor, 10)
+	require.NoError(t, err)
+	require.Empty(t, page.Nodes)
+	require.Empty(t, page.EndCursor)
+	_, err = client.User.Query().Paginate(ctx, "", 0)
+	require.Error(t, err)
+	_, err = client.User.Query().Paginate(ctx, "invalid", 10)
+	require.Error(t, err)
+}
+
+func Outbox(t *testing.T, client *ent.Client) {
+	ctx := context.Background()
+	// process returns the pending change records and marks them as processed.
+	process := func() []*outbox.Change {
+		var changes []*outbox.Change
+		for {
+			n, err := client.Outbox().Process(ctx, 100, func(_ context.Context, cs []*outbox.Change) error {
+				changes = append(changes, cs...)
+				return nil
+			})
+			require.NoError(t, err)
+			if n == 0 {
+				return changes
+			}
+		}
+	}
+	process()
+
+	usr := client.User.Create().SetName("a8m").SetAge(30).SetPassword("secret-password").SaveX(ctx)
+	usr = usr.Update().SetName("Ariel").ClearPassword().SaveX(ctx)
+	changes := process()
+	require.Len(t, changes, 2)
+	require.Equal(t, ent.TypeUser, changes[0].Type)
+	require.Equal(t, ent.OpCreate.String(), changes[0].Op)
+	require.JSONEq(t, strconv.Itoa(usr.ID), string(changes[0].EntityID))
+	require.Contains(t, changes[0].Fields, user.FieldName)
+	require.NotContains(t, changes[0].Fields, user.FieldPassword, "sensitive fields are omitted")
+	require.Equal(t, ent.OpUpdateOne.String(), changes[1].Op)
+	var oldName, newName string
+	require.NoError(t, changes[1].Fields[user.FieldName].Decode(&oldName, &newName))
+	require.Equal(t, "a8m", oldName)
+	require.Equal(t, "Ariel", newName)
+	require.NotContains(t, changes[1].Fields, user.FieldPassword, "sensitive fields are omitted")
+
+	t.Log("nodes that were created in bulk can be used after the outbox transaction was committed")
+	pets := client.Pet.CreateBulk(
+		client.Pet.Create().SetName("pedro").SetOwner(usr),
+		client.Pet.Create().SetName("xabi").SetOwner(usr),
+	).SaveX(ctx)
+	for _, p := range pets {
+		require.Equal(t, usr.ID, p.QueryOwner().OnlyIDX(ctx))
+	}
+	changes = process()
+	require.Len(t, changes, 2)
+	for i, c := range changes {
+		require.Equal(t, ent.TypePet, c.Type)
+		require.JSONEq(t, strconv.Itoa(pets[i].ID), string(c.EntityID))
+		require.JSONEq(t, fmt.Sprintf("[%d]", usr.ID), string(c.AddedEdges[pet.EdgeOwner]))
+	}
+
+	t.Log("rolled back mutations do not write change records")
+	tx, err := client.Tx(ctx)
+	require.NoError(t, err)
+	tx.Pet.DeleteOne(pets[0]).ExecX(ctx)
+	require.NoError(t, tx.Rollback())
+	require.Empty(t, process())
+	client.Pet.DeleteOne(pets[0]).ExecX(ctx)
+	changes = process()
+	require.Len(t, changes, 1)
+	require.Equal(t, ent.OpDeleteOne.String(), changes[0].Op)
+}
+
 func Select(t *testing.T, client *ent.Client) {
 	ctx := context.Background()
 	require := require.New(t)
@@ -1657,6 +1930,42 @@ func (m *mocker) rHook() ent.RollbackHook {
 	}
 }
 
+func RetryTx(t *testing.T, client *ent.Client) {
+	// Only PostgreSQL fails transactions that update rows that were changed
+	// after their snapshot was taken (REPEATABLE READ), instead of blocking.
+	skip(t, "SQLite", "MySQL", "Maria")
+	ctx := context.Background()
+	a8m := client.User.Create().SetName("a8m").SetAge(30).SaveX(ctx)
+	addAge := func(attempts *int) func(*ent.Tx) error {
+		return func(tx *ent.Tx) error {
+			*attempts++
+			u, err := tx.User.Get(ctx, a8m.ID)
+			if err != nil {
+				return err
+			}
+			if *attempts == 1 {
+				// Update the user concurrently, after it was read by the transaction.
+				client.User.UpdateOneID(a8m.ID).AddAge(1).ExecX(ctx)
+			}
+			return tx.User.UpdateOne(u).AddAge(1).Exec(ctx)
+		}
+	}
+	opts := ent.TxWithOptions(&sql.TxOptions{Isolation: stdsql.LevelRepeatableRead})
+
+	t.Log("Serialization failures are retried in a new transaction")
+	var attempts int
+	require.NoError(t, client.WithTx(ctx, addAge(&attempts), opts))
+	require.Equal(t, 2, attempts)
+	require.Equal(t, 32, client.User.GetX(ctx, a8m.ID).Age)
+
+	t.Log("The last serialization failure is returned when all attempts failed")
+	attempts = 0
+	err := client.WithTx(ctx, addAge(&attempts), opts, ent.TxWithAttempts(1))
+	require.True(t, sqlgraph.IsSerializationError(err))
+	require.Equal(t, 1, attempts)
+	require.Equal(t, 33, client.User.GetX(ctx, a8m.ID).Age, "only the concurrent update was applied")
+}
+
 func Tx(t *testing.T, client *ent.Client) {
 	ctx := context.Background()
 	t.Run("Rollback", func(t *testing.T) {
@@ -1698,10 +2007,46 @@ func Tx(t *testing.T, client *ent.Client) {
 		m.On("onRollback", nil).Once()
 		defer m.AssertExpectations(t)
 		tx.OnRollback(m.rHook())
-		_, err = tx.Client().Tx(ctx)
-		require.Error(t, err, "cannot start a transaction within a transaction")
+		n := tx.Node.Query().CountX(ctx)
+		nested, err := tx.Client().Tx(ctx)
+		require.NoError(t, err, "nested transactions are started using savepoints")
+		nested.Node.Create().ExecX(ctx)
+		require.NoError(t, nested.Rollback())
+		require.Equal(t, n, tx.Node.Query().CountX(ctx))
 		require.NoError(t, tx.Rollback())
 	})
+	t.Run("Savepoint", func(t *testing.T) {
+		client.Node.Delete().ExecX(ctx)
+		tx, err := client.Tx(ctx)
+		require.NoError(t, err)
+		tx.Node.Create().SetValue(1).ExecX(ctx)
+		sp, err := tx.Savepoint(ctx)
+		require.NoError(t, err)
+		sp.Node.Create().SetValue(2).ExecX(ctx)
+		require.NoError(t, sp.Rollback(), "discard only the changes made after the savepoint")
+		sp, err = tx.Savepoint(ctx)
+		require.NoError(t, err)
+		n3 := sp.Node.Create().SetValue(3).SaveX(ctx)
+		inner, err := sp.Savepoint(ctx)
+		require.NoError(t, err)
+		inner.Node.UpdateOne(n3).SetValue(4).ExecX(ctx)
+		// Raw statements are executed in the savepoint as well.
+		_, err = inner.ExecContext(ctx, "DELETE FROM "+node.Table)
+		require.NoError(t, err)
+		rows, err := inner.QueryContext(ctx, "SELECT COUNT(*) FROM "+node.Table)
+		require.NoError(t, err)
+		count, err := sql.ScanInt(rows)
+		require.NoError(t, err)
+		require.NoError(t, rows.Close())
+		require.Zero(t, count)
+		require.NoError(t, inner.Rollback())
+		_, err = sp.Client().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+		require.Error(t, err, "savepoints do not support transaction options")
+		require.NoError(t, sp.Commit())
+		require.Equal(t, []int{1, 3}, tx.Node.Query().Order(ent.Asc(node.FieldValue)).Select(node.FieldValue).IntsX(ctx))
+		require.NoError(t, tx.Commit())
+		require.Equal(t, []int{1, 3}, client.Node.Query().Order(ent.Asc(node.FieldValue)).Select(node.FieldValue).IntsX(ctx))
+	})
 	t.Run("TxOptions Rollback", func(t *testing.T) {
 		skip(t, "SQLite")
 		tx, err := client.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
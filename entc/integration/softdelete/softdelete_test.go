@@ -0,0 +1,86 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package softdelete
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/entc/integration/softdelete/ent"
+	"entgo.io/ent/entc/integration/softdelete/ent/post"
+	"entgo.io/ent/entc/integration/softdelete/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoftDelete(t *testing.T) {
+	client, err := ent.Open("sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+	defer client.Close()
+	ctx := context.Background()
+	require.NoError(t, client.Schema.Create(ctx))
+
+	a8m := client.User.Create().SetName("a8m").SaveX(ctx)
+	nati := client.User.Create().SetName("nati").SaveX(ctx)
+	p1 := client.Post.Create().SetTitle("Go").SetAuthor(a8m).SaveX(ctx)
+	p2 := client.Post.Create().SetTitle("Ent").SetAuthor(a8m).SaveX(ctx)
+	p3 := client.Post.Create().SetTitle("GraphQL").SetAuthor(nati).SaveX(ctx)
+
+	t.Log("Deleted posts are marked and skipped by queries")
+	client.Post.DeleteOne(p1).ExecX(ctx)
+	require.Equal(t, []int{p2.ID, p3.ID}, client.Post.Query().Order(post.ByID()).IDsX(ctx))
+	require.Equal(t, 3, client.Post.Query().WithDeleted().CountX(ctx))
+	deleted := client.Post.Query().WithDeleted().Where(post.ID(p1.ID)).OnlyX(ctx)
+	require.NotNil(t, deleted.DeletedAt)
+	_, err = client.Post.Get(ctx, p1.ID)
+	require.True(t, ent.IsNotFound(err))
+	n, err := client.Post.Delete().Where(post.ID(p1.ID)).Exec(ctx)
+	require.NoError(t, err)
+	require.Zero(t, n, "posts that were already deleted are skipped")
+
+	t.Log("Edge traversals, eager-loading and edge predicates skip deleted posts")
+	require.Equal(t, []int{p2.ID}, a8m.QueryPosts().IDsX(ctx))
+	u := client.User.Query().Where(user.ID(a8m.ID)).WithPosts().OnlyX(ctx)
+	require.Len(t, u.Edges.Posts, 1)
+	require.Equal(t, p2.ID, u.Edges.Posts[0].ID)
+	require.False(t, client.User.Query().Where(user.HasPostsWith(post.Title("Go"))).ExistX(ctx))
+	require.True(t, client.User.Query().Where(user.HasPostsWith(post.Title("Ent"))).ExistX(ctx))
+	client.Post.DeleteOne(p3).ExecX(ctx)
+	require.Equal(t, []int{a8m.ID}, client.User.Query().Where(user.HasPosts()).IDsX(ctx))
+	require.Equal(t, []int{nati.ID}, client.User.Query().Where(user.Not(user.HasPosts())).IDsX(ctx))
+
+	t.Log("Edge predicates skip deleted neighbors on the inverse side")
+	client.User.DeleteOne(nati).ExecX(ctx)
+	require.Equal(t, []int{p1.ID, p2.ID}, client.Post.Query().WithDeleted().Where(post.HasAuthor()).Order(post.ByID()).IDsX(ctx))
+	require.Equal(t, []int{p1.ID, p2.ID}, client.Post.Query().WithDeleted().Where(post.HasAuthorWith(user.Name("a8m"))).Order(post.ByID()).IDsX(ctx))
+	require.Empty(t, client.Post.Query().WithDeleted().Where(post.HasAuthorWith(user.Name("nati"))).IDsX(ctx))
+
+	t.Log("Unique indexes cover only the users that were not deleted")
+	nati2 := client.User.Create().SetName("nati").SaveX(ctx)
+	require.NotEqual(t, nati.ID, nati2.ID)
+
+	t.Log("Updates skip deleted posts, unless configured otherwise")
+	n = client.Post.Update().SetTitle("Ent").SaveX(ctx)
+	require.Equal(t, 1, n)
+	err = client.Post.UpdateOneID(p1.ID).SetTitle("Go!").Exec(ctx)
+	require.True(t, ent.IsNotFound(err))
+	require.Equal(t, "Go", client.Post.Query().WithDeleted().Where(post.ID(p1.ID)).OnlyX(ctx).Title)
+	restored := client.Post.UpdateOneID(p1.ID).WithDeleted().ClearDeletedAt().SaveX(ctx)
+	require.Nil(t, restored.DeletedAt)
+	require.Equal(t, []int{p1.ID, p2.ID}, a8m.QueryPosts().Order(post.ByID()).IDsX(ctx))
+	n = client.Post.Update().WithDeleted().Where(post.DeletedAtNotNil()).SetTitle("Deleted").SaveX(ctx)
+	require.Equal(t, 1, n)
+	require.Equal(t, "Deleted", client.Post.Query().WithDeleted().Where(post.ID(p3.ID)).OnlyX(ctx).Title)
+
+	t.Log("Hard delete removes the records from the database, including the deleted ones")
+	n = client.Post.HardDelete().Where(post.DeletedAtNotNil()).ExecX(ctx)
+	require.Equal(t, 1, n)
+	require.Equal(t, 2, client.Post.Query().WithDeleted().CountX(ctx))
+	client.Post.DeleteOne(p2).ExecX(ctx)
+	n = client.Post.HardDelete().ExecX(ctx)
+	require.Equal(t, 2, n)
+	require.Zero(t, client.Post.Query().WithDeleted().CountX(ctx))
+}
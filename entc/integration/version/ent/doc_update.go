@@ -0,0 +1,296 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/entc/integration/version/ent/doc"
+	"entgo.io/ent/entc/integration/version/ent/predicate"
+	"entgo.io/ent/schema/field"
+)
+
+// DocUpdate is the builder for updating Doc entities.
+type DocUpdate struct {
+	config
+	hooks    []Hook
+	mutation *DocMutation
+}
+
+// Where appends a list predicates to the DocUpdate builder.
+func (_u *DocUpdate) Where(ps ...predicate.Doc) *DocUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetVersion sets the "version" field.
+func (_u *DocUpdate) SetVersion(v int64) *DocUpdate {
+	_u.mutation.ResetVersion()
+	_u.mutation.SetVersion(v)
+	return _u
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (_u *DocUpdate) SetNillableVersion(v *int64) *DocUpdate {
+	if v != nil {
+		_u.SetVersion(*v)
+	}
+	return _u
+}
+
+// AddVersion adds value to the "version" field.
+func (_u *DocUpdate) AddVersion(v int64) *DocUpdate {
+	_u.mutation.AddVersion(v)
+	return _u
+}
+
+// SetText sets the "text" field.
+func (_u *DocUpdate) SetText(v string) *DocUpdate {
+	_u.mutation.SetText(v)
+	return _u
+}
+
+// SetNillableText sets the "text" field if the given value is not nil.
+func (_u *DocUpdate) SetNillableText(v *string) *DocUpdate {
+	if v != nil {
+		_u.SetText(*v)
+	}
+	return _u
+}
+
+// Mutation returns the DocMutation object of the builder.
+func (_u *DocUpdate) Mutation() *DocMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *DocUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *DocUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *DocUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *DocUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *DocUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(doc.Table, doc.Columns, sqlgraph.NewFieldSpec(doc.FieldID, field.TypeInt))
+	if _u.mutation.Op().Is(ent.OpUpdate) {
+		return 0, errors.New("ent: bulk update of Doc is not allowed with optimistic locking, use UpdateOne instead")
+	}
+	if _, ok := _u.mutation.Version(); !ok {
+		if _, ok := _u.mutation.AddedVersion(); !ok {
+			_u.mutation.AddVersion(1)
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Version(); ok {
+		_spec.SetField(doc.FieldVersion, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedVersion(); ok {
+		_spec.AddField(doc.FieldVersion, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.Text(); ok {
+		_spec.SetField(doc.FieldText, field.TypeString, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{doc.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// DocUpdateOne is the builder for updating a single Doc entity.
+type DocUpdateOne struct {
+	config
+	fields          []string
+	expectedVersion *int64
+	hooks           []Hook
+	mutation        *DocMutation
+}
+
+// SetVersion sets the "version" field.
+func (_u *DocUpdateOne) SetVersion(v int64) *DocUpdateOne {
+	_u.mutation.ResetVersion()
+	_u.mutation.SetVersion(v)
+	return _u
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (_u *DocUpdateOne) SetNillableVersion(v *int64) *DocUpdateOne {
+	if v != nil {
+		_u.SetVersion(*v)
+	}
+	return _u
+}
+
+// AddVersion adds value to the "version" field.
+func (_u *DocUpdateOne) AddVersion(v int64) *DocUpdateOne {
+	_u.mutation.AddVersion(v)
+	return _u
+}
+
+// SetText sets the "text" field.
+func (_u *DocUpdateOne) SetText(v string) *DocUpdateOne {
+	_u.mutation.SetText(v)
+	return _u
+}
+
+// SetNillableText sets the "text" field if the given value is not nil.
+func (_u *DocUpdateOne) SetNillableText(v *string) *DocUpdateOne {
+	if v != nil {
+		_u.SetText(*v)
+	}
+	return _u
+}
+
+// Mutation returns the DocMutation object of the builder.
+func (_u *DocUpdateOne) Mutation() *DocMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the DocUpdate builder.
+func (_u *DocUpdateOne) Where(ps ...predicate.Doc) *DocUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *DocUpdateOne) Select(field string, fields ...string) *DocUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// SetExpectedVersion sets the "version" value that the Doc entity is expected to have in the database.
+// The update fails with a *sqlgraph.StaleObjectError if the entity was changed since this version. Updates of
+// loaded entities (UpdateOne) expect their loaded "version", and updates by ID (UpdateOneID) must set it.
+func (_u *DocUpdateOne) SetExpectedVersion(version int64) *DocUpdateOne {
+	_u.expectedVersion = &version
+	return _u
+}
+
+// Save executes the query and returns the updated Doc entity.
+func (_u *DocUpdateOne) Save(ctx context.Context) (*Doc, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *DocUpdateOne) SaveX(ctx context.Context) *Doc {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *DocUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *DocUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *DocUpdateOne) sqlSave(ctx context.Context) (_node *Doc, err error) {
+	_spec := sqlgraph.NewUpdateSpec(doc.Table, doc.Columns, sqlgraph.NewFieldSpec(doc.FieldID, field.TypeInt))
+	version := _u.expectedVersion
+	if version == nil {
+		return nil, errors.New("ent: missing the expected version of Doc, use UpdateOne(entity) or SetExpectedVersion")
+	}
+	_spec.Version = sqlgraph.NewFieldSpec(doc.FieldVersion, field.TypeInt64)
+	_spec.Version.Value = *version
+	if _, ok := _u.mutation.Version(); !ok {
+		if _, ok := _u.mutation.AddedVersion(); !ok {
+			_u.mutation.AddVersion(1)
+		}
+	}
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Doc.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, doc.FieldID)
+		for _, f := range fields {
+			if !doc.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != doc.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Version(); ok {
+		_spec.SetField(doc.FieldVersion, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedVersion(); ok {
+		_spec.AddField(doc.FieldVersion, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.Text(); ok {
+		_spec.SetField(doc.FieldText, field.TypeString, value)
+	}
+	_node = &Doc{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{doc.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
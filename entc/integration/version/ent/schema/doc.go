@@ -0,0 +1,34 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
+)
+
+// Doc holds the schema definition for the Doc entity.
+type Doc struct {
+	ent.Schema
+}
+
+// Mixin of the Doc.
+func (Doc) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.AnnotateFields(
+			mixin.Version{},
+			entsql.OptimisticLock(entsql.RefuseBulkUpdate()),
+		),
+	}
+}
+
+// Fields of the Doc.
+func (Doc) Fields() []ent.Field {
+	return []ent.Field{
+		field.Text("text"),
+	}
+}
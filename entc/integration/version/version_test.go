@@ -0,0 +1,65 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package version
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/entc/integration/version/ent"
+	"entgo.io/ent/entc/integration/version/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptimisticLock(t *testing.T) {
+	client, err := ent.Open("sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+	defer client.Close()
+	ctx := context.Background()
+	require.NoError(t, client.Schema.Create(ctx))
+
+	u := client.User.Create().SetName("a8m").SaveX(ctx)
+	require.EqualValues(t, 1, u.Version)
+
+	t.Log("Updates of loaded entities expect their loaded version")
+	u1 := u.Update().SetName("Ariel").SaveX(ctx)
+	require.EqualValues(t, 2, u1.Version)
+	err = u.Update().SetName("Mashraki").Exec(ctx)
+	require.True(t, sqlgraph.IsStaleObjectError(err), "u was changed since it was loaded")
+	require.Equal(t, "Ariel", client.User.GetX(ctx, u.ID).Name)
+
+	t.Log("Updates by ID must set the expected version")
+	err = client.User.UpdateOneID(u.ID).SetName("Mashraki").Exec(ctx)
+	require.EqualError(t, err, "ent: missing the expected version of User, use UpdateOne(entity) or SetExpectedVersion")
+	err = client.User.UpdateOneID(u.ID).SetExpectedVersion(1).SetName("Mashraki").Exec(ctx)
+	require.True(t, sqlgraph.IsStaleObjectError(err))
+	u2 := client.User.UpdateOneID(u.ID).SetExpectedVersion(2).SetName("Mashraki").SaveX(ctx)
+	require.EqualValues(t, 3, u2.Version)
+	err = client.User.UpdateOneID(u.ID + 1).SetExpectedVersion(1).SetName("Mashraki").Exec(ctx)
+	require.True(t, ent.IsNotFound(err))
+
+	t.Log("Bulk updates increment the versions of all matched entities")
+	client.User.Create().SetName("nati").ExecX(ctx)
+	n := client.User.Update().Where(user.NameNEQ("")).SetName("Unknown").SaveX(ctx)
+	require.Equal(t, 2, n)
+	users := client.User.Query().Order(user.ByID()).AllX(ctx)
+	require.EqualValues(t, 4, users[0].Version)
+	require.EqualValues(t, 2, users[1].Version)
+	err = u2.Update().SetName("a8m").Exec(ctx)
+	require.True(t, sqlgraph.IsStaleObjectError(err), "u2 was changed by the bulk update")
+
+	t.Log("Bulk updates are refused when configured with RefuseBulkUpdate")
+	d := client.Doc.Create().SetText("Go").SaveX(ctx)
+	err = client.Doc.Update().SetText("Ent").Exec(ctx)
+	require.EqualError(t, err, "ent: bulk update of Doc is not allowed with optimistic locking, use UpdateOne instead")
+	d1 := d.Update().SetText("Ent").SaveX(ctx)
+	require.EqualValues(t, 2, d1.Version)
+	err = d.Update().SetText("GraphQL").Exec(ctx)
+	require.True(t, sqlgraph.IsStaleObjectError(err))
+	require.Equal(t, "Ent", client.Doc.GetX(ctx, d.ID).Text)
+}
@@ -44,6 +44,8 @@ type Field struct {
 	ValueScanner     bool                    `json:"value_scanner,omitempty"`
 	Tag              string                  `json:"tag,omitempty"`
 	Size             *int64                  `json:"size,omitempty"`
+	Precision        int                     `json:"precision,omitempty"`
+	Scale            int                     `json:"scale,omitempty"`
 	Enums            []struct{ N, V string } `json:"enums,omitempty"`
 	Unique           bool                    `json:"unique,omitempty"`
 	Nillable         bool                    `json:"nillable,omitempty"`
@@ -62,6 +64,7 @@ type Field struct {
 	Comment          string                  `json:"comment,omitempty"`
 	Deprecated       bool                    `json:"deprecated,omitempty"`
 	DeprecatedReason string                  `json:"deprecated_reason,omitempty"`
+	Embedded         []*Field                `json:"embedded,omitempty"`
 }
 
 // Edge represents an ent.Edge that was loaded from a complied user package.
@@ -128,6 +131,8 @@ func NewField(fd *field.Descriptor) (*Field, error) {
 		Info:             fd.Info,
 		ValueScanner:     fd.ValueScanner != nil,
 		Tag:              fd.Tag,
+		Precision:        fd.Precision,
+		Scale:            fd.Scale,
 		Enums:            fd.Enums,
 		Unique:           fd.Unique,
 		Nillable:         fd.Nillable,
@@ -161,6 +166,13 @@ func NewField(fd *field.Descriptor) (*Field, error) {
 	if _, err := json.Marshal(fd.Default); err == nil {
 		sf.DefaultValue = fd.Default
 	}
+	for _, ed := range fd.Embedded {
+		ef, err := NewField(ed)
+		if err != nil {
+			return nil, fmt.Errorf("embedded field %q: %w", sf.Name, err)
+		}
+		sf.Embedded = append(sf.Embedded, ef)
+	}
 	return sf, nil
 }
 
@@ -409,7 +421,8 @@ func addAnnotation(annotations map[string]any, an schema.Annotation) {
 }
 
 func (f *Field) defaults() error {
-	if !f.Default || !f.Info.Numeric() || f.DefaultKind == reflect.Func {
+	// Decimal values are represented as strings.
+	if !f.Default || !f.Info.Numeric() || f.Info.Type == field.TypeDecimal || f.DefaultKind == reflect.Func {
 		return nil
 	}
 	n, ok := f.DefaultValue.(float64)
@@ -421,6 +434,9 @@ func (f *Field) defaults() error {
 		f.DefaultValue = int64(n)
 	case t >= field.TypeUint8 && t <= field.TypeUint64:
 		f.DefaultValue = uint64(n)
+	case t == field.TypeDuration:
+		// Durations are marshaled as integers (nanoseconds).
+		f.DefaultValue = int64(n)
 	}
 	return nil
 }
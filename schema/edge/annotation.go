@@ -17,6 +17,45 @@ type Annotation struct {
 	//	}
 	//
 	StructTag string
+
+	// The OnDelete option defines the action that is applied by the generated Delete builders
+	// on the neighbors of the edge, when the entities that hold it are deleted. Unlike the
+	// foreign-key actions (e.g. entsql.OnDelete), it is applied by the generated code in the
+	// same transaction, and therefore, works without foreign-keys and for all storage drivers.
+	//
+	//	edge.To("posts", Post.Type).
+	//		Annotations(edge.OnDelete(edge.Cascade))
+	//
+	OnDelete DeleteAction
+}
+
+// DeleteAction defines the action that is applied on the neighbors of
+// an edge, when the entities that hold it are deleted.
+type DeleteAction string
+
+// Delete actions that can be configured using the OnDelete annotation.
+const (
+	// Cascade deletes the neighbors of the deleted entities using their
+	// Delete builders. Hence, hooks, privacy policies and delete actions
+	// that are defined on the neighbors' schema are applied as well.
+	Cascade DeleteAction = "CASCADE"
+	// Restrict fails the deletion if any of the entities has neighbors.
+	Restrict DeleteAction = "RESTRICT"
+	// SetNull clears the edge. i.e. sets the foreign-key of the neighbors to
+	// NULL in O2M and O2O relations, and removes the rows of the join table
+	// in M2M relations.
+	SetNull DeleteAction = "SET NULL"
+)
+
+// OnDelete returns an edge annotation that configures the action that is applied
+// on the neighbors of the edge, when the entities that hold it are deleted.
+//
+//	edge.To("pets", Pet.Type).
+//		Annotations(edge.OnDelete(edge.SetNull))
+func OnDelete(action DeleteAction) *Annotation {
+	return &Annotation{
+		OnDelete: action,
+	}
 }
 
 // Name describes the annotation name.
@@ -40,6 +79,9 @@ func (a Annotation) Merge(other schema.Annotation) schema.Annotation {
 	if tag := ant.StructTag; tag != "" {
 		a.StructTag = tag
 	}
+	if action := ant.OnDelete; action != "" {
+		a.OnDelete = action
+	}
 	return a
 }
 
@@ -127,3 +127,13 @@ func TestAnnotations(t *testing.T) {
 	require.Equal(t, []schema.Annotation{GQL{Field: "from"}}, bidi.Annotations)
 	require.Equal(t, []schema.Annotation{GQL{Field: "to"}}, bidi.Ref.Annotations)
 }
+
+func TestAnnotation_Merge(t *testing.T) {
+	ant := edge.Annotation{StructTag: `json:"edges"`}
+	ant = ant.Merge(edge.OnDelete(edge.Cascade)).(edge.Annotation)
+	require.Equal(t, edge.Annotation{StructTag: `json:"edges"`, OnDelete: edge.Cascade}, ant)
+	ant = ant.Merge(edge.Annotation{OnDelete: edge.SetNull}).(edge.Annotation)
+	require.Equal(t, edge.Annotation{StructTag: `json:"edges"`, OnDelete: edge.SetNull}, ant)
+	ant = ant.Merge(GQL{Field: "to"}).(edge.Annotation)
+	require.Equal(t, edge.SetNull, ant.OnDelete)
+}
@@ -20,8 +20,9 @@ type Annotation struct {
 	//
 	StructTag map[string]string
 
-	// ID defines a multi-field schema identifier. Note,
-	// the annotation is valid only for edge schemas.
+	// ID defines a multi-field schema identifier. In edge
+	// schemas, it is composed of the two edge fields. In other
+	// schemas, it can be composed of any required and immutable fields.
 	//
 	//	func (TweetLike) Annotations() []schema.Annotation {
 	//		return []schema.Annotation{
@@ -32,8 +33,9 @@ type Annotation struct {
 	ID []string
 }
 
-// ID defines a multi-field schema identifier. Note, the
-// annotation is valid only for edge schemas.
+// ID defines a multi-field schema identifier. In edge schemas, it is
+// composed of the two edge fields. In other schemas, it can be composed
+// of any required and immutable fields. For example:
 //
 //	func (TweetLike) Annotations() []schema.Annotation {
 //		return []schema.Annotation{
@@ -41,6 +43,15 @@ type Annotation struct {
 //		}
 //	}
 //
+//	func (Order) Annotations() []schema.Annotation {
+//		return []schema.Annotation{
+//			field.ID("tenant_id", "number"),
+//		}
+//	}
+//
+// Note that foreign keys are single-column, and therefore, edges whose
+// foreign key references a type with a composite identifier (including
+// M2M edges) are not supported and rejected by the code generation.
 func ID(first, second string, fields ...string) *Annotation {
 	return &Annotation{ID: append([]string{first, second}, fields...)}
 }
@@ -0,0 +1,207 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package field
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"entgo.io/ent/schema"
+)
+
+// Decimal returns a new Field with type decimal. Decimal fields are stored as exact
+// numeric columns (e.g. numeric(p, s) in PostgreSQL or decimal(p, s) in MySQL), and
+// their precision is required. In order to keep their values exact, their Go type is
+// string by default. Use GoType to plug in a decimal type, for example:
+//
+//	field.Decimal("amount").
+//		Precision(10).
+//		Scale(2).
+//		GoType(decimal.Decimal{})
+//
+// Note that the Add<F> operation of update builders is generated only for Go
+// types that implement the "Add(T) T" method, and not for string decimals,
+// as their values cannot be summed exactly in Go.
+func Decimal(name string) *decimalBuilder {
+	return &decimalBuilder{&Descriptor{
+		Name: name,
+		Info: &TypeInfo{Type: TypeDecimal},
+	}}
+}
+
+// decimalRe matches the decimal number literals that are accepted as default values.
+var decimalRe = regexp.MustCompile(`^[-+]?(\d+(\.\d*)?|\.\d+)$`)
+
+// decimalBuilder is the builder for decimal fields.
+type decimalBuilder struct {
+	desc *Descriptor
+}
+
+// Precision sets the precision (the maximum number of digits) of the decimal column.
+// It is required for all decimal fields.
+func (b *decimalBuilder) Precision(p int) *decimalBuilder {
+	b.desc.Precision = p
+	return b
+}
+
+// Scale sets the scale (the number of digits after the decimal point) of the decimal column.
+func (b *decimalBuilder) Scale(s int) *decimalBuilder {
+	b.desc.Scale = s
+	return b
+}
+
+// Unique makes the field unique within all vertices of this type.
+func (b *decimalBuilder) Unique() *decimalBuilder {
+	b.desc.Unique = true
+	return b
+}
+
+// Default sets the default value of the field. The value is
+// expected to be a decimal number literal. e.g. "9.99".
+func (b *decimalBuilder) Default(s string) *decimalBuilder {
+	b.desc.Default = s
+	return b
+}
+
+// DefaultFunc sets the function that is applied to set the default value
+// of the field on creation. It is required for setting default values of
+// fields with a custom Go type. For example:
+//
+//	field.Decimal("amount").
+//		GoType(decimal.Decimal{}).
+//		DefaultFunc(func() decimal.Decimal {
+//			return decimal.Zero
+//		})
+func (b *decimalBuilder) DefaultFunc(fn any) *decimalBuilder {
+	b.desc.Default = fn
+	return b
+}
+
+// UpdateDefault sets the function that is applied to set default value
+// of the field on update.
+func (b *decimalBuilder) UpdateDefault(fn any) *decimalBuilder {
+	b.desc.UpdateDefault = fn
+	return b
+}
+
+// Nillable indicates that this field is a nillable.
+// Unlike "Optional" only fields, "Nillable" fields are pointers in the generated struct.
+func (b *decimalBuilder) Nillable() *decimalBuilder {
+	b.desc.Nillable = true
+	return b
+}
+
+// Comment sets the comment of the field.
+func (b *decimalBuilder) Comment(c string) *decimalBuilder {
+	b.desc.Comment = c
+	return b
+}
+
+// Optional indicates that this field is optional on create.
+// Unlike edges, fields are required by default.
+func (b *decimalBuilder) Optional() *decimalBuilder {
+	b.desc.Optional = true
+	return b
+}
+
+// Immutable indicates that this field cannot be updated.
+func (b *decimalBuilder) Immutable() *decimalBuilder {
+	b.desc.Immutable = true
+	return b
+}
+
+// StructTag sets the struct tag of the field.
+func (b *decimalBuilder) StructTag(s string) *decimalBuilder {
+	b.desc.Tag = s
+	return b
+}
+
+// Validate adds a validator for this field. Operation fails if the validation fails.
+func (b *decimalBuilder) Validate(fn func(string) error) *decimalBuilder {
+	b.desc.Validators = append(b.desc.Validators, fn)
+	return b
+}
+
+// StorageKey sets the storage key of the field.
+// In SQL dialects is the column name and Gremlin is the property.
+func (b *decimalBuilder) StorageKey(key string) *decimalBuilder {
+	b.desc.StorageKey = key
+	return b
+}
+
+// SchemaType overrides the default database type with a custom
+// schema type (per dialect) for decimal.
+//
+//	field.Decimal("amount").
+//		SchemaType(map[string]string{
+//			dialect.MySQL:		"decimal(5, 2)",
+//			dialect.Postgres: 	"money",
+//		})
+func (b *decimalBuilder) SchemaType(types map[string]string) *decimalBuilder {
+	b.desc.SchemaType = types
+	return b
+}
+
+// GoType overrides the default Go type with a custom one. The type
+// is expected to be a string-based type, or to implement the
+// sql.Scanner and driver.Valuer interfaces.
+//
+//	field.Decimal("amount").
+//		GoType(decimal.Decimal{})
+//
+// Note that, the custom Go type `T` needs to implement the
+// `Add(T) T` method in order to support the `Add<F>` operation
+// in mutations.
+func (b *decimalBuilder) GoType(typ any) *decimalBuilder {
+	b.desc.goType(typ)
+	return b
+}
+
+// ValueScanner provides an external value scanner for the given GoType.
+// Using this option allow users to use field types that do not implement
+// the sql.Scanner and driver.Valuer interfaces.
+func (b *decimalBuilder) ValueScanner(vs any) *decimalBuilder {
+	b.desc.ValueScanner = vs
+	return b
+}
+
+// Annotations adds a list of annotations to the field object to be used by
+// codegen extensions.
+func (b *decimalBuilder) Annotations(annotations ...schema.Annotation) *decimalBuilder {
+	b.desc.Annotations = append(b.desc.Annotations, annotations...)
+	return b
+}
+
+// Deprecated marks the field as deprecated. Deprecated fields are not
+// selected by default in queries, and their struct fields are annotated
+// with `deprecated` in the generated code.
+func (b *decimalBuilder) Deprecated(reason ...string) *decimalBuilder {
+	b.desc.Deprecated = true
+	if len(reason) > 0 {
+		b.desc.DeprecatedReason = strings.Join(reason, " ")
+	}
+	return b
+}
+
+// Descriptor implements the ent.Field interface by returning its descriptor.
+func (b *decimalBuilder) Descriptor() *Descriptor {
+	switch p, s := b.desc.Precision, b.desc.Scale; {
+	case p < 0 || s < 0:
+		b.desc.Err = fmt.Errorf("invalid decimal precision (%d) or scale (%d)", p, s)
+	case p == 0:
+		b.desc.Err = fmt.Errorf("decimal field %q requires a precision", b.desc.Name)
+	case s > p:
+		b.desc.Err = fmt.Errorf("decimal scale (%d) cannot be greater than its precision (%d)", s, p)
+	}
+	if s, ok := b.desc.Default.(string); ok && !decimalRe.MatchString(s) {
+		b.desc.Err = fmt.Errorf("invalid default value for decimal field %q: %q", b.desc.Name, s)
+	}
+	if b.desc.Default != nil || b.desc.UpdateDefault != nil {
+		b.desc.checkDefaultFunc(stringType)
+	}
+	b.desc.checkGoType(stringType)
+	return b.desc
+}
@@ -0,0 +1,189 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package field
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+
+	"entgo.io/ent/schema"
+)
+
+// Duration returns a new Field with type time.Duration. In SQL dialects, durations
+// are stored as interval in PostgreSQL, and as bigint (nanoseconds) in other dialects.
+// Use the entsql.DurationFormat annotation for changing the storage format.
+//
+//	field.Duration("timeout").
+//		Default(30 * time.Second)
+func Duration(name string) *durationBuilder {
+	return &durationBuilder{&Descriptor{
+		Name: name,
+		Info: &TypeInfo{Type: TypeDuration, PkgPath: "time"},
+	}}
+}
+
+// durationBuilder is the builder for duration fields.
+type durationBuilder struct {
+	desc *Descriptor
+}
+
+// Unique makes the field unique within all vertices of this type.
+func (b *durationBuilder) Unique() *durationBuilder {
+	b.desc.Unique = true
+	return b
+}
+
+// Range adds a range validator for this field where the given value needs to be in the range of [i, j].
+func (b *durationBuilder) Range(i, j time.Duration) *durationBuilder {
+	b.desc.Validators = append(b.desc.Validators, func(v time.Duration) error {
+		if v < i || v > j {
+			return errors.New("value out of range")
+		}
+		return nil
+	})
+	return b
+}
+
+// Min adds a minimum value validator for this field. Operation fails if the validator fails.
+func (b *durationBuilder) Min(i time.Duration) *durationBuilder {
+	b.desc.Validators = append(b.desc.Validators, func(v time.Duration) error {
+		if v < i {
+			return errors.New("value out of range")
+		}
+		return nil
+	})
+	return b
+}
+
+// Max adds a maximum value validator for this field. Operation fails if the validator fails.
+func (b *durationBuilder) Max(i time.Duration) *durationBuilder {
+	b.desc.Validators = append(b.desc.Validators, func(v time.Duration) error {
+		if v > i {
+			return errors.New("value out of range")
+		}
+		return nil
+	})
+	return b
+}
+
+// Positive adds a minimum value validator with the value of 1ns. Operation fails if the validator fails.
+func (b *durationBuilder) Positive() *durationBuilder {
+	return b.Min(1)
+}
+
+// NonNegative adds a minimum value validator with the value of 0. Operation fails if the validator fails.
+func (b *durationBuilder) NonNegative() *durationBuilder {
+	return b.Min(0)
+}
+
+// Default sets the default value of the field.
+func (b *durationBuilder) Default(d time.Duration) *durationBuilder {
+	b.desc.Default = d
+	return b
+}
+
+// DefaultFunc sets the function that is applied to set the default value
+// of the field on creation.
+func (b *durationBuilder) DefaultFunc(fn any) *durationBuilder {
+	b.desc.Default = fn
+	return b
+}
+
+// UpdateDefault sets the function that is applied to set default value
+// of the field on update.
+func (b *durationBuilder) UpdateDefault(fn any) *durationBuilder {
+	b.desc.UpdateDefault = fn
+	return b
+}
+
+// Nillable indicates that this field is a nillable.
+// Unlike "Optional" only fields, "Nillable" fields are pointers in the generated struct.
+func (b *durationBuilder) Nillable() *durationBuilder {
+	b.desc.Nillable = true
+	return b
+}
+
+// Comment sets the comment of the field.
+func (b *durationBuilder) Comment(c string) *durationBuilder {
+	b.desc.Comment = c
+	return b
+}
+
+// Optional indicates that this field is optional on create.
+// Unlike edges, fields are required by default.
+func (b *durationBuilder) Optional() *durationBuilder {
+	b.desc.Optional = true
+	return b
+}
+
+// Immutable indicates that this field cannot be updated.
+func (b *durationBuilder) Immutable() *durationBuilder {
+	b.desc.Immutable = true
+	return b
+}
+
+// StructTag sets the struct tag of the field.
+func (b *durationBuilder) StructTag(s string) *durationBuilder {
+	b.desc.Tag = s
+	return b
+}
+
+// Validate adds a validator for this field. Operation fails if the validation fails.
+func (b *durationBuilder) Validate(fn func(time.Duration) error) *durationBuilder {
+	b.desc.Validators = append(b.desc.Validators, fn)
+	return b
+}
+
+// StorageKey sets the storage key of the field.
+// In SQL dialects is the column name and Gremlin is the property.
+func (b *durationBuilder) StorageKey(key string) *durationBuilder {
+	b.desc.StorageKey = key
+	return b
+}
+
+// SchemaType overrides the default database type with a custom
+// schema type (per dialect) for duration.
+//
+//	field.Duration("timeout").
+//		SchemaType(map[string]string{
+//			dialect.Postgres: "interval second(0)",
+//		})
+func (b *durationBuilder) SchemaType(types map[string]string) *durationBuilder {
+	b.desc.SchemaType = types
+	return b
+}
+
+// Annotations adds a list of annotations to the field object to be used by
+// codegen extensions.
+//
+//	field.Duration("timeout").
+//		Annotations(entsql.DurationFormat(sql.DurationTime))
+func (b *durationBuilder) Annotations(annotations ...schema.Annotation) *durationBuilder {
+	b.desc.Annotations = append(b.desc.Annotations, annotations...)
+	return b
+}
+
+// Deprecated marks the field as deprecated. Deprecated fields are not
+// selected by default in queries, and their struct fields are annotated
+// with `deprecated` in the generated code.
+func (b *durationBuilder) Deprecated(reason ...string) *durationBuilder {
+	b.desc.Deprecated = true
+	if len(reason) > 0 {
+		b.desc.DeprecatedReason = strings.Join(reason, " ")
+	}
+	return b
+}
+
+// Descriptor implements the ent.Field interface by returning its descriptor.
+func (b *durationBuilder) Descriptor() *Descriptor {
+	if b.desc.Default != nil || b.desc.UpdateDefault != nil {
+		b.desc.checkDefaultFunc(durationType)
+	}
+	return b.desc
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
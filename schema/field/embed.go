@@ -0,0 +1,128 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package field
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Embed returns a new Field that embeds a value object (a Go struct) into the schema.
+// Unlike JSON fields, each exported field of the struct is stored in its own column,
+// and the columns are prefixed with the name of the embedded field. For example:
+//
+//	type Address struct {
+//		Street string
+//		City   string
+//		Zip    string
+//	}
+//
+//	field.Embed("address", Address{})
+//
+// The field above is stored in the "address_street", "address_city" and "address_zip"
+// columns. The generated setters, getters and predicates accept the Address struct as
+// a whole, and the columns can be set and queried separately as well.
+//
+// The struct fields must be of type string, bool, []byte, time.Time, time.Duration,
+// or of a numeric type. Types that are defined on top of these types (e.g. type Zip
+// string) are supported as well, and they are treated as a GoType.
+func Embed(name string, typ any) *embedBuilder {
+	b := &embedBuilder{&Descriptor{
+		Name: name,
+		Info: &TypeInfo{},
+	}}
+	t := reflect.TypeOf(typ)
+	if t == nil || t.Kind() != reflect.Struct {
+		b.desc.Err = fmt.Errorf("expect a struct type for embedded field %q, got %T", name, typ)
+		return b
+	}
+	b.desc.goType(typ)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		fd, err := embedField(sf)
+		if err != nil {
+			b.desc.Err = fmt.Errorf("embedded field %q: %w", name, err)
+			return b
+		}
+		b.desc.Embedded = append(b.desc.Embedded, fd)
+	}
+	if len(b.desc.Embedded) == 0 {
+		b.desc.Err = fmt.Errorf("embedded field %q: struct %s has no exported fields", name, t)
+	}
+	return b
+}
+
+// embedBuilder is the builder for embedded fields.
+type embedBuilder struct {
+	desc *Descriptor
+}
+
+// Optional indicates that this field is optional on create. The columns of
+// optional embedded fields are nullable, and the embedded value is nil if
+// all its columns are NULL.
+func (b *embedBuilder) Optional() *embedBuilder {
+	b.desc.Optional = true
+	return b
+}
+
+// Immutable indicates that this field cannot be updated.
+func (b *embedBuilder) Immutable() *embedBuilder {
+	b.desc.Immutable = true
+	return b
+}
+
+// Comment sets the comment of the field.
+func (b *embedBuilder) Comment(c string) *embedBuilder {
+	b.desc.Comment = c
+	return b
+}
+
+// Descriptor implements the ent.Field interface by returning its descriptor.
+func (b *embedBuilder) Descriptor() *Descriptor {
+	return b.desc
+}
+
+// embedKinds maps the kinds of struct fields to their field types.
+var embedKinds = map[reflect.Kind]Type{
+	reflect.Bool:    TypeBool,
+	reflect.String:  TypeString,
+	reflect.Int:     TypeInt,
+	reflect.Int8:    TypeInt8,
+	reflect.Int16:   TypeInt16,
+	reflect.Int32:   TypeInt32,
+	reflect.Int64:   TypeInt64,
+	reflect.Uint:    TypeUint,
+	reflect.Uint8:   TypeUint8,
+	reflect.Uint16:  TypeUint16,
+	reflect.Uint32:  TypeUint32,
+	reflect.Uint64:  TypeUint64,
+	reflect.Float32: TypeFloat32,
+	reflect.Float64: TypeFloat64,
+}
+
+// embedField returns the descriptor of the given struct field.
+func embedField(sf reflect.StructField) (*Descriptor, error) {
+	d := &Descriptor{Name: sf.Name}
+	switch t := sf.Type; {
+	case t == durationType:
+		d.Info = &TypeInfo{Type: TypeDuration, PkgPath: "time"}
+	case t == timeType:
+		d.Info = &TypeInfo{Type: TypeTime, PkgPath: "time"}
+	case t == bytesType:
+		d.Info = &TypeInfo{Type: TypeBytes, Nillable: true}
+	case embedKinds[t.Kind()] != TypeInvalid:
+		d.Info = &TypeInfo{Type: embedKinds[t.Kind()]}
+		// A type that was defined on top of a basic type.
+		if t.PkgPath() != "" {
+			d.goType(reflect.Zero(t).Interface())
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type %s for struct field %q", sf.Type, sf.Name)
+	}
+	return d, nil
+}
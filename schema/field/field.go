@@ -1395,6 +1395,8 @@ func (b *otherBuilder) Descriptor() *Descriptor {
 type Descriptor struct {
 	Tag              string                  // struct tag.
 	Size             int                     // varchar size.
+	Precision        int                     // decimal precision.
+	Scale            int                     // decimal scale.
 	Name             string                  // field name.
 	Info             *TypeInfo               // field type info.
 	ValueScanner     any                     // custom field codec.
@@ -1413,6 +1415,7 @@ type Descriptor struct {
 	Comment          string                  // field comment.
 	Deprecated       bool                    // mark the field as deprecated.
 	DeprecatedReason string                  // deprecation reason.
+	Embedded         []*Descriptor           // embedded struct fields.
 	Err              error
 }
 
@@ -181,6 +181,102 @@ func TestFloat_DefaultFunc(t *testing.T) {
 	assert.NoError(t, fd.Err)
 }
 
+func TestDecimal(t *testing.T) {
+	fd := field.Decimal("amount").Precision(10).Scale(2).Default("1.50").Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.Equal(t, "amount", fd.Name)
+	assert.Equal(t, field.TypeDecimal, fd.Info.Type)
+	assert.Equal(t, "string", fd.Info.String())
+	assert.Equal(t, "TypeDecimal", fd.Info.ConstName())
+	assert.True(t, fd.Info.Numeric())
+	assert.False(t, fd.Info.Type.Integer())
+	assert.Equal(t, 10, fd.Precision)
+	assert.Equal(t, 2, fd.Scale)
+	assert.Equal(t, "1.50", fd.Default)
+
+	fd = field.Decimal("amount").Precision(10).GoType(&sql.NullString{}).Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.True(t, fd.Info.ValueScanner())
+	fd = field.Decimal("amount").Precision(10).GoType(struct{}{}).Descriptor()
+	assert.Error(t, fd.Err)
+	fd = field.Decimal("amount").Precision(10).GoType(1.5).Descriptor()
+	assert.Error(t, fd.Err, "float types are not exact")
+	fd = field.Decimal("amount").Precision(10).Default("1.5e3").Descriptor()
+	assert.EqualError(t, fd.Err, `invalid default value for decimal field "amount": "1.5e3"`)
+
+	fd = field.Decimal("amount").Descriptor()
+	assert.EqualError(t, fd.Err, `decimal field "amount" requires a precision`)
+	fd = field.Decimal("amount").Scale(2).Descriptor()
+	assert.EqualError(t, fd.Err, `decimal field "amount" requires a precision`)
+	fd = field.Decimal("amount").Precision(2).Scale(3).Descriptor()
+	assert.EqualError(t, fd.Err, "decimal scale (3) cannot be greater than its precision (2)")
+	fd = field.Decimal("amount").Precision(-1).Descriptor()
+	assert.Error(t, fd.Err)
+}
+
+func TestDuration(t *testing.T) {
+	fd := field.Duration("timeout").Default(time.Minute).Range(time.Second, time.Hour).Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.Equal(t, "timeout", fd.Name)
+	assert.Equal(t, field.TypeDuration, fd.Info.Type)
+	assert.Equal(t, "time.Duration", fd.Info.String())
+	assert.Equal(t, "TypeDuration", fd.Info.ConstName())
+	assert.Equal(t, "time", fd.Info.PkgPath)
+	assert.True(t, fd.Info.Numeric())
+	assert.False(t, fd.Info.Type.Integer())
+	assert.Equal(t, time.Minute, fd.Default)
+	assert.Len(t, fd.Validators, 1)
+	assert.Error(t, fd.Validators[0].(func(time.Duration) error)(2*time.Hour))
+	assert.NoError(t, fd.Validators[0].(func(time.Duration) error)(time.Minute))
+
+	fd = field.Duration("timeout").DefaultFunc(func() time.Duration { return time.Second }).Descriptor()
+	assert.NoError(t, fd.Err)
+	fd = field.Duration("timeout").DefaultFunc(func() int64 { return 1 }).Descriptor()
+	assert.Error(t, fd.Err)
+}
+
+func TestEmbed(t *testing.T) {
+	type Zip string
+	type Address struct {
+		Street  string
+		Zip     Zip
+		Lines   []byte
+		Updated time.Time
+		TTL     time.Duration
+		Floor   uint8
+		private int
+	}
+	fd := field.Embed("address", Address{}).Optional().Immutable().Comment("comment").Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.Equal(t, "address", fd.Name)
+	assert.Equal(t, "field_test.Address", fd.Info.String())
+	assert.Equal(t, "entgo.io/ent/schema/field_test", fd.Info.PkgPath)
+	assert.Equal(t, reflect.Struct, fd.Info.RType.Kind)
+	assert.True(t, fd.Optional)
+	assert.True(t, fd.Immutable)
+	assert.Equal(t, "comment", fd.Comment)
+	assert.Len(t, fd.Embedded, 6)
+	names := make([]string, len(fd.Embedded))
+	for i, e := range fd.Embedded {
+		names[i] = e.Name
+	}
+	assert.Equal(t, []string{"Street", "Zip", "Lines", "Updated", "TTL", "Floor"}, names)
+	assert.Equal(t, field.TypeString, fd.Embedded[0].Info.Type)
+	assert.Equal(t, field.TypeString, fd.Embedded[1].Info.Type)
+	assert.Equal(t, "field_test.Zip", fd.Embedded[1].Info.String())
+	assert.Equal(t, field.TypeBytes, fd.Embedded[2].Info.Type)
+	assert.Equal(t, field.TypeTime, fd.Embedded[3].Info.Type)
+	assert.Equal(t, field.TypeDuration, fd.Embedded[4].Info.Type)
+	assert.Equal(t, field.TypeUint8, fd.Embedded[5].Info.Type)
+
+	fd = field.Embed("address", &Address{}).Descriptor()
+	assert.EqualError(t, fd.Err, `expect a struct type for embedded field "address", got *field_test.Address`)
+	fd = field.Embed("address", struct{ Tags []string }{}).Descriptor()
+	assert.EqualError(t, fd.Err, `embedded field "address": unsupported type []string for struct field "Tags"`)
+	fd = field.Embed("address", struct{ private int }{}).Descriptor()
+	assert.Error(t, fd.Err)
+}
+
 func TestBool(t *testing.T) {
 	fd := field.Bool("active").Default(true).Comment("comment").Immutable().Descriptor()
 	assert.Equal(t, "active", fd.Name)
@@ -943,7 +1039,7 @@ func TestTypeString(t *testing.T) {
 	assert.Equal(t, "bool", typ.String())
 	typ = field.TypeInvalid
 	assert.Equal(t, "invalid", typ.String())
-	typ = 21
+	typ = 23
 	assert.Equal(t, "invalid", typ.String())
 }
 
@@ -959,7 +1055,7 @@ func TestTypeValid(t *testing.T) {
 	assert.True(t, typ.Valid())
 	typ = 0
 	assert.False(t, typ.Valid())
-	typ = 21
+	typ = 23
 	assert.False(t, typ.Valid())
 }
 
@@ -972,6 +1068,6 @@ func TestTypeConstName(t *testing.T) {
 	assert.Equal(t, "TypeInt64", typ.ConstName())
 	typ = field.TypeOther
 	assert.Equal(t, "TypeOther", typ.ConstName())
-	typ = 21
+	typ = 23
 	assert.Equal(t, "invalid", typ.ConstName())
 }
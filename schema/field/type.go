@@ -36,6 +36,8 @@ const (
 	TypeUint64
 	TypeFloat32
 	TypeFloat64
+	TypeDecimal
+	TypeDuration
 	endTypes
 )
 
@@ -59,7 +61,7 @@ func (t Type) Float() bool {
 
 // Integer reports if the given type is an integral type.
 func (t Type) Integer() bool {
-	return t.Numeric() && !t.Float()
+	return t.Numeric() && !t.Float() && t != TypeDecimal && t != TypeDuration
 }
 
 // Valid reports if the given type if known type.
@@ -157,35 +159,39 @@ func (t TypeInfo) Stringer() bool {
 
 var (
 	typeNames = [...]string{
-		TypeInvalid: "invalid",
-		TypeBool:    "bool",
-		TypeTime:    "time.Time",
-		TypeJSON:    "json.RawMessage",
-		TypeUUID:    "[16]byte",
-		TypeBytes:   "[]byte",
-		TypeEnum:    "string",
-		TypeString:  "string",
-		TypeOther:   "other",
-		TypeInt:     "int",
-		TypeInt8:    "int8",
-		TypeInt16:   "int16",
-		TypeInt32:   "int32",
-		TypeInt64:   "int64",
-		TypeUint:    "uint",
-		TypeUint8:   "uint8",
-		TypeUint16:  "uint16",
-		TypeUint32:  "uint32",
-		TypeUint64:  "uint64",
-		TypeFloat32: "float32",
-		TypeFloat64: "float64",
+		TypeInvalid:  "invalid",
+		TypeBool:     "bool",
+		TypeTime:     "time.Time",
+		TypeJSON:     "json.RawMessage",
+		TypeUUID:     "[16]byte",
+		TypeBytes:    "[]byte",
+		TypeEnum:     "string",
+		TypeString:   "string",
+		TypeOther:    "other",
+		TypeInt:      "int",
+		TypeInt8:     "int8",
+		TypeInt16:    "int16",
+		TypeInt32:    "int32",
+		TypeInt64:    "int64",
+		TypeUint:     "uint",
+		TypeUint8:    "uint8",
+		TypeUint16:   "uint16",
+		TypeUint32:   "uint32",
+		TypeUint64:   "uint64",
+		TypeFloat32:  "float32",
+		TypeFloat64:  "float64",
+		TypeDecimal:  "string",
+		TypeDuration: "time.Duration",
 	}
 	constNames = [...]string{
-		TypeJSON:  "TypeJSON",
-		TypeUUID:  "TypeUUID",
-		TypeTime:  "TypeTime",
-		TypeEnum:  "TypeEnum",
-		TypeBytes: "TypeBytes",
-		TypeOther: "TypeOther",
+		TypeJSON:     "TypeJSON",
+		TypeUUID:     "TypeUUID",
+		TypeTime:     "TypeTime",
+		TypeEnum:     "TypeEnum",
+		TypeBytes:    "TypeBytes",
+		TypeOther:    "TypeOther",
+		TypeDecimal:  "TypeDecimal",
+		TypeDuration: "TypeDuration",
 	}
 )
 
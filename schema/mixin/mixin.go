@@ -8,8 +8,10 @@ import (
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 )
 
 // Schema is the default implementation for the ent.Mixin interface.
@@ -88,6 +90,54 @@ func (Time) Fields() []ent.Field {
 // time mixin must implement `Mixin` interface.
 var _ ent.Mixin = (*Time)(nil)
 
+// Version adds a version field that is used for optimistic locking.
+// See entsql.OptimisticLock for more info.
+type Version struct{ Schema }
+
+// Fields of the version mixin.
+func (Version) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("version").
+			Default(1).
+			Annotations(
+				entsql.OptimisticLock(),
+			),
+	}
+}
+
+// version mixin must implement `Mixin` interface.
+var _ ent.Mixin = (*Version)(nil)
+
+// SoftDelete adds a deleted_at field that marks entities as deleted instead of
+// deleting them from the database. See entsql.SoftDelete for more info.
+type SoftDelete struct{ Schema }
+
+// Fields of the soft delete mixin.
+func (SoftDelete) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time("deleted_at").
+			Optional().
+			Nillable().
+			Annotations(
+				entsql.SoftDelete(),
+			),
+	}
+}
+
+// soft delete mixin must implement `Mixin` interface.
+var _ ent.Mixin = (*SoftDelete)(nil)
+
+// UniqueNotDeleted returns a unique index on the given fields that covers only the entities
+// that were not soft-deleted (i.e. a partial index). It allows creating an entity with the same
+// values as an entity that was soft-deleted. Note that partial indexes are not supported by MySQL.
+func UniqueNotDeleted(fields ...string) ent.Index {
+	return index.Fields(fields...).
+		Unique().
+		Annotations(
+			entsql.IndexWhere("deleted_at IS NULL"),
+		)
+}
+
 // AnnotateFields adds field annotations to underlying mixin fields.
 func AnnotateFields(m ent.Mixin, annotations ...schema.Annotation) ent.Mixin {
 	return fieldAnnotator{Mixin: m, annotations: annotations}
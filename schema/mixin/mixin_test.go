@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/mixin"
@@ -45,6 +46,38 @@ func TestTimeMixin(t *testing.T) {
 	})
 }
 
+func TestVersionMixin(t *testing.T) {
+	fields := mixin.Version{}.Fields()
+	require.Len(t, fields, 1)
+	desc := fields[0].Descriptor()
+	assert.Equal(t, "version", desc.Name)
+	assert.EqualValues(t, 1, desc.Default)
+	require.Len(t, desc.Annotations, 1)
+	ant, ok := desc.Annotations[0].(*entsql.Annotation)
+	require.True(t, ok)
+	require.NotNil(t, ant.OptimisticLock)
+	assert.False(t, ant.OptimisticLock.RefuseBulkUpdate)
+}
+
+func TestSoftDeleteMixin(t *testing.T) {
+	fields := mixin.SoftDelete{}.Fields()
+	require.Len(t, fields, 1)
+	desc := fields[0].Descriptor()
+	assert.Equal(t, "deleted_at", desc.Name)
+	assert.True(t, desc.Optional)
+	assert.True(t, desc.Nillable)
+	require.Len(t, desc.Annotations, 1)
+	ant, ok := desc.Annotations[0].(*entsql.Annotation)
+	require.True(t, ok)
+	assert.True(t, ant.SoftDelete)
+
+	idx := mixin.UniqueNotDeleted("name", "owner").Descriptor()
+	assert.True(t, idx.Unique)
+	assert.Equal(t, []string{"name", "owner"}, idx.Fields)
+	require.Len(t, idx.Annotations, 1)
+	assert.Equal(t, "deleted_at IS NULL", idx.Annotations[0].(*entsql.IndexAnnotation).Where)
+}
+
 type annotation string
 
 func (annotation) Name() string { return "" }